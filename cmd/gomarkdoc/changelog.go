@@ -0,0 +1,412 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/module"
+
+	"github.com/princjef/gomarkdoc/format"
+	"github.com/princjef/gomarkdoc/lang"
+	"github.com/princjef/gomarkdoc/logger"
+)
+
+type changelogOptions struct {
+	from      string
+	to        string
+	output    string
+	format    string
+	check     bool
+	verbosity int
+}
+
+// packageChanges holds the API changes to a single package between two
+// revisions.
+type packageChanges struct {
+	importPath string
+	changes    []*lang.APIChange
+	majorBump  bool
+}
+
+func buildChangelogCommand() *cobra.Command {
+	var opts changelogOptions
+
+	var command = &cobra.Command{
+		Use:   "changelog --from <revision> [package ...]",
+		Short: "report the changes to the exported API of packages between two git revisions",
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				// Default to current directory
+				args = []string{"."}
+			}
+
+			return runChangelog(args, opts)
+		},
+	}
+
+	command.Flags().StringVar(
+		&opts.from,
+		"from",
+		"",
+		"Git revision with the old version of the packages, such as a tag, branch or commit.",
+	)
+	command.Flags().StringVar(
+		&opts.to,
+		"to",
+		"HEAD",
+		"Git revision with the new version of the packages.",
+	)
+	command.Flags().StringVarP(
+		&opts.output,
+		"output",
+		"o",
+		"",
+		"File to write the changelog to. Defaults to printing to stdout.",
+	)
+	command.Flags().StringVarP(
+		&opts.format,
+		"format",
+		"f",
+		"github",
+		"Format to use for writing output data. Valid options: github (default), azure-devops, gitlab, plain",
+	)
+	command.Flags().BoolVarP(
+		&opts.check,
+		"check",
+		"c",
+		false,
+		"Check that the API changes are compatible, failing if there are breaking changes without a new major version "+
+			"in the module path.",
+	)
+	command.Flags().CountVarP(
+		&opts.verbosity,
+		"verbose",
+		"v",
+		"Log additional output from the execution of the command. Can be chained for additional verbosity.",
+	)
+
+	_ = command.MarkFlagRequired("from")
+
+	return command
+}
+
+func runChangelog(paths []string, opts changelogOptions) error {
+	log := logger.New(getLogLevel(opts.verbosity))
+
+	f, err := resolveFormat(opts.format)
+	if err != nil {
+		return err
+	}
+
+	from, err := lang.NewRevision(log, ".", opts.from)
+	if err != nil {
+		return err
+	}
+
+	to, err := lang.NewRevision(log, ".", opts.to)
+	if err != nil {
+		return err
+	}
+
+	var pkgs []packageChanges
+	for _, p := range paths {
+		dir, recursive := filepath.FromSlash(p), false
+		if trimmed := strings.TrimSuffix(dir, string(os.PathSeparator)+"..."); trimmed != dir {
+			dir, recursive = trimmed, true
+		}
+
+		if !isLocalPath(dir) {
+			return fmt.Errorf("gomarkdoc: changelogs can only be generated for local directories: %s", p)
+		}
+
+		changes, err := changelogPackages(from, to, dir, recursive, opts.check)
+		if err != nil {
+			return err
+		}
+
+		pkgs = append(pkgs, changes...)
+	}
+
+	if opts.check {
+		return checkCompatibility(pkgs)
+	}
+
+	text, err := changelogText(f, from.Name(), to.Name(), pkgs)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "" {
+		fmt.Fprint(os.Stdout, text)
+		return nil
+	}
+
+	if err := writeFile(opts.output, text); err != nil {
+		return fmt.Errorf("gomarkdoc: failed to write output file %s: %w", opts.output, err)
+	}
+
+	return nil
+}
+
+// changelogPackages compares the packages in the provided directory between the
+// two revisions. Packages that only exist in one of the revisions are compared
+// against an empty package, and packages without changes are left out. If
+// classify is true, the changes are classified as compatible or breaking.
+func changelogPackages(from, to *lang.Revision, dir string, recursive, classify bool) ([]packageChanges, error) {
+	fromDirs, err := from.PackageDirs(dir, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	toDirs, err := to.PackageDirs(dir, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	inFrom := make(map[string]bool)
+	inTo := make(map[string]bool)
+	for _, d := range fromDirs {
+		inFrom[d] = true
+		dirs = append(dirs, d)
+	}
+
+	for _, d := range toDirs {
+		inTo[d] = true
+		if !inFrom[d] {
+			dirs = append(dirs, d)
+		}
+	}
+
+	sort.Strings(dirs)
+
+	var res []packageChanges
+	for _, d := range dirs {
+		var oldPkg, newPkg *lang.Package
+		if inFrom[d] {
+			if oldPkg, err = from.Package(d); err != nil {
+				return nil, err
+			}
+		}
+
+		if inTo[d] {
+			if newPkg, err = to.Package(d); err != nil {
+				return nil, err
+			}
+		}
+
+		var changes []*lang.APIChange
+		if classify {
+			changes = lang.CheckCompatibility(oldPkg, newPkg)
+		} else {
+			changes = lang.DiffPackages(oldPkg, newPkg)
+		}
+
+		if len(changes) == 0 {
+			continue
+		}
+
+		pkg := newPkg
+		if pkg == nil {
+			pkg = oldPkg
+		}
+
+		majorBump, err := isMajorBump(from, to, d)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, packageChanges{pkg.ImportPath(), changes, majorBump})
+	}
+
+	return res, nil
+}
+
+// isMajorBump identifies whether the major version in the path of the module
+// containing the directory changed between the revisions (e.g. from
+// example.com/mod to example.com/mod/v2).
+func isMajorBump(from, to *lang.Revision, dir string) (bool, error) {
+	fromPath, err := from.ModulePath(dir)
+	if err != nil {
+		return false, err
+	}
+
+	toPath, err := to.ModulePath(dir)
+	if err != nil {
+		return false, err
+	}
+
+	return majorVersion(fromPath) != majorVersion(toPath), nil
+}
+
+// majorVersion provides the major version suffix of the module path, such as
+// v2 for example.com/mod/v2, or an empty string for modules without one.
+func majorVersion(modPath string) string {
+	_, pathMajor, ok := module.SplitPathVersion(modPath)
+	if !ok {
+		return ""
+	}
+
+	return module.PathMajorPrefix(pathMajor)
+}
+
+// checkCompatibility reports the breaking changes to packages whose module
+// didn't get a new major version to stderr, failing if there are any.
+func checkCompatibility(pkgs []packageChanges) error {
+	var failed bool
+	for _, pkg := range pkgs {
+		if pkg.majorBump {
+			continue
+		}
+
+		for _, c := range pkg.changes {
+			if c.Breaking() {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", pkg.importPath, c.BreakingReason())
+				failed = true
+			}
+		}
+	}
+
+	if failed {
+		return errors.New("gomarkdoc: breaking API changes require a new major version of the module")
+	}
+
+	return nil
+}
+
+// changelogText renders the changes to the packages as a markdown report with
+// a section for each package listing the symbols that were added and removed,
+// followed by a diff of the declarations of the symbols that changed.
+func changelogText(f format.Format, from, to string, pkgs []packageChanges) (string, error) {
+	var b strings.Builder
+	write := func(text string, err error) error {
+		if err != nil {
+			return err
+		}
+
+		b.WriteString(text)
+		b.WriteString("\n\n")
+		return nil
+	}
+
+	if err := write(f.Header(1, fmt.Sprintf("API changes from %s to %s", from, to))); err != nil {
+		return "", err
+	}
+
+	if len(pkgs) == 0 {
+		if err := write("No API changes.", nil); err != nil {
+			return "", err
+		}
+	}
+
+	for _, pkg := range pkgs {
+		if err := write(f.Header(2, pkg.importPath)); err != nil {
+			return "", err
+		}
+
+		for _, kind := range []lang.ChangeKind{lang.AddedChange, lang.RemovedChange, lang.ModifiedChange} {
+			var entries []string
+			for _, c := range pkg.changes {
+				if c.Kind() != kind {
+					continue
+				}
+
+				var text string
+				var err error
+				if kind == lang.ModifiedChange {
+					text, err = changedSymbolText(f, c)
+				} else {
+					text, err = changedSymbolEntry(f, c)
+				}
+
+				if err != nil {
+					return "", err
+				}
+
+				entries = append(entries, text)
+			}
+
+			if len(entries) == 0 {
+				continue
+			}
+
+			title := strings.ToUpper(kind.String()[:1]) + kind.String()[1:]
+			if err := write(f.Header(3, title)); err != nil {
+				return "", err
+			}
+
+			sep := "\n\n"
+			if kind != lang.ModifiedChange {
+				sep = "\n"
+			}
+
+			if err := write(strings.Join(entries, sep), nil); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return strings.TrimSpace(b.String()) + "\n", nil
+}
+
+// changedSymbolEntry renders an added or removed symbol as a list entry.
+func changedSymbolEntry(f format.Format, c *lang.APIChange) (string, error) {
+	code, err := format.Code(f, c.Title())
+	if err != nil {
+		return "", err
+	}
+
+	return f.ListEntry(0, code)
+}
+
+// changedSymbolText renders a symbol whose declaration changed as a header
+// followed by a diff of the old and new declarations.
+func changedSymbolText(f format.Format, c *lang.APIChange) (string, error) {
+	header, err := f.Header(4, c.Name())
+	if err != nil {
+		return "", err
+	}
+
+	diff, err := f.CodeBlock("diff", declDiff(c.Old(), c.New()))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s\n\n%s", header, diff), nil
+}
+
+// declDiff produces a line-based diff of the two declarations, with each line
+// prefixed by "-" if it was removed, "+" if it was added or a space if it is
+// unchanged.
+func declDiff(old, new string) string {
+	differ := diffmatchpatch.New()
+	a, b, lines := differ.DiffLinesToChars(strings.TrimSuffix(old, "\n")+"\n", strings.TrimSuffix(new, "\n")+"\n")
+	diffs := differ.DiffCharsToLines(differ.DiffMain(a, b, false), lines)
+
+	var out strings.Builder
+	for _, d := range diffs {
+		prefix := " "
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix = "-"
+		case diffmatchpatch.DiffInsert:
+			prefix = "+"
+		}
+
+		for _, line := range strings.SplitAfter(d.Text, "\n") {
+			if line != "" {
+				out.WriteString(prefix)
+				out.WriteString(line)
+			}
+		}
+	}
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/matryer/is"
+)
+
+func TestChangelog(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	is.NoErr(err)
+
+	commit(t, repo, dir, "v1.0.0", map[string]string{
+		"go.mod":          "module example.com/changes\n",
+		"changes.go":      "package changes\n\nfunc Run(name string) error { return nil }\n\nfunc Stop() {}\n",
+		"old/old.go":      "package old\n\nconst Version = 1\n",
+		"same/same.go":    "package same\n\n// Same is unchanged.\nfunc Same() {}\n",
+		"testdata/gen.go": "package gen\n",
+	})
+	commit(t, repo, dir, "", map[string]string{
+		"changes.go":   "package changes\n\nfunc Run(name string, force bool) error { return nil }\n",
+		"client.go":    "package changes\n\ntype Client struct{}\n",
+		"same/same.go": "package same\n\n// Same is still unchanged.\nfunc Same() {}\n",
+	})
+	_, err = mustWorktree(t, repo).Remove("old/old.go")
+	is.NoErr(err)
+	commit(t, repo, dir, "", nil)
+
+	err = os.Chdir(dir)
+	is.NoErr(err)
+	defer func() { _ = os.Chdir(wd) }()
+
+	diffs := map[string]string{
+		"github":       "```diff\n-func Run(name string) error\n+func Run(name string, force bool) error\n```",
+		"azure-devops": "```diff\n-func Run(name string) error\n+func Run(name string, force bool) error\n```",
+		"plain":        "\t-func Run(name string) error\n\t+func Run(name string, force bool) error",
+	}
+
+	for format, diff := range diffs {
+		t.Run(format, func(t *testing.T) {
+			is := is.New(t)
+
+			os.Args = []string{
+				"gomarkdoc", "changelog", "./...",
+				"--from", "v1.0.0",
+				"--format", format,
+				"-o", "CHANGELOG.md",
+			}
+
+			cmd := buildCommand()
+			is.NoErr(cmd.Execute())
+
+			data, err := os.ReadFile("CHANGELOG.md")
+			is.NoErr(err)
+
+			is.Equal(string(data), "# API changes from v1.0.0 to HEAD\n\n"+
+				"## example.com/changes\n\n"+
+				"### Added\n\n"+
+				"- `type Client`\n\n"+
+				"### Removed\n\n"+
+				"- `func Stop()`\n\n"+
+				"### Changed\n\n"+
+				"#### Run\n\n"+
+				diff+"\n\n"+
+				"## example.com/changes/old\n\n"+
+				"### Removed\n\n"+
+				"- `const Version`\n")
+		})
+	}
+}
+
+func TestChangelog_noChanges(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	is.NoErr(err)
+
+	commit(t, repo, dir, "v1.0.0", map[string]string{
+		"go.mod":     "module example.com/changes\n",
+		"changes.go": "package changes\n\nfunc Run() {}\n",
+	})
+
+	err = os.Chdir(dir)
+	is.NoErr(err)
+	defer func() { _ = os.Chdir(wd) }()
+
+	os.Args = []string{"gomarkdoc", "changelog", "--from", "v1.0.0", "-o", "CHANGELOG.md"}
+
+	cmd := buildCommand()
+	is.NoErr(cmd.Execute())
+
+	data, err := os.ReadFile("CHANGELOG.md")
+	is.NoErr(err)
+	is.Equal(string(data), "# API changes from v1.0.0 to HEAD\n\nNo API changes.\n")
+}
+
+func TestChangelog_check(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	is.NoErr(err)
+
+	commit(t, repo, dir, "v1.0.0", map[string]string{
+		"go.mod":     "module example.com/changes\n",
+		"changes.go": "package changes\n\nfunc Run(name string) error { return nil }\n",
+	})
+	commit(t, repo, dir, "compatible", map[string]string{
+		"changes.go": "package changes\n\nfunc Run(n string) error { return nil }\n\nfunc Stop() {}\n",
+	})
+	commit(t, repo, dir, "breaking", map[string]string{
+		"changes.go": "package changes\n\nfunc Run(n string, force bool) error { return nil }\n",
+	})
+	commit(t, repo, dir, "major", map[string]string{
+		"go.mod": "module example.com/changes/v2\n",
+	})
+
+	err = os.Chdir(dir)
+	is.NoErr(err)
+	defer func() { _ = os.Chdir(wd) }()
+
+	tests := map[string]string{
+		"compatible": "",
+		"breaking":   "gomarkdoc: breaking API changes require a new major version of the module",
+		"major":      "",
+	}
+
+	for to, expected := range tests {
+		t.Run(to, func(t *testing.T) {
+			is := is.New(t)
+
+			os.Args = []string{"gomarkdoc", "changelog", "--from", "v1.0.0", "--to", to, "-c"}
+
+			cmd := buildCommand()
+			err := cmd.Execute()
+			if expected == "" {
+				is.NoErr(err)
+			} else {
+				is.True(err != nil)
+				is.Equal(err.Error(), expected)
+			}
+		})
+	}
+}
+
+func TestChangelog_constrainedDirs(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	is.NoErr(err)
+
+	commit(t, repo, dir, "v1.0.0", map[string]string{
+		"go.mod":               "module example.com/changes\n",
+		"changes.go":           "package changes\n\nfunc Run() {}\n",
+		"tools/tools.go":       "//go:build tools\n\npackage tools\n\nimport _ \"example.com/tool\"\n",
+		"gen/gen.go":           "//go:build ignore\n\npackage main\n\nfunc main() {}\n",
+		"plan9/plan9_plan9.go": "package plan9\n\nfunc Run() {}\n",
+	})
+	commit(t, repo, dir, "", map[string]string{
+		"changes.go": "package changes\n\nfunc Run() {}\n\nfunc Stop() {}\n",
+	})
+
+	err = os.Chdir(dir)
+	is.NoErr(err)
+	defer func() { _ = os.Chdir(wd) }()
+
+	os.Args = []string{"gomarkdoc", "changelog", "./...", "--from", "v1.0.0", "--check"}
+
+	cmd := buildCommand()
+	is.NoErr(cmd.Execute())
+
+	os.Args = []string{"gomarkdoc", "changelog", "./...", "--from", "v1.0.0", "-o", "CHANGELOG.md"}
+
+	cmd = buildCommand()
+	is.NoErr(cmd.Execute())
+
+	data, err := os.ReadFile("CHANGELOG.md")
+	is.NoErr(err)
+	is.Equal(string(data), "# API changes from v1.0.0 to HEAD\n\n"+
+		"## example.com/changes\n\n"+
+		"### Added\n\n"+
+		"- `func Stop()`\n")
+}
+
+func TestChangelog_missingFrom(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{"gomarkdoc", "changelog", "./simple"}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), `required flag(s) "from" not set`)
+}
+
+// commit commits the provided files to the repository, along with any other
+// changes already staged, and tags the commit with the provided tag if it isn't
+// empty.
+func commit(t *testing.T, repo *git.Repository, dir, tag string, files map[string]string) {
+	is := is.New(t)
+
+	wt := mustWorktree(t, repo)
+	for name, contents := range files {
+		is.NoErr(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		is.NoErr(os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+
+		_, err := wt.Add(name)
+		is.NoErr(err)
+	}
+
+	sig := &object.Signature{Name: "gomarkdoc", Email: "gomarkdoc@example.com", When: time.Now()}
+	hash, err := wt.Commit("commit", &git.CommitOptions{Author: sig})
+	is.NoErr(err)
+
+	if tag != "" {
+		_, err = repo.CreateTag(tag, hash, nil)
+		is.NoErr(err)
+	}
+}
+
+func mustWorktree(t *testing.T, repo *git.Repository) *git.Worktree {
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return wt
+}
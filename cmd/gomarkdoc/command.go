@@ -6,7 +6,6 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"go/build"
 	"hash/fnv"
 	"html/template"
 	"io"
@@ -18,6 +17,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/tools/go/packages"
 
 	"github.com/princjef/gomarkdoc"
 	"github.com/princjef/gomarkdoc/format"
@@ -45,18 +45,40 @@ type PackageSpec struct {
 
 type commandOptions struct {
 	repository            lang.Repo
+	repositoryHost        string
 	output                string
 	header                string
 	headerFile            string
 	footer                string
 	footerFile            string
+	stylesheetFile        string
+	site                  string
+	siteNav               string
+	siteDocsDir           string
+	frontMatterFile       string
 	format                string
 	tags                  []string
+	mod                   string
+	typeCheck             bool
+	docLinks              []string
+	buildMatrix           []string
 	excludeDirs           []string
 	templateOverrides     map[string]string
 	templateFileOverrides map[string]string
 	verbosity             int
 	includeUnexported     bool
+	hideDeprecated        bool
+	includeSymbols        []string
+	excludeSymbols        []string
+	includeKinds          []string
+	excludeKinds          []string
+	collapseDeprecated    bool
+	tableOfContents       bool
+	verifyExamples        bool
+	strict                bool
+	fieldStyle            string
+	symbolOrder           string
+	since                 bool
 	check                 bool
 	embed                 bool
 	version               bool
@@ -76,6 +98,7 @@ func buildCommand() *cobra.Command {
 	var command = &cobra.Command{
 		Use:   "gomarkdoc [package ...]",
 		Short: "generate markdown documentation for golang code",
+		Args:  cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if opts.version {
 				printVersion()
@@ -86,26 +109,52 @@ func buildCommand() *cobra.Command {
 
 			// Load configuration from viper
 			opts.includeUnexported = viper.GetBool("includeUnexported")
+			opts.hideDeprecated = viper.GetBool("hideDeprecated")
+			opts.includeSymbols = viper.GetStringSlice("includeSymbols")
+			opts.excludeSymbols = viper.GetStringSlice("excludeSymbols")
+			opts.includeKinds = viper.GetStringSlice("includeKinds")
+			opts.excludeKinds = viper.GetStringSlice("excludeKinds")
+			opts.collapseDeprecated = viper.GetBool("collapseDeprecated")
+			opts.tableOfContents = viper.GetBool("tableOfContents")
 			opts.output = viper.GetString("output")
 			opts.check = viper.GetBool("check")
+			opts.verifyExamples = viper.GetBool("verifyExamples")
+			opts.strict = viper.GetBool("strict")
 			opts.embed = viper.GetBool("embed")
 			opts.format = viper.GetString("format")
+			opts.fieldStyle = viper.GetString("fieldStyle")
+			opts.symbolOrder = viper.GetString("symbolOrder")
+			opts.since = viper.GetBool("since")
 			opts.templateOverrides = viper.GetStringMapString("template")
 			opts.templateFileOverrides = viper.GetStringMapString("templateFile")
 			opts.header = viper.GetString("header")
 			opts.headerFile = viper.GetString("headerFile")
 			opts.footer = viper.GetString("footer")
 			opts.footerFile = viper.GetString("footerFile")
+			opts.stylesheetFile = viper.GetString("stylesheetFile")
+			opts.site = viper.GetString("site")
+			opts.siteNav = viper.GetString("siteNav")
+			opts.siteDocsDir = viper.GetString("siteDocsDir")
+			opts.frontMatterFile = viper.GetString("frontMatterFile")
 			opts.tags = viper.GetStringSlice("tags")
+			opts.mod = viper.GetString("mod")
+			opts.typeCheck = viper.GetBool("typeCheck")
+			opts.docLinks = viper.GetStringSlice("docLinks")
+			opts.buildMatrix = viper.GetStringSlice("buildMatrix")
 			opts.excludeDirs = viper.GetStringSlice("excludeDirs")
 			opts.repository.Remote = viper.GetString("repository.url")
 			opts.repository.DefaultBranch = viper.GetString("repository.defaultBranch")
 			opts.repository.PathFromRoot = viper.GetString("repository.path")
+			opts.repositoryHost = viper.GetString("repository.host")
 
 			if opts.check && opts.output == "" {
 				return errors.New("gomarkdoc: check mode cannot be run without an output set")
 			}
 
+			if err := validateSite(opts); err != nil {
+				return err
+			}
+
 			if len(args) == 0 {
 				// Default to current directory
 				args = []string{"."}
@@ -128,6 +177,56 @@ func buildCommand() *cobra.Command {
 		false,
 		"Output documentation for unexported symbols, methods and fields in addition to exported ones.",
 	)
+	command.Flags().BoolVar(
+		&opts.hideDeprecated,
+		"hide-deprecated",
+		false,
+		"Leave deprecated symbols out of the documentation.",
+	)
+	command.Flags().StringArrayVar(
+		&opts.includeSymbols,
+		"include-symbols",
+		nil,
+		"Glob or /regex/ matching the names of symbols to include in the documentation. "+
+			"Methods and fields are matched as Type.Name.",
+	)
+	command.Flags().StringArrayVar(
+		&opts.excludeSymbols,
+		"exclude-symbols",
+		nil,
+		"Glob or /regex/ matching the names of symbols to leave out of the documentation. "+
+			"Methods and fields are matched as Type.Name.",
+	)
+	command.Flags().StringSliceVar(
+		&opts.includeKinds,
+		"include-kinds",
+		nil,
+		"Kinds of top-level symbols to include in the documentation. Valid options: type, func, const, var",
+	)
+	command.Flags().StringSliceVar(
+		&opts.excludeKinds,
+		"exclude-kinds",
+		nil,
+		"Kinds of symbols to leave out of the documentation. Valid options: type, func, const, var, method, field",
+	)
+	command.Flags().BoolVar(
+		&opts.collapseDeprecated,
+		"collapse-deprecated",
+		false,
+		"Collapse the documentation for deprecated symbols into an accordion.",
+	)
+	command.Flags().BoolVar(
+		&opts.tableOfContents,
+		"table-of-contents",
+		false,
+		"Add a table of contents to each output file. Only used by the gitlab format and ignored with --embed.",
+	)
+	command.Flags().BoolVar(
+		&opts.since,
+		"since",
+		false,
+		"Note the release in which each symbol was added, based on the semantic version tags of the repository.",
+	)
 	command.Flags().StringVarP(
 		&opts.output,
 		"output",
@@ -142,6 +241,18 @@ func buildCommand() *cobra.Command {
 		false,
 		"Check the output to see if it matches the generated documentation. --output must be specified to use this.",
 	)
+	command.Flags().BoolVar(
+		&opts.verifyExamples,
+		"verify-examples",
+		false,
+		"Run the documented examples and check that their output matches the documented output.",
+	)
+	command.Flags().BoolVar(
+		&opts.strict,
+		"strict",
+		false,
+		"Fail if any doc links can't be resolved to the symbols they refer to.",
+	)
 	command.Flags().BoolVarP(
 		&opts.embed,
 		"embed",
@@ -154,7 +265,19 @@ func buildCommand() *cobra.Command {
 		"format",
 		"f",
 		"github",
-		"Format to use for writing output data. Valid options: github (default), azure-devops, plain",
+		"Format to use for writing output data. Valid options: github (default), azure-devops, gitlab, plain, html",
+	)
+	command.Flags().StringVar(
+		&opts.fieldStyle,
+		"field-style",
+		"list",
+		"Style to use for rendering the fields of struct types. Valid options: list (default), table",
+	)
+	command.Flags().StringVar(
+		&opts.symbolOrder,
+		"symbol-order",
+		"alphabetical",
+		"Order in which to document symbols. Valid options: alphabetical (default), source, file, category",
 	)
 	command.Flags().StringToStringVarP(
 		&opts.templateOverrides,
@@ -193,12 +316,67 @@ func buildCommand() *cobra.Command {
 		"",
 		"File containing additional content to inject at the end of each output file.",
 	)
+	command.Flags().StringVar(
+		&opts.stylesheetFile,
+		"stylesheet-file",
+		"",
+		"CSS file to add to the page of each output file after the default stylesheet. Only used by the html format.",
+	)
+	command.Flags().StringVar(
+		&opts.site,
+		"site",
+		"",
+		"Static site generator to generate documentation for. Valid options: hugo, docusaurus, mkdocs",
+	)
+	command.Flags().StringVar(
+		&opts.siteNav,
+		"site-nav",
+		"",
+		"File to write the navigation of the site to. Only used by the docusaurus and mkdocs sites.",
+	)
+	command.Flags().StringVar(
+		&opts.siteDocsDir,
+		"site-docs-dir",
+		"docs",
+		"Directory containing the documents of the site, which the paths in the site navigation are relative to.",
+	)
+	command.Flags().StringVar(
+		&opts.frontMatterFile,
+		"front-matter-file",
+		"",
+		"File containing the template for the front matter of each output file of the site.",
+	)
 	command.Flags().StringSliceVar(
 		&opts.tags,
 		"tags",
 		defaultTags(),
 		"Set of build tags to apply when choosing which files to include for documentation generation.",
 	)
+	command.Flags().StringVar(
+		&opts.mod,
+		"mod",
+		defaultMod(),
+		"Module download mode to use when loading packages. Valid options: readonly, vendor, mod",
+	)
+	command.Flags().BoolVar(
+		&opts.typeCheck,
+		"type-check",
+		false,
+		"Type check packages to link identifiers in declarations to their documentation.",
+	)
+	command.Flags().StringArrayVar(
+		&opts.docLinks,
+		"doc-links",
+		nil,
+		"URL template to use for links to packages with an import path prefix, specified as prefix=template.",
+	)
+	command.Flags().StringArrayVar(
+		&opts.buildMatrix,
+		"build-matrix",
+		nil,
+		"Build context to document packages under, specified as GOOS/GOARCH[,tag...]. "+
+			"Can be repeated to merge the documentation from several contexts.",
+	)
 	command.Flags().StringSliceVar(
 		&opts.excludeDirs,
 		"exclude-dirs",
@@ -229,6 +407,14 @@ func buildCommand() *cobra.Command {
 		"",
 		"Manual override for the path from the root of the git repository used in place of automatic detection.",
 	)
+	command.Flags().StringVar(
+		&opts.repositoryHost,
+		"repository.host",
+		"",
+		"Manual override for the host of the git repository used in place of automatic detection. "+
+			"Needed for self-managed servers whose hostname doesn't start with the name of the host (e.g. gitlab.). "+
+			"Valid options: github, gitlab, azure-devops, bitbucket-cloud, bitbucket-server, gitea, forgejo",
+	)
 	command.Flags().BoolVar(
 		&opts.version,
 		"version",
@@ -238,43 +424,89 @@ func buildCommand() *cobra.Command {
 
 	// We ignore the errors here because they only happen if the specified flag doesn't exist
 	_ = viper.BindPFlag("includeUnexported", command.Flags().Lookup("include-unexported"))
+	_ = viper.BindPFlag("hideDeprecated", command.Flags().Lookup("hide-deprecated"))
+	_ = viper.BindPFlag("collapseDeprecated", command.Flags().Lookup("collapse-deprecated"))
+	_ = viper.BindPFlag("tableOfContents", command.Flags().Lookup("table-of-contents"))
+	_ = viper.BindPFlag("includeSymbols", command.Flags().Lookup("include-symbols"))
+	_ = viper.BindPFlag("excludeSymbols", command.Flags().Lookup("exclude-symbols"))
+	_ = viper.BindPFlag("includeKinds", command.Flags().Lookup("include-kinds"))
+	_ = viper.BindPFlag("excludeKinds", command.Flags().Lookup("exclude-kinds"))
 	_ = viper.BindPFlag("output", command.Flags().Lookup("output"))
 	_ = viper.BindPFlag("check", command.Flags().Lookup("check"))
+	_ = viper.BindPFlag("verifyExamples", command.Flags().Lookup("verify-examples"))
+	_ = viper.BindPFlag("strict", command.Flags().Lookup("strict"))
 	_ = viper.BindPFlag("embed", command.Flags().Lookup("embed"))
 	_ = viper.BindPFlag("format", command.Flags().Lookup("format"))
+	_ = viper.BindPFlag("fieldStyle", command.Flags().Lookup("field-style"))
+	_ = viper.BindPFlag("symbolOrder", command.Flags().Lookup("symbol-order"))
+	_ = viper.BindPFlag("since", command.Flags().Lookup("since"))
 	_ = viper.BindPFlag("template", command.Flags().Lookup("template"))
 	_ = viper.BindPFlag("templateFile", command.Flags().Lookup("template-file"))
 	_ = viper.BindPFlag("header", command.Flags().Lookup("header"))
 	_ = viper.BindPFlag("headerFile", command.Flags().Lookup("header-file"))
 	_ = viper.BindPFlag("footer", command.Flags().Lookup("footer"))
 	_ = viper.BindPFlag("footerFile", command.Flags().Lookup("footer-file"))
+	_ = viper.BindPFlag("stylesheetFile", command.Flags().Lookup("stylesheet-file"))
+	_ = viper.BindPFlag("site", command.Flags().Lookup("site"))
+	_ = viper.BindPFlag("siteNav", command.Flags().Lookup("site-nav"))
+	_ = viper.BindPFlag("siteDocsDir", command.Flags().Lookup("site-docs-dir"))
+	_ = viper.BindPFlag("frontMatterFile", command.Flags().Lookup("front-matter-file"))
 	_ = viper.BindPFlag("tags", command.Flags().Lookup("tags"))
+	_ = viper.BindPFlag("mod", command.Flags().Lookup("mod"))
+	_ = viper.BindPFlag("typeCheck", command.Flags().Lookup("type-check"))
+	_ = viper.BindPFlag("docLinks", command.Flags().Lookup("doc-links"))
+	_ = viper.BindPFlag("buildMatrix", command.Flags().Lookup("build-matrix"))
 	_ = viper.BindPFlag("excludeDirs", command.Flags().Lookup("exclude-dirs"))
 	_ = viper.BindPFlag("repository.url", command.Flags().Lookup("repository.url"))
 	_ = viper.BindPFlag("repository.defaultBranch", command.Flags().Lookup("repository.default-branch"))
 	_ = viper.BindPFlag("repository.path", command.Flags().Lookup("repository.path"))
+	_ = viper.BindPFlag("repository.host", command.Flags().Lookup("repository.host"))
+
+	command.CompletionOptions.DisableDefaultCmd = true
+	command.AddCommand(buildChangelogCommand())
+	command.AddCommand(buildCoverageCommand())
 
 	return command
 }
 
 func defaultTags() []string {
-	f, ok := os.LookupEnv("GOFLAGS")
+	tags, ok := lookupGoFlag("tags")
 	if !ok {
 		return nil
 	}
 
+	return strings.Split(tags, ",")
+}
+
+func defaultMod() string {
+	mod, _ := lookupGoFlag("mod")
+	return mod
+}
+
+// lookupGoFlag finds the value of the flag with the provided name in the
+// GOFLAGS environment variable. Only the flags that gomarkdoc understands can
+// be looked up, and nothing is found if GOFLAGS cannot be parsed.
+func lookupGoFlag(name string) (string, bool) {
+	f, ok := os.LookupEnv("GOFLAGS")
+	if !ok {
+		return "", false
+	}
+
 	fs := flag.NewFlagSet("goflags", flag.ContinueOnError)
-	tags := fs.String("tags", "", "")
+	fs.SetOutput(io.Discard)
+	_ = fs.String("tags", "", "")
+	_ = fs.String("mod", "", "")
 
 	if err := fs.Parse(strings.Fields(f)); err != nil {
-		return nil
+		return "", false
 	}
 
-	if tags == nil {
-		return nil
+	fl := fs.Lookup(name)
+	if fl == nil {
+		return "", false
 	}
 
-	return strings.Split(*tags, ",")
+	return fl.Value.String(), true
 }
 
 func buildConfig(configFile string) {
@@ -314,11 +546,29 @@ func runCommand(paths []string, opts commandOptions) error {
 		return err
 	}
 
-	if err := loadPackages(specs, opts); err != nil {
+	links := lang.NewLinkReport()
+	if err := loadPackages(specs, opts, links); err != nil {
+		return err
+	}
+
+	if opts.verifyExamples {
+		if err := verifyExamples(specs, opts); err != nil {
+			return err
+		}
+	}
+
+	files, err := renderOutput(specs, opts)
+	if err != nil {
+		return err
+	}
+
+	// Links are collected while rendering, so they're reported before any
+	// files are written or checked
+	if err := reportUnresolvedLinks(links, opts); err != nil {
 		return err
 	}
 
-	return writeOutput(specs, opts)
+	return writeOutput(files, opts)
 }
 
 func resolveOutput(specs []*PackageSpec, outputTmpl *template.Template) error {
@@ -364,23 +614,52 @@ func resolveOverrides(opts commandOptions) ([]gomarkdoc.RendererOption, error) {
 		overrides = append(overrides, gomarkdoc.WithTemplateOverride(name, string(b)))
 	}
 
-	var f format.Format
-	switch opts.format {
-	case "github":
-		f = &format.GitHubFlavoredMarkdown{}
-	case "azure-devops":
-		f = &format.AzureDevOpsMarkdown{}
-	case "plain":
-		f = &format.PlainMarkdown{}
-	default:
-		return nil, fmt.Errorf("gomarkdoc: invalid format: %s", opts.format)
+	f, err := resolveFormat(opts.format)
+	if err != nil {
+		return nil, err
+	}
+
+	// MDX parses HTML as JSX, so Docusaurus sites need the format's HTML to be
+	// adjusted.
+	if opts.site == siteDocusaurus {
+		f = &format.MDX{Format: f}
 	}
 
 	overrides = append(overrides, gomarkdoc.WithFormat(f))
+	overrides = append(overrides, gomarkdoc.WithFieldStyle(gomarkdoc.FieldStyle(opts.fieldStyle)))
+	overrides = append(overrides, gomarkdoc.WithSymbolOrder(lang.SymbolOrder(opts.symbolOrder)))
+
+	if opts.collapseDeprecated {
+		overrides = append(overrides, gomarkdoc.WithDeprecatedCollapsed())
+	}
+
+	// Embedded documentation is only part of the file, so a table of contents
+	// for the whole file doesn't belong in it
+	if opts.tableOfContents && !opts.embed {
+		overrides = append(overrides, gomarkdoc.WithTableOfContents())
+	}
 
 	return overrides, nil
 }
 
+// resolveFormat finds the format with the provided name.
+func resolveFormat(name string) (format.Format, error) {
+	switch name {
+	case "github":
+		return &format.GitHubFlavoredMarkdown{}, nil
+	case "azure-devops":
+		return &format.AzureDevOpsMarkdown{}, nil
+	case "gitlab":
+		return &format.GitLabFlavoredMarkdown{}, nil
+	case "plain":
+		return &format.PlainMarkdown{}, nil
+	case "html":
+		return &format.HTML{}, nil
+	default:
+		return nil, fmt.Errorf("gomarkdoc: invalid format: %s", name)
+	}
+}
+
 func resolveHeader(opts commandOptions) (string, error) {
 	if opts.header != "" {
 		return opts.header, nil
@@ -415,29 +694,104 @@ func resolveFooter(opts commandOptions) (string, error) {
 	return "", nil
 }
 
-func loadPackages(specs []*PackageSpec, opts commandOptions) error {
+// loadPackages loads the documentation for the packages of the provided specs.
+// If a link report is provided, the doc links that can't be resolved when the
+// documentation is rendered are collected in it.
+func loadPackages(specs []*PackageSpec, opts commandOptions, links *lang.LinkReport) error {
+	if opts.repositoryHost != "" {
+		host, err := lang.ParseRepoHost(opts.repositoryHost)
+		if err != nil {
+			return err
+		}
+
+		opts.repository.Host = host
+	}
+
+	matrix, err := resolveBuildMatrix(opts)
+	if err != nil {
+		return err
+	}
+
+	loaded, err := getLoadedPackages(specs, opts, matrix)
+	if err != nil {
+		return err
+	}
+
+	filterOpts, err := resolveSymbolFilters(opts)
+	if err != nil {
+		return err
+	}
+
+	docLinks, err := lang.ParseDocLinks(opts.docLinks)
+	if err != nil {
+		return err
+	}
+
+	var history *lang.VersionHistory
+	if opts.since {
+		history, err = lang.NewVersionHistory(logger.New(getLogLevel(opts.verbosity)), ".")
+		if err != nil {
+			return err
+		}
+	}
+
+	// All packages share a symbol table so that links between them can point
+	// to the documentation generated in this run.
+	symbols := lang.NewSymbolTable()
+
 	for _, spec := range specs {
 		log := logger.New(getLogLevel(opts.verbosity), logger.WithField("dir", spec.Dir))
 
-		buildPkg, err := getBuildPackage(spec.ImportPath, opts.tags)
+		loadedPkg, matrixPkgs, err := findMatrixPackages(spec, loaded)
 		if err != nil {
 			log.Debugf("unable to load package in directory: %s", err)
 			// We don't care if a wildcard path produces nothing
-			if spec.isWildcard {
+			if spec.isWildcard && (loadedPkg == nil || len(loadedPkg.GoFiles) == 0) {
 				continue
 			}
 
 			return err
 		}
 
+		warned := make(map[string]bool)
+		for _, p := range append([]*packages.Package{loadedPkg}, matrixPkgs...) {
+			for _, e := range p.Errors {
+				if e.Kind == packages.TypeError && !warned[e.Error()] {
+					warned[e.Error()] = true
+					log.Warnf("type checking failed: %s", e)
+				}
+			}
+		}
+
 		var pkgOpts []lang.PackageOption
 		pkgOpts = append(pkgOpts, lang.PackageWithRepositoryOverrides(&opts.repository))
+		pkgOpts = append(pkgOpts, lang.PackageWithSymbolTable(symbols, spec.outputFile))
+		pkgOpts = append(pkgOpts, lang.PackageWithDocLinks(docLinks))
+
+		if matrix != nil {
+			pkgOpts = append(pkgOpts, lang.PackageWithBuildMatrix(matrix))
+			pkgOpts = append(pkgOpts, lang.PackageWithMatrixPackages(matrixPkgs...))
+		}
 
 		if opts.includeUnexported {
 			pkgOpts = append(pkgOpts, lang.PackageWithUnexportedIncluded())
 		}
 
-		pkg, err := lang.NewPackageFromBuild(log, buildPkg, pkgOpts...)
+		if opts.hideDeprecated {
+			pkgOpts = append(pkgOpts, lang.PackageWithDeprecatedExcluded())
+		}
+
+		if history != nil {
+			pkgOpts = append(pkgOpts, lang.PackageWithVersionHistory(history))
+		}
+
+		if links != nil {
+			pkgOpts = append(pkgOpts, lang.PackageWithLinkReport(links))
+		}
+
+		pkgOpts = append(pkgOpts, filterOpts...)
+
+		pkg, err := lang.NewPackageFromPackages(log, loadedPkg, pkgOpts...)
 		if err != nil {
 			return err
 		}
@@ -448,30 +802,312 @@ func loadPackages(specs []*PackageSpec, opts commandOptions) error {
 	return nil
 }
 
-func getBuildPackage(path string, tags []string) (*build.Package, error) {
-	ctx := build.Default
-	ctx.BuildTags = tags
+// resolveSymbolFilters creates the package options for the configured symbol
+// name and kind filters.
+func resolveSymbolFilters(opts commandOptions) ([]lang.PackageOption, error) {
+	var pkgOpts []lang.PackageOption
+
+	if len(opts.includeSymbols) > 0 || len(opts.excludeSymbols) > 0 {
+		filter, err := lang.SymbolPatternFilter(opts.includeSymbols, opts.excludeSymbols)
+		if err != nil {
+			return nil, err
+		}
+
+		pkgOpts = append(pkgOpts, lang.PackageWithSymbolFilter(filter))
+	}
+
+	if len(opts.includeKinds) > 0 || len(opts.excludeKinds) > 0 {
+		include, err := parseSymbolKinds(opts.includeKinds)
+		if err != nil {
+			return nil, err
+		}
+
+		// Methods and fields are documented with their types, so they can
+		// only be left out
+		for _, kind := range include {
+			if kind == lang.MethodSymbolKind || kind == lang.FieldSymbolKind {
+				return nil, fmt.Errorf("gomarkdoc: symbol kind %q can't be included, only excluded", kind)
+			}
+		}
+
+		exclude, err := parseSymbolKinds(opts.excludeKinds)
+		if err != nil {
+			return nil, err
+		}
+
+		pkgOpts = append(pkgOpts, lang.PackageWithSymbolFilter(lang.SymbolKindFilter(include, exclude)))
+	}
+
+	return pkgOpts, nil
+}
 
-	if isLocalPath(path) {
-		pkg, err := ctx.ImportDir(path, build.ImportComment)
+func parseSymbolKinds(names []string) ([]lang.SymbolKind, error) {
+	kinds := make([]lang.SymbolKind, len(names))
+	for i, name := range names {
+		kind, err := lang.ParseSymbolKind(name)
 		if err != nil {
-			return nil, fmt.Errorf("gomarkdoc: invalid package in directory: %s", path)
+			return nil, err
 		}
 
-		return pkg, nil
+		kinds[i] = kind
+	}
+
+	return kinds, nil
+}
+
+// resolveBuildMatrix creates the build matrix from the configured build
+// contexts, or nil if there are none. The tags from the --tags flag apply to
+// all of the contexts.
+func resolveBuildMatrix(opts commandOptions) (*lang.BuildMatrix, error) {
+	if len(opts.buildMatrix) == 0 {
+		return nil, nil
 	}
 
-	wd, err := os.Getwd()
+	matrix, err := lang.ParseBuildMatrix(opts.buildMatrix)
 	if err != nil {
 		return nil, err
 	}
 
-	pkg, err := ctx.Import(path, wd, build.ImportComment)
+	contexts := matrix.Contexts()
+	for i := range contexts {
+		contexts[i].Tags = append(contexts[i].Tags, opts.tags...)
+	}
+
+	return lang.NewBuildMatrix(contexts...), nil
+}
+
+// getLoadedPackages loads all of the packages identified by the provided specs.
+// Packages are resolved by the go command, so module settings such as go.mod,
+// go.work and vendor directories are honored. Without a build matrix, the
+// packages are loaded in a single pass and a single list is returned. With
+// one, they're loaded once under each of its contexts and there is a list for
+// each context, in the same order as the contexts.
+func getLoadedPackages(
+	specs []*PackageSpec,
+	opts commandOptions,
+	matrix *lang.BuildMatrix,
+) ([][]*packages.Package, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	if matrix == nil {
+		pkgs, err := loadGoPackages(specs, opts, nil, opts.tags)
+		if err != nil {
+			return nil, err
+		}
+
+		return [][]*packages.Package{pkgs}, nil
+	}
+
+	var loaded [][]*packages.Package
+	for _, ctx := range matrix.Contexts() {
+		env := []string{fmt.Sprintf("GOOS=%s", ctx.GOOS), fmt.Sprintf("GOARCH=%s", ctx.GOARCH)}
+		pkgs, err := loadGoPackages(specs, opts, env, ctx.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		loaded = append(loaded, pkgs)
+	}
+
+	return loaded, nil
+}
+
+// loadGoPackages loads the packages identified by the provided specs in a
+// single pass with the provided additional environment and build tags.
+func loadGoPackages(
+	specs []*PackageSpec,
+	opts commandOptions,
+	env []string,
+	tags []string,
+) ([]*packages.Package, error) {
+	patterns := make([]string, len(specs))
+	for i, spec := range specs {
+		patterns[i] = spec.ImportPath
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedModule,
+		Env:  append(goEnv(), env...),
+	}
+
+	if len(tags) > 0 {
+		cfg.BuildFlags = append(cfg.BuildFlags, fmt.Sprintf("-tags=%s", strings.Join(tags, ",")))
+	}
+
+	if opts.typeCheck {
+		// Type information is used to resolve the identifiers in declarations
+		cfg.Mode |= packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax |
+			packages.NeedImports | packages.NeedDeps
+	}
+
+	if opts.mod != "" {
+		cfg.BuildFlags = append(cfg.BuildFlags, fmt.Sprintf("-mod=%s", opts.mod))
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
 	if err != nil {
-		return nil, fmt.Errorf("gomarkdoc: invalid package at import path: %s", path)
+		return nil, fmt.Errorf("gomarkdoc: failed to load packages: %w", err)
+	}
+
+	return pkgs, nil
+}
+
+// goBuildFlags holds the names of the build flags the go command accepts in
+// GOFLAGS, except for -tags and -mod.
+var goBuildFlags = map[string]bool{
+	"a": true, "asan": true, "asmflags": true, "buildmode": true, "buildvcs": true,
+	"compiler": true, "cover": true, "covermode": true, "coverpkg": true,
+	"gccgoflags": true, "gcflags": true, "installsuffix": true, "ldflags": true,
+	"linkshared": true, "modcacherw": true, "modfile": true, "msan": true,
+	"overlay": true, "p": true, "pgo": true, "pkgdir": true, "race": true,
+	"toolexec": true, "trimpath": true, "work": true,
+}
+
+// goEnv provides the environment for invoking the go command. The -tags and
+// -mod flags are removed from GOFLAGS because gomarkdoc reads them from it and
+// passes them again explicitly. Anything other than a build flag is removed
+// as well, since the go command refuses to run if GOFLAGS contains it. All
+// other build flags are left to the go command.
+func goEnv() []string {
+	env := os.Environ()
+	out := make([]string, 0, len(env))
+	for _, e := range env {
+		if !strings.HasPrefix(e, "GOFLAGS=") {
+			out = append(out, e)
+			continue
+		}
+
+		var flags []string
+		for _, f := range strings.Fields(strings.TrimPrefix(e, "GOFLAGS=")) {
+			name := strings.SplitN(strings.TrimLeft(f, "-"), "=", 2)[0]
+			if !strings.HasPrefix(f, "-") || !goBuildFlags[name] {
+				continue
+			}
+
+			flags = append(flags, f)
+		}
+
+		if len(flags) > 0 {
+			out = append(out, fmt.Sprintf("GOFLAGS=%s", strings.Join(flags, " ")))
+		}
+	}
+
+	return out
+}
+
+// findLoadedPackage finds the package corresponding to the provided spec in
+// the list of loaded packages. Local specs are matched by directory, while
+// remote specs are matched by import path. If the go command reported errors
+// for the package, they're returned along with it.
+func findLoadedPackage(spec *PackageSpec, pkgs []*packages.Package) (*packages.Package, error) {
+	var (
+		found   *packages.Package
+		invalid error
+	)
+	if spec.isLocal {
+		dir, err := filepath.Abs(spec.Dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pkg := range pkgs {
+			if pkg.ID == spec.ImportPath || packageDir(pkg) == dir {
+				found = pkg
+				break
+			}
+		}
+
+		invalid = fmt.Errorf("gomarkdoc: invalid package in directory: %s", spec.ImportPath)
+	} else {
+		for _, pkg := range pkgs {
+			if pkg.PkgPath == spec.ImportPath || pkg.ID == spec.ImportPath {
+				found = pkg
+				break
+			}
+		}
+
+		invalid = fmt.Errorf("gomarkdoc: invalid package at import path: %s", spec.ImportPath)
+	}
+
+	// Directories without any files don't hold a package to report errors for
+	if found == nil || packageDir(found) == "" {
+		return nil, invalid
+	}
+
+	if err := loadErrors(found); err != nil {
+		return found, err
+	}
+
+	if len(found.GoFiles) == 0 {
+		return found, invalid
+	}
+
+	return found, nil
+}
+
+// findMatrixPackages finds the package corresponding to the provided spec in
+// each of the lists of packages loaded by getLoadedPackages. The first package
+// that was loaded without errors is returned along with the ones from the
+// later lists that were loaded without errors. A package only needs to build
+// under one of the contexts of a build matrix, so the errors for the other
+// contexts are ignored. If there is no such package, the result of
+// findLoadedPackage for the first list is returned instead.
+func findMatrixPackages(
+	spec *PackageSpec,
+	loaded [][]*packages.Package,
+) (*packages.Package, []*packages.Package, error) {
+	var found []*packages.Package
+	for _, pkgs := range loaded {
+		pkg, err := findLoadedPackage(spec, pkgs)
+		if err == nil {
+			found = append(found, pkg)
+		}
+	}
+
+	if len(found) == 0 {
+		pkg, err := findLoadedPackage(spec, loaded[0])
+		return pkg, nil, err
+	}
+
+	return found[0], found[1:], nil
+}
+
+// packageDir provides the directory containing the files of the loaded
+// package, or an empty string if it has no files.
+func packageDir(pkg *packages.Package) string {
+	for _, files := range [][]string{pkg.GoFiles, pkg.IgnoredFiles, pkg.OtherFiles} {
+		if len(files) > 0 {
+			return filepath.Dir(files[0])
+		}
+	}
+
+	return ""
+}
+
+// loadErrors combines the errors the go command reported when listing and
+// parsing the package into a single error. Type errors are left out, since
+// type checking is only used to add links to the documentation.
+func loadErrors(pkg *packages.Package) error {
+	var msgs []string
+	for _, e := range pkg.Errors {
+		if e.Kind == packages.TypeError {
+			continue
+		}
+
+		if e.Pos == "" {
+			msgs = append(msgs, e.Msg)
+		} else {
+			msgs = append(msgs, e.Error())
+		}
+	}
+
+	if len(msgs) == 0 {
+		return nil
 	}
 
-	return pkg, nil
+	return fmt.Errorf("gomarkdoc: failed to load package %s: %s", pkg.ID, strings.Join(msgs, "; "))
 }
 
 func getSpecs(paths ...string) []*PackageSpec {
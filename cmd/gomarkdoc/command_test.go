@@ -21,6 +21,8 @@ func TestCommand(t *testing.T) {
 		"./lang/function",
 		"./docs",
 		"./untagged",
+		"./multipkg",
+		"./directives",
 	}
 
 	for _, test := range tests {
@@ -59,111 +61,750 @@ func TestCommand_check(t *testing.T) {
 	main()
 }
 
+func TestCommand_strict(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./links",
+		"--strict",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "links")
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), "gomarkdoc: documentation contains 1 unresolved doc links")
+
+	// Nothing is written when the documentation fails the strict check
+	_, err = os.Stat("./links/README-github-test.md")
+	is.True(os.IsNotExist(err))
+}
+
+func TestCommand_strictFiltered(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	// Links to symbols left out by filters, deprecation or directives are
+	// written as plain text without failing the strict check
+	os.Args = []string{
+		"gomarkdoc", "./links", "./directives",
+		"--strict",
+		"--exclude-kinds", "const",
+		"--exclude-symbols", "Broken",
+		"--hide-deprecated",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "links")
+	cleanup(t, "directives")
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	data, err := os.ReadFile("./links/README-github-test.md")
+	is.NoErr(err)
+	is.True(strings.Contains(string(data), "It replaces Old and uses DefaultSize unless told otherwise."))
+}
+
 func TestCommand_nested(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	os.Args = []string{
-		"gomarkdoc", "./nested/...",
-		"-o", "{{.Dir}}/README-github-test.md",
+	os.Args = []string{
+		"gomarkdoc", "./nested/...",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "nested")
+	cleanup(t, "nested/inner")
+
+	main()
+
+	verify(t, "nested", "github")
+	verify(t, "nested/inner", "github")
+}
+
+func TestCommand_siteDocusaurus(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./nested/...",
+		"-o", "{{.Dir}}/README-docusaurus-test.md",
+		"--site", "docusaurus",
+		"--site-docs-dir", ".",
+		"--site-nav", "nested/sidebars-test.js",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "nested")
+	cleanup(t, "nested/inner")
+	t.Cleanup(func() { os.Remove("nested/sidebars-test.js") })
+
+	main()
+
+	verifyLinked(t, "nested", "docusaurus")
+	verifyLinked(t, "nested/inner", "docusaurus")
+	verifyNav(t, "nested/sidebars.js", "nested/sidebars-test.js", "docusaurus")
+}
+
+func TestCommand_siteMkDocs(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./nested/...",
+		"-o", "{{.Dir}}/README-mkdocs-test.md",
+		"--site", "mkdocs",
+		"--site-docs-dir", ".",
+		"--site-nav", "nested/nav-test.yml",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "nested")
+	cleanup(t, "nested/inner")
+	t.Cleanup(func() { os.Remove("nested/nav-test.yml") })
+
+	main()
+
+	verifyLinked(t, "nested", "mkdocs")
+	verifyLinked(t, "nested/inner", "mkdocs")
+	verifyNav(t, "nested/nav.yml", "nested/nav-test.yml", "mkdocs")
+}
+
+func TestCommand_siteFrontMatterFile(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	frontMatterFile := filepath.Join(t.TempDir(), "front-matter.gotxt")
+	err = os.WriteFile(frontMatterFile, []byte("linkTitle: {{ .Title }}\npackage: {{ .ImportPath }}\n"), 0o644)
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"-o", "{{.Dir}}/README-hugo-test.md",
+		"--site", "hugo",
+		"--front-matter-file", frontMatterFile,
+	}
+	cleanup(t, "simple")
+	t.Cleanup(func() { cleanup(t, "simple") })
+
+	main()
+
+	data, err := os.ReadFile("simple/README-hugo-test.md")
+	is.NoErr(err)
+	is.True(strings.HasPrefix(
+		string(data),
+		"---\nlinkTitle: simple\npackage: github.com/princjef/gomarkdoc/testData/simple\n---\n\n<!-- Code generated",
+	))
+}
+
+func TestCommand_invalidSite(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"unknown site": {
+			args: []string{"--site", "jekyll"},
+			err:  "gomarkdoc: invalid site: jekyll",
+		},
+		"no site": {
+			args: []string{"--site-nav", "sidebars.js"},
+			err:  "gomarkdoc: site options cannot be used without a site",
+		},
+		"html format": {
+			args: []string{"--site", "docusaurus", "--format", "html"},
+			err:  "gomarkdoc: site documentation must use a markdown format",
+		},
+		"hugo nav": {
+			args: []string{"--site", "hugo", "--site-nav", "nav.yml"},
+			err:  "gomarkdoc: hugo sites don't use a navigation file",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			err := os.Chdir(filepath.Join(wd, "../../testData"))
+			is.NoErr(err)
+
+			os.Args = append([]string{"gomarkdoc", "./simple"}, test.args...)
+
+			cmd := buildCommand()
+			err = cmd.Execute()
+			is.True(err != nil)
+			is.Equal(err.Error(), test.err)
+		})
+	}
+}
+
+func TestCommand_sharedOutput(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./shared/...",
+		"-o", "./shared/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "shared")
+
+	main()
+
+	verify(t, "shared", "github")
+}
+
+func TestCommand_crossPackageLinks(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./crosslink/...",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "crosslink")
+	cleanup(t, "crosslink/inner")
+
+	main()
+
+	verifyLinked(t, "crosslink", "github")
+	verifyLinked(t, "crosslink/inner", "github")
+}
+
+func TestCommand_gitlab(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./docs",
+		"-o", "{{.Dir}}/README-gitlab-test.md",
+		"--format", "gitlab",
+		"--table-of-contents",
+		"--repository.url", "https://gitlab.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "docs")
+
+	main()
+
+	verify(t, "docs", "gitlab")
+}
+
+func TestCommand_gitlabTableOfContents(t *testing.T) {
+	tests := map[string]struct {
+		args     []string
+		expected bool
+	}{
+		"default":   {nil, false},
+		"requested": {[]string{"--table-of-contents"}, true},
+		"embedded":  {[]string{"--table-of-contents", "--embed"}, false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			err := os.Chdir(filepath.Join(wd, "../../testData"))
+			is.NoErr(err)
+
+			os.Args = append([]string{
+				"gomarkdoc", "./simple",
+				"-o", "{{.Dir}}/README-gitlab-test.md",
+				"--format", "gitlab",
+			}, test.args...)
+			cleanup(t, "simple")
+			t.Cleanup(func() { cleanup(t, "simple") })
+
+			cmd := buildCommand()
+			is.NoErr(cmd.Execute())
+
+			data, err := os.ReadFile(filepath.Join("simple", "README-gitlab-test.md"))
+			is.NoErr(err)
+			is.Equal(strings.Contains(string(data), "[[_TOC_]]"), test.expected)
+		})
+	}
+}
+
+func TestCommand_repositoryHost(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://git.example.com/projects/PROJ/repos/gomarkdoc",
+		"--repository.default-branch", "main",
+		"--repository.path", "/testData/",
+		"--repository.host", "bitbucket-server",
+	}
+	cleanup(t, "simple")
+	t.Cleanup(func() { cleanup(t, "simple") })
+
+	main()
+
+	data, err := os.ReadFile(filepath.Join("simple", "README-github-test.md"))
+	is.NoErr(err)
+	is.True(strings.Contains(
+		string(data),
+		"(<https://git.example.com/projects/PROJ/repos/gomarkdoc/browse/testData/simple/main.go?at=refs/heads/main#8>)",
+	))
+}
+
+func TestCommand_invalidRepositoryHost(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"--repository.host", "sourceforge",
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), `gomarkdoc: invalid repository host "sourceforge"`)
+}
+
+func TestCommand_html(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./docs",
+		"-o", "{{.Dir}}/README-html-test.md",
+		"--format", "html",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "docs")
+
+	main()
+
+	verify(t, "docs", "html")
+}
+
+func TestCommand_htmlExampleOutput(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./examples", "./simple",
+		"-o", "{{.Dir}}/README-html-test.md",
+		"--format", "html",
+	}
+	cleanup(t, "examples")
+	cleanup(t, "simple")
+	t.Cleanup(func() {
+		cleanup(t, "examples")
+		cleanup(t, "simple")
+	})
+
+	cmd := buildCommand()
+	is.NoErr(cmd.Execute())
+
+	// The examples each have a header for their output, which get distinct ids
+	data, err := os.ReadFile(filepath.Join("examples", "README-html-test.md"))
+	is.NoErr(err)
+	is.True(strings.Contains(string(data), `<h4 id="output">Output</h4>`))
+	is.True(strings.Contains(string(data), `<h4 id="output-1">Output</h4>`))
+	is.True(strings.Contains(string(data), `<h4 id="output-2">Output</h4>`))
+
+	// Ids are only unique within each file
+	data, err = os.ReadFile(filepath.Join("simple", "README-html-test.md"))
+	is.NoErr(err)
+	is.True(strings.Contains(string(data), `<h2 id="index">Index</h2>`))
+	is.True(!strings.Contains(string(data), `id="index-1"`))
+}
+
+func TestCommand_htmlStylesheet(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	stylesheet := filepath.Join(t.TempDir(), "theme.css")
+	err = os.WriteFile(stylesheet, []byte(":root {\n  --gomarkdoc-link: rebeccapurple;\n}\n"), 0o644)
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"-o", "{{.Dir}}/README-html-test.md",
+		"--format", "html",
+		"--stylesheet-file", stylesheet,
+	}
+	cleanup(t, "simple")
+	t.Cleanup(func() { cleanup(t, "simple") })
+
+	main()
+
+	data, err := os.ReadFile(filepath.Join("simple", "README-html-test.md"))
+	is.NoErr(err)
+
+	page := string(data)
+	is.True(strings.HasPrefix(page, "<!DOCTYPE html>"))
+	is.True(strings.Contains(page, "<title>simple</title>"))
+
+	// The theme comes after the default stylesheet so that it takes precedence
+	is.True(strings.Index(page, "--gomarkdoc-link: #0969da;") < strings.Index(page, "--gomarkdoc-link: rebeccapurple;"))
+}
+
+func TestCommand_implements(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./implements/...",
+		"--type-check",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "implements")
+	cleanup(t, "implements/plugins")
+
+	main()
+
+	verifyLinked(t, "implements", "github")
+	verifyLinked(t, "implements/plugins", "github")
+}
+
+func TestCommand_unexported(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	harness(t, "unexported", []string{
+		"gomarkdoc", "./unexported",
+		"-u",
+		"-o", "{{.Dir}}/README-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	})
+}
+
+func TestCommand_version(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{"gomarkdoc", "--version"}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() { os.Stdout = oldStdout }()
+
+	main()
+	w.Close()
+
+	data, err := io.ReadAll(r)
+	is.NoErr(err)
+
+	is.Equal(strings.TrimSpace(string(data)), "(devel)")
+}
+
+func TestCommand_invalidCheck(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"-c",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "simple")
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	t.Log(err.Error())
+
+	is.Equal(err.Error(), "gomarkdoc: check mode cannot be run without an output set")
+}
+
+func TestCommand_invalidDocLinks(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"--doc-links", "example.com/",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "simple")
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	t.Log(err.Error())
+
+	is.Equal(err.Error(), `gomarkdoc: invalid doc link "example.com/", expected prefix=template`)
+}
+
+func TestCommand_defaultDirectory(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData/simple"))
+	is.NoErr(err)
+
+	harness(t, ".", []string{
+		"gomarkdoc",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/simple/",
+	})
+}
+
+func TestCommand_nonexistant(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./nonexistant",
+		"-o", "{{.Dir}}/README-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	t.Log(err.Error())
+	is.Equal(err.Error(), fmt.Sprintf("gomarkdoc: invalid package in directory: .%snonexistant", string(filepath.Separator)))
+}
+
+func TestCommand_tags(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	harness(t, "tags", []string{
+		"gomarkdoc", "./tags",
+		"--tags", "tagged",
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
 		"--repository.path", "/testData/",
-	}
-	cleanup(t, "nested")
-	cleanup(t, "nested/inner")
+	})
+}
 
-	main()
+func TestCommand_typeCheck(t *testing.T) {
+	is := is.New(t)
 
-	verify(t, "nested", "github")
-	verify(t, "nested/inner", "github")
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	harness(t, "typecheck", []string{
+		"gomarkdoc", "./typecheck",
+		"--type-check",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	})
 }
 
-func TestCommand_unexported(t *testing.T) {
+func TestCommand_promoted(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	harness(t, "unexported", []string{
-		"gomarkdoc", "./unexported",
-		"-u",
-		"-o", "{{.Dir}}/README-test.md",
+	harness(t, "promoted", []string{
+		"gomarkdoc", "./promoted",
+		"--type-check",
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
 		"--repository.path", "/testData/",
 	})
 }
 
-func TestCommand_version(t *testing.T) {
+func TestCommand_deprecated(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	os.Args = []string{"gomarkdoc", "--version"}
+	harness(t, "deprecated", []string{
+		"gomarkdoc", "./deprecated",
+		"--collapse-deprecated",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	})
+}
 
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-	defer func() { os.Stdout = oldStdout }()
+func TestCommand_fields(t *testing.T) {
+	is := is.New(t)
 
-	main()
-	w.Close()
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
 
-	data, err := io.ReadAll(r)
+	harness(t, "fields", []string{
+		"gomarkdoc", "./fields",
+		"--field-style", "table",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	})
+}
+
+func TestCommand_buildMatrix(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	is.Equal(strings.TrimSpace(string(data)), "(devel)")
+	harness(t, "matrix", []string{
+		"gomarkdoc", "./matrix",
+		"--build-matrix", "linux/amd64",
+		"--build-matrix", "darwin/arm64",
+		"--build-matrix", "windows/amd64",
+		"--build-matrix", "linux/amd64,enterprise",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	})
 }
 
-func TestCommand_invalidCheck(t *testing.T) {
+func TestCommand_buildMatrixLoading(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
 	os.Args = []string{
-		"gomarkdoc", "./simple",
-		"-c",
+		"gomarkdoc", "./winonly", "./matrix",
+		"--build-matrix", "linux/amd64",
+		"--build-matrix", "windows/amd64",
+		"--type-check",
+		"-o", "{{.Dir}}/README-github-test.md",
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
 		"--repository.path", "/testData/",
 	}
-	cleanup(t, "simple")
+	cleanup(t, "winonly")
+	cleanup(t, "matrix")
 
 	cmd := buildCommand()
 	err = cmd.Execute()
-	t.Log(err.Error())
+	is.NoErr(err)
 
-	is.Equal(err.Error(), "gomarkdoc: check mode cannot be run without an output set")
+	data, err := os.ReadFile("./winonly/README-github-test.md")
+	is.NoErr(err)
+	is.True(strings.Contains(string(data), "<kbd>windows only</kbd>"))
+
+	// Handle is only built on windows, so its types come from the windows context
+	data, err = os.ReadFile("./matrix/README-github-test.md")
+	is.NoErr(err)
+	is.True(strings.Contains(string(data), `Handle() <a href="https://pkg.go.dev/builtin/#uintptr">uintptr</a>`))
 }
 
-func TestCommand_defaultDirectory(t *testing.T) {
+func TestCommand_invalidBuildMatrix(t *testing.T) {
 	is := is.New(t)
 
-	err := os.Chdir(filepath.Join(wd, "../../testData/simple"))
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	harness(t, ".", []string{
-		"gomarkdoc",
+	os.Args = []string{
+		"gomarkdoc", "./matrix",
+		"--build-matrix", "linux",
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
-		"--repository.path", "/testData/simple/",
+		"--repository.path", "/testData/",
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), `gomarkdoc: invalid build context "linux", expected GOOS/GOARCH[,tag...]`)
+}
+
+func TestCommand_symbolFilters(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	harness(t, "filters", []string{
+		"gomarkdoc", "./filters",
+		"--exclude-symbols", "Internal*",
+		"--exclude-symbols", "Client.Undo",
+		"--exclude-kinds", "var",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
 	})
 }
 
-func TestCommand_nonexistant(t *testing.T) {
+func TestCommand_invalidSymbolKind(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
 	os.Args = []string{
-		"gomarkdoc", "./nonexistant",
-		"-o", "{{.Dir}}/README-test.md",
+		"gomarkdoc", "./filters",
+		"--include-kinds", "interface",
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
 		"--repository.path", "/testData/",
@@ -171,25 +812,205 @@ func TestCommand_nonexistant(t *testing.T) {
 
 	cmd := buildCommand()
 	err = cmd.Execute()
-	t.Log(err.Error())
-	is.Equal(err.Error(), fmt.Sprintf("gomarkdoc: invalid package in directory: .%snonexistant", string(filepath.Separator)))
+	is.True(err != nil)
+	is.Equal(err.Error(), `gomarkdoc: invalid symbol kind "interface"`)
 }
 
-func TestCommand_tags(t *testing.T) {
+func TestCommand_includeMemberKind(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	harness(t, "tags", []string{
-		"gomarkdoc", "./tags",
-		"--tags", "tagged",
+	os.Args = []string{
+		"gomarkdoc", "./filters",
+		"--include-kinds", "method",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), `gomarkdoc: symbol kind "method" can't be included, only excluded`)
+}
+
+func TestCommand_symbolOrder(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	harness(t, "ordering", []string{
+		"gomarkdoc", "./ordering",
+		"--symbol-order", "category",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	})
+}
+
+func TestCommand_hideDeprecated(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./deprecated",
+		"--hide-deprecated",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "deprecated")
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	data, err := os.ReadFile("./deprecated/README-github-test.md")
+	is.NoErr(err)
+
+	for _, name := range []string{"OldVersion", "Default", "Start", "Client", "Reset", "ModeLegacy"} {
+		is.True(!strings.Contains(string(data), fmt.Sprintf(`<a name="%s">`, name))) // deprecated symbol rendered
+	}
+
+	is.True(!strings.Contains(string(data), "deprecated\\)"))
+	is.True(strings.Contains(string(data), `<a name="Settings.Apply">`))
+	is.True(strings.Contains(string(data), `<a name="NewClient">`))
+	is.True(strings.Contains(string(data), `<a name="DefaultClient">`))
+	is.True(strings.Contains(string(data), `<a name="ModeCurrent">`))
+}
+
+func TestCommand_verifyExamples(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	harness(t, "./examples", []string{
+		"gomarkdoc", "./examples",
+		"--verify-examples",
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
 		"--repository.path", "/testData/",
 	})
 }
 
+func TestCommand_verifyExamplesMismatch(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./examples/stale",
+		"--verify-examples",
+		"--tags", "verifyexamples",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "examples/stale")
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), "gomarkdoc: example output does not match the documented output")
+
+	// Nothing is written when the examples don't match
+	_, err = os.Stat("./examples/stale/README-github-test.md")
+	is.True(os.IsNotExist(err))
+}
+
+func TestCommand_verifyExamplesInPackage(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./examples/inpackage",
+		"--verify-examples",
+		"--tags", "verifyexamples",
+		"-o", "{{.Dir}}/README-github-test.md",
+	}
+	cleanup(t, "examples/inpackage")
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), "gomarkdoc: example output does not match the documented output")
+}
+
+func TestCommand_verifyExamplesNotBuilt(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	// Without the tag, the stale examples aren't built, so they're reported as
+	// unverified instead of failing
+	os.Args = []string{
+		"gomarkdoc", "./examples/stale",
+		"--verify-examples",
+		"-o", "{{.Dir}}/README-github-test.md",
+	}
+	cleanup(t, "examples/stale")
+	t.Cleanup(func() { cleanup(t, "examples/stale") })
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+}
+
+func TestCommand_verifyExamplesScratchModule(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                  "module example.com/verify\n\ngo 1.22\n",
+		"internal/greet/greet.go": "package greet\n\nfunc Hello(name string) string { return \"Hello, \" + name }\n",
+		"verify/verify.go": "// Package verify greets people.\npackage verify\n\n" +
+			"import \"example.com/verify/internal/greet\"\n\n" +
+			"// Greet greets the provided person.\nfunc Greet(name string) string { return greet.Hello(name) }\n",
+		"verify/example_test.go": "package verify_test\n\n" +
+			"import (\n\t\"fmt\"\n\n\t\"example.com/verify/verify\"\n)\n\n" +
+			"func ExampleGreet() {\n\tfmt.Println(verify.Greet(\"gopher\"))\n\t// Output: Hello, gopher\n}\n",
+		"verify/internal_test.go": "package verify\n\nimport \"fmt\"\n\n" +
+			"func ExampleGreet_internal() {\n\tfmt.Println(Greet(\"you\"))\n\t// Output: Hello, you\n}\n",
+		// Test files without examples aren't built, so this one doesn't get in
+		// the way
+		"verify/broken_test.go": "package verify\n\nfunc TestBroken(t *testing.T) { undefined() }\n",
+	}
+
+	for name, contents := range files {
+		is.NoErr(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		is.NoErr(os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+	}
+
+	err := os.Chdir(dir)
+	is.NoErr(err)
+	defer func() { _ = os.Chdir(wd) }()
+
+	os.Args = []string{"gomarkdoc", "./verify", "--verify-examples", "-o", "README.md"}
+
+	cmd := buildCommand()
+	is.NoErr(cmd.Execute())
+
+	// The module of the package is left alone
+	data, err := os.ReadFile("go.mod")
+	is.NoErr(err)
+	is.Equal(string(data), files["go.mod"])
+
+	_, err = os.Stat("go.sum")
+	is.True(os.IsNotExist(err))
+}
+
 func TestCommand_tagsWithGOFLAGS(t *testing.T) {
 	is := is.New(t)
 
@@ -266,6 +1087,44 @@ func TestCommand_tagsWithGOFLAGSNoParse(t *testing.T) {
 	verifyNotEqual(t, "./tags", "github")
 }
 
+func TestCommand_loadErrors(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./winonly",
+		"--config", "../.gomarkdoc-empty.yml",
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.True(strings.HasPrefix(err.Error(), "gomarkdoc: failed to load package "))
+	is.True(strings.Contains(err.Error(), "build constraints exclude all Go files"))
+}
+
+func TestGoEnv(t *testing.T) {
+	is := is.New(t)
+
+	t.Setenv("GOFLAGS", "-tags=tagged -mod=vendor -modfile=go.alt.mod invalid -other=foo --buildvcs=false")
+
+	var goflags []string
+	for _, e := range goEnv() {
+		if strings.HasPrefix(e, "GOFLAGS=") {
+			goflags = append(goflags, e)
+		}
+	}
+
+	is.Equal(goflags, []string{"GOFLAGS=-modfile=go.alt.mod --buildvcs=false"})
+
+	t.Setenv("GOFLAGS", "-tags=tagged")
+	for _, e := range goEnv() {
+		is.True(!strings.HasPrefix(e, "GOFLAGS="))
+	}
+}
+
 func TestCommand_embed(t *testing.T) {
 	is := is.New(t)
 
@@ -377,6 +1236,45 @@ func verify(t *testing.T, dir, format string) {
 	is.Equal(string(data), string(data2))
 }
 
+// verifyLinked is like verify, but it accounts for links between the
+// documentation of multiple packages pointing to the test output files instead
+// of the expected ones.
+func verifyLinked(t *testing.T, dir, format string) {
+	is := is.New(t)
+
+	data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("README-%s.md", format)))
+	is.NoErr(err)
+
+	data2, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("README-%s-test.md", format)))
+	is.NoErr(err)
+
+	normalized := strings.ReplaceAll(
+		string(data2),
+		fmt.Sprintf("README-%s-test.md", format),
+		fmt.Sprintf("README-%s.md", format),
+	)
+	is.Equal(string(data), normalized)
+}
+
+// verifyNav compares the navigation generated for a site with the expected
+// one, accounting for its entries pointing to the test output files.
+func verifyNav(t *testing.T, expected, actual, format string) {
+	is := is.New(t)
+
+	data, err := os.ReadFile(expected)
+	is.NoErr(err)
+
+	data2, err := os.ReadFile(actual)
+	is.NoErr(err)
+
+	normalized := strings.ReplaceAll(
+		string(data2),
+		fmt.Sprintf("README-%s-test", format),
+		fmt.Sprintf("README-%s", format),
+	)
+	is.Equal(string(data), normalized)
+}
+
 func verifyNotEqual(t *testing.T, dir, format string) {
 	is := is.New(t)
 
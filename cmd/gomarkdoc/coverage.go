@@ -0,0 +1,306 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/princjef/gomarkdoc/format"
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+type coverageOptions struct {
+	threshold   float64
+	json        bool
+	output      string
+	format      string
+	tags        []string
+	excludeDirs []string
+	verbosity   int
+}
+
+type (
+	// coverageReport is the JSON representation of the documentation coverage
+	// of a set of packages.
+	coverageReport struct {
+		Packages []packageCoverage `json:"packages"`
+		Total    kindCoverage      `json:"total"`
+	}
+
+	// packageCoverage is the JSON representation of the documentation
+	// coverage of a single package.
+	packageCoverage struct {
+		ImportPath string         `json:"importPath"`
+		Kinds      []kindCoverage `json:"kinds"`
+		Total      kindCoverage   `json:"total"`
+	}
+
+	// kindCoverage is the JSON representation of the documentation coverage
+	// of the symbols of a single kind, or of all symbols for totals.
+	kindCoverage struct {
+		Kind            string  `json:"kind,omitempty"`
+		Symbols         int     `json:"symbols"`
+		Documented      int     `json:"documented"`
+		MissingExamples int     `json:"missingExamples"`
+		Misnamed        int     `json:"misnamed"`
+		Percent         float64 `json:"percent"`
+	}
+)
+
+func buildCoverageCommand() *cobra.Command {
+	var opts coverageOptions
+	var configFile string
+
+	var command = &cobra.Command{
+		Use:   "coverage [package ...]",
+		Short: "report how thoroughly the exported symbols of packages are documented",
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			buildConfig(configFile)
+
+			opts.threshold = viper.GetFloat64("coverage.threshold")
+
+			if len(args) == 0 {
+				// Default to current directory
+				args = []string{"."}
+			}
+
+			return runCoverage(args, opts)
+		},
+	}
+
+	command.Flags().StringVar(
+		&configFile,
+		"config",
+		"",
+		fmt.Sprintf("File from which to load configuration (default: %s.yml)", configFilePrefix),
+	)
+	command.Flags().Float64Var(
+		&opts.threshold,
+		"threshold",
+		0,
+		"Minimum percentage of exported symbols that must be documented. The command fails below it.",
+	)
+	command.Flags().BoolVar(
+		&opts.json,
+		"json",
+		false,
+		"Write the report as JSON instead of a markdown table.",
+	)
+	command.Flags().StringVarP(
+		&opts.output,
+		"output",
+		"o",
+		"",
+		"File to write the report to. Defaults to printing to stdout.",
+	)
+	command.Flags().StringVarP(
+		&opts.format,
+		"format",
+		"f",
+		"github",
+		"Format to use for writing output data. Valid options: github (default), azure-devops, gitlab, plain",
+	)
+	command.Flags().StringSliceVar(
+		&opts.tags,
+		"tags",
+		defaultTags(),
+		"Set of build tags to apply when choosing which files to include for documentation generation.",
+	)
+	command.Flags().StringSliceVar(
+		&opts.excludeDirs,
+		"exclude-dirs",
+		nil,
+		"List of package directories to ignore when producing documentation.",
+	)
+	command.Flags().CountVarP(
+		&opts.verbosity,
+		"verbose",
+		"v",
+		"Log additional output from the execution of the command. Can be chained for additional verbosity.",
+	)
+
+	// We ignore the errors here because they only happen if the specified flag doesn't exist
+	_ = viper.BindPFlag("coverage.threshold", command.Flags().Lookup("threshold"))
+
+	return command
+}
+
+func runCoverage(paths []string, opts coverageOptions) error {
+	f, err := resolveFormat(opts.format)
+	if err != nil {
+		return err
+	}
+
+	specs := getSpecs(paths...)
+
+	excluded := getSpecs(opts.excludeDirs...)
+	if err := validateExcludes(excluded); err != nil {
+		return err
+	}
+
+	specs = removeExcludes(specs, excluded)
+
+	loadOpts := commandOptions{
+		tags:      opts.tags,
+		mod:       defaultMod(),
+		verbosity: opts.verbosity,
+	}
+	if err := loadPackages(specs, loadOpts, nil); err != nil {
+		return err
+	}
+
+	report := newCoverageReport(specs)
+
+	var text string
+	if opts.json {
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("gomarkdoc: failed to write coverage report: %w", err)
+		}
+
+		text = string(b) + "\n"
+	} else {
+		text, err = coverageTable(f, report)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.output == "" {
+		fmt.Fprint(os.Stdout, text)
+	} else if err := writeFile(opts.output, text); err != nil {
+		return fmt.Errorf("gomarkdoc: failed to write output file %s: %w", opts.output, err)
+	}
+
+	if report.Total.Percent < opts.threshold {
+		return fmt.Errorf(
+			"gomarkdoc: documentation coverage of %s is below the threshold of %s",
+			formatPercent(report.Total.Percent),
+			formatPercent(opts.threshold),
+		)
+	}
+
+	return nil
+}
+
+// newCoverageReport aggregates the documentation coverage of the loaded
+// packages.
+func newCoverageReport(specs []*PackageSpec) coverageReport {
+	var report coverageReport
+	var total lang.Coverage
+	for _, spec := range specs {
+		if spec.pkg == nil {
+			continue
+		}
+
+		pkg := packageCoverage{ImportPath: spec.pkg.ImportPath()}
+		var pkgTotal lang.Coverage
+		for _, c := range spec.pkg.Coverage() {
+			pkg.Kinds = append(pkg.Kinds, newKindCoverage(c))
+			pkgTotal = addCoverage(pkgTotal, c)
+		}
+
+		pkg.Total = newKindCoverage(pkgTotal)
+		report.Packages = append(report.Packages, pkg)
+		total = addCoverage(total, pkgTotal)
+	}
+
+	report.Total = newKindCoverage(total)
+	return report
+}
+
+func newKindCoverage(c lang.Coverage) kindCoverage {
+	var kind string
+	if c.Kind != 0 {
+		kind = c.Kind.String()
+	}
+
+	return kindCoverage{
+		Kind:            kind,
+		Symbols:         c.Symbols,
+		Documented:      c.Documented(),
+		MissingExamples: c.MissingExamples,
+		Misnamed:        c.Misnamed,
+		Percent:         c.Percent(),
+	}
+}
+
+func addCoverage(total, c lang.Coverage) lang.Coverage {
+	total.Symbols += c.Symbols
+	total.Undocumented += c.Undocumented
+	total.MissingExamples += c.MissingExamples
+	total.Misnamed += c.Misnamed
+	return total
+}
+
+// coverageTable renders the report as a table with a row for each kind of
+// symbol in each package, followed by the totals.
+func coverageTable(f format.Format, report coverageReport) (string, error) {
+	header, err := format.TableHeader(
+		f,
+		"Package",
+		"Kind",
+		"Symbols",
+		"Documented",
+		"Coverage",
+		"Missing Examples",
+		"Misnamed",
+	)
+	if err != nil {
+		return "", err
+	}
+
+	rows := []string{header}
+	addRow := func(name, kind string, c kindCoverage) error {
+		row, err := format.TableRow(
+			f,
+			name,
+			kind,
+			strconv.Itoa(c.Symbols),
+			strconv.Itoa(c.Documented),
+			formatPercent(c.Percent),
+			strconv.Itoa(c.MissingExamples),
+			strconv.Itoa(c.Misnamed),
+		)
+		if err != nil {
+			return err
+		}
+
+		rows = append(rows, row)
+		return nil
+	}
+
+	for _, pkg := range report.Packages {
+		name, err := format.Code(f, pkg.ImportPath)
+		if err != nil {
+			return "", err
+		}
+
+		for _, c := range pkg.Kinds {
+			if err := addRow(name, c.Kind, c); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	total, err := f.Bold("Total")
+	if err != nil {
+		return "", err
+	}
+
+	if err := addRow(total, "", report.Total); err != nil {
+		return "", err
+	}
+
+	return strings.Join(rows, "\n") + "\n", nil
+}
+
+func formatPercent(p float64) string {
+	return strconv.FormatFloat(p, 'f', 1, 64) + "%"
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCoverage(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{"gomarkdoc", "coverage", "./coverage", "-o", "./coverage/COVERAGE-test.md"}
+	t.Cleanup(func() { os.Remove("./coverage/COVERAGE-test.md") })
+
+	cmd := buildCommand()
+	is.NoErr(cmd.Execute())
+
+	data, err := os.ReadFile("./coverage/COVERAGE-test.md")
+	is.NoErr(err)
+
+	pkg := "`github.com/princjef/gomarkdoc/testData/coverage`"
+	is.Equal(string(data), ""+
+		"| Package | Kind | Symbols | Documented | Coverage | Missing Examples | Misnamed |\n"+
+		"| --- | --- | --- | --- | --- | --- | --- |\n"+
+		"| "+pkg+" | type | 2 | 2 | 100.0% | 2 | 0 |\n"+
+		"| "+pkg+" | func | 2 | 1 | 50.0% | 1 | 0 |\n"+
+		"| "+pkg+" | const | 4 | 3 | 75.0% | 0 | 0 |\n"+
+		"| "+pkg+" | var | 1 | 1 | 100.0% | 0 | 1 |\n"+
+		"| "+pkg+" | method | 4 | 2 | 50.0% | 1 | 0 |\n"+
+		"| "+pkg+" | field | 2 | 1 | 50.0% | 0 | 0 |\n"+
+		"| **Total** |  | 15 | 10 | 66.7% | 4 | 1 |\n")
+}
+
+func TestCoverage_json(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{"gomarkdoc", "coverage", "./coverage", "--json", "-o", "./coverage/COVERAGE-test.json"}
+	t.Cleanup(func() { os.Remove("./coverage/COVERAGE-test.json") })
+
+	cmd := buildCommand()
+	is.NoErr(cmd.Execute())
+
+	data, err := os.ReadFile("./coverage/COVERAGE-test.json")
+	is.NoErr(err)
+
+	var report coverageReport
+	is.NoErr(json.Unmarshal(data, &report))
+
+	is.Equal(len(report.Packages), 1)
+	is.Equal(report.Packages[0].ImportPath, "github.com/princjef/gomarkdoc/testData/coverage")
+	is.Equal(len(report.Packages[0].Kinds), 6)
+	is.Equal(report.Packages[0].Kinds[4], kindCoverage{
+		Kind:            "method",
+		Symbols:         4,
+		Documented:      2,
+		MissingExamples: 1,
+		Percent:         50,
+	})
+	is.Equal(report.Total.Symbols, 15)
+	is.Equal(report.Total.Documented, 10)
+}
+
+func TestCoverage_threshold(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "coverage", "./coverage",
+		"--threshold", "70",
+		"-o", "./coverage/COVERAGE-test.md",
+	}
+	t.Cleanup(func() { os.Remove("./coverage/COVERAGE-test.md") })
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), "gomarkdoc: documentation coverage of 66.7% is below the threshold of 70.0%")
+
+	// The report is still written so that the gaps can be found
+	_, err = os.Stat("./coverage/COVERAGE-test.md")
+	is.NoErr(err)
+}
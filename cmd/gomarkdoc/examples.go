@@ -0,0 +1,378 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/princjef/gomarkdoc/lang"
+	"github.com/princjef/gomarkdoc/logger"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// exampleResult holds the outcome of running an example with go test.
+type exampleResult struct {
+	passed bool
+	output string
+}
+
+// testEvent is an event reported by go test -json.
+type testEvent struct {
+	Action string
+	Test   string
+	Output string
+}
+
+// verifyExamples runs the examples with documented output from the provided
+// packages and compares what they print with what is documented. The examples
+// are run with go test in a scratch module (see newExampleModule), so the
+// local toolchain is used without touching the module of the package, and
+// examples in both the package itself and its external test package are
+// covered. Mismatches are reported to stderr along with the location of the
+// example.
+func verifyExamples(specs []*PackageSpec, opts commandOptions) error {
+	log := logger.New(getLogLevel(opts.verbosity))
+
+	var failed bool
+	for _, spec := range specs {
+		if spec.pkg == nil {
+			continue
+		}
+
+		var examples []*lang.Example
+		for _, ex := range packageExamples(spec.pkg) {
+			if ex.HasOutput() {
+				examples = append(examples, ex)
+			}
+		}
+
+		if len(examples) == 0 {
+			continue
+		}
+
+		results, err := runExamples(examples, opts)
+		if errors.Is(err, errNoModule) {
+			log.Warnf("unable to verify the example output of %s. The package isn't in a module", spec.ImportPath)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		for _, ex := range examples {
+			res, ok := results[ex.FuncName()]
+			if !ok {
+				log.Warnf(
+					"%s: unable to verify example output. The example isn't built with the current build tags",
+					exampleLocation(ex),
+				)
+				continue
+			}
+
+			if res.passed {
+				log.Debugf("%s: example output matches", exampleLocation(ex))
+				continue
+			}
+
+			failed = true
+			fmt.Fprintf(os.Stderr, "%s: example output does not match\n%s", exampleLocation(ex), res.output)
+		}
+	}
+
+	if failed {
+		return errors.New("gomarkdoc: example output does not match the documented output")
+	}
+
+	return nil
+}
+
+// runExamples runs the provided examples of a package with go test in a
+// scratch module. The results are keyed by the name of the example function.
+// Examples that aren't built with the configured build tags don't have a
+// result.
+func runExamples(examples []*lang.Example, opts commandOptions) (map[string]exampleResult, error) {
+	dir, err := os.MkdirTemp("", "gomarkdoc-examples-")
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to create module for examples: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := newExampleModule(dir, examples); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(examples))
+	for i, ex := range examples {
+		names[i] = regexp.QuoteMeta(ex.FuncName())
+	}
+
+	// The scratch module starts out without the requirements of the package,
+	// so the go command is allowed to add them
+	args := []string{"test", "-json", "-vet=off", "-mod=mod", "-run", fmt.Sprintf("^(%s)$", strings.Join(names, "|"))}
+	if len(opts.tags) > 0 {
+		args = append(args, fmt.Sprintf("-tags=%s", strings.Join(opts.tags, ",")))
+	}
+
+	args = append(args, ".")
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(exampleEnv(), "GOWORK=off")
+	out, runErr := cmd.Output()
+
+	var (
+		results   = make(map[string]exampleResult)
+		outputs   = make(map[string]*strings.Builder)
+		pkgOutput strings.Builder
+	)
+	for _, line := range strings.Split(string(out), "\n") {
+		var event testEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			continue
+		}
+
+		if event.Test == "" {
+			pkgOutput.WriteString(event.Output)
+			continue
+		}
+
+		if outputs[event.Test] == nil {
+			outputs[event.Test] = &strings.Builder{}
+		}
+
+		switch event.Action {
+		case "output":
+			// The lines announcing the example and its outcome aren't useful in
+			// the report of a mismatch
+			if !strings.HasPrefix(event.Output, "=== ") && !strings.HasPrefix(event.Output, "--- ") {
+				outputs[event.Test].WriteString(event.Output)
+			}
+		case "pass", "fail":
+			results[event.Test] = exampleResult{
+				passed: event.Action == "pass",
+				output: outputs[event.Test].String(),
+			}
+		}
+	}
+
+	if runErr != nil && len(results) == 0 {
+		var stderr string
+		if exitErr, ok := runErr.(*exec.ExitError); ok {
+			stderr = string(exitErr.Stderr)
+		}
+
+		return nil, fmt.Errorf("gomarkdoc: failed to run examples: %w\n%s%s", runErr, pkgOutput.String(), stderr)
+	}
+
+	return results, nil
+}
+
+// errNoModule is returned when the examples of a package can't be run because
+// the package isn't part of a module.
+var errNoModule = errors.New("gomarkdoc: package isn't in a module")
+
+// exampleModulePath is the last element of the path of the scratch module that
+// examples are run in.
+const exampleModulePath = "gomarkdoc_examples"
+
+// newExampleModule sets up a scratch module in the provided directory to run
+// the examples of a package in. The module holds a copy of the package along
+// with the test files declaring the examples, but none of its other test
+// files. The module containing the package is required through a replace
+// directive pointing at its directory, so the examples in the external test
+// package use it directly. The path of the scratch module is nested within
+// the import path of the package so that the copy can still import the same
+// internal packages.
+func newExampleModule(dir string, examples []*lang.Example) error {
+	pkgDir := filepath.Dir(examples[0].Location().Filepath)
+	modFile, ok := findModuleFile(pkgDir)
+	if !ok {
+		return errNoModule
+	}
+
+	data, err := os.ReadFile(modFile)
+	if err != nil {
+		return fmt.Errorf("gomarkdoc: failed to read %s: %w", modFile, err)
+	}
+
+	orig, err := modfile.Parse(modFile, data, nil)
+	if err != nil {
+		return fmt.Errorf("gomarkdoc: failed to parse %s: %w", modFile, err)
+	}
+
+	if orig.Module == nil || orig.Module.Mod.Path == "std" {
+		return errNoModule
+	}
+
+	modPath, modDir := orig.Module.Mod.Path, filepath.Dir(modFile)
+	rel, err := filepath.Rel(modDir, pkgDir)
+	if err != nil {
+		return err
+	}
+
+	version := "v0.0.0"
+	if _, major, ok := module.SplitPathVersion(modPath); ok && major != "" {
+		version = module.PathMajorPrefix(major) + ".0.0"
+	}
+
+	f := &modfile.File{}
+	_ = f.AddModuleStmt(path.Join(modPath, filepath.ToSlash(rel), exampleModulePath))
+	if orig.Go != nil {
+		_ = f.AddGoStmt(orig.Go.Version)
+	}
+
+	_ = f.AddRequire(modPath, version)
+	_ = f.AddReplace(modPath, "", modDir, "")
+
+	// Replacements only apply in the main module, so the ones of the module
+	// containing the package are carried over
+	for _, r := range orig.Replace {
+		newPath := r.New.Path
+		if r.New.Version == "" && !filepath.IsAbs(newPath) {
+			newPath = filepath.Join(modDir, newPath)
+		}
+
+		_ = f.AddReplace(r.Old.Path, r.Old.Version, newPath, r.New.Version)
+	}
+
+	f.Cleanup()
+	out, err := f.Format()
+	if err != nil {
+		return fmt.Errorf("gomarkdoc: failed to create module for examples: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), out, 0644); err != nil {
+		return fmt.Errorf("gomarkdoc: failed to create module for examples: %w", err)
+	}
+
+	err = copyFile(filepath.Join(modDir, "go.sum"), filepath.Join(dir, "go.sum"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("gomarkdoc: failed to create module for examples: %w", err)
+	}
+
+	exampleFiles := make(map[string]bool)
+	for _, ex := range examples {
+		exampleFiles[filepath.Base(ex.Location().Filepath)] = true
+	}
+
+	entries, err := os.ReadDir(pkgDir)
+	if err != nil {
+		return fmt.Errorf("gomarkdoc: failed to read directory %s: %w", pkgDir, err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		switch {
+		case !entry.Type().IsRegular(), name == "go.mod", name == "go.sum":
+			continue
+		case strings.HasSuffix(name, "_test.go") && !exampleFiles[name]:
+			continue
+		}
+
+		if err := copyFile(filepath.Join(pkgDir, name), filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("gomarkdoc: failed to copy %s for examples: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// findModuleFile finds the go.mod file of the module containing the provided
+// directory.
+func findModuleFile(dir string) (string, bool) {
+	for {
+		p := filepath.Join(dir, "go.mod")
+		if info, err := os.Stat(p); err == nil && info.Mode().IsRegular() {
+			return p, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
+
+// copyFile copies the contents of the file at src to a new file at dst.
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(dst, data, 0644)
+}
+
+// exampleEnv provides the environment for running examples in a scratch
+// module. It's the same as the one for the go command in general, except that
+// a -modfile flag in GOFLAGS is dropped since it refers to the module of the
+// package rather than the scratch module.
+func exampleEnv() []string {
+	env := goEnv()
+	for i, e := range env {
+		if !strings.HasPrefix(e, "GOFLAGS=") {
+			continue
+		}
+
+		var flags []string
+		for _, f := range strings.Fields(strings.TrimPrefix(e, "GOFLAGS=")) {
+			if !strings.HasPrefix(strings.TrimLeft(f, "-"), "modfile=") {
+				flags = append(flags, f)
+			}
+		}
+
+		env[i] = fmt.Sprintf("GOFLAGS=%s", strings.Join(flags, " "))
+	}
+
+	return env
+}
+
+// packageExamples lists all of the examples documented for the package,
+// including those of the symbols within it.
+func packageExamples(pkg *lang.Package) []*lang.Example {
+	examples := pkg.Examples()
+	for _, fn := range pkg.Funcs() {
+		examples = append(examples, fn.Examples()...)
+	}
+
+	for _, typ := range pkg.Types() {
+		examples = append(examples, typ.Examples()...)
+		for _, fn := range typ.Funcs() {
+			examples = append(examples, fn.Examples()...)
+		}
+
+		for _, fn := range typ.Methods() {
+			examples = append(examples, fn.Examples()...)
+		}
+	}
+
+	return examples
+}
+
+// exampleLocation provides the file:line location of the example, relative to
+// the working directory where possible.
+func exampleLocation(ex *lang.Example) string {
+	return locationText(ex.Location())
+}
+
+// locationText describes the location as a path relative to the working
+// directory along with the line number.
+func locationText(loc lang.Location) string {
+	path := loc.Filepath
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil {
+			path = rel
+		}
+	}
+
+	return fmt.Sprintf("%s:%d", path, loc.Start.Line)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"strings"
+
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+// htmlPage is the page shell that the documentation in each output file of the
+// html format is written into.
+var htmlPage = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Title }}</title>
+<style>
+{{ .Stylesheet }}
+</style>
+</head>
+<body>
+<main>
+{{ .Content }}
+</main>
+</body>
+</html>
+`))
+
+// defaultStylesheet is the stylesheet embedded in each page of the html
+// format. Its colors are defined as custom properties so that themes only need
+// to override them.
+const defaultStylesheet = `:root {
+  --gomarkdoc-text: #1f2328;
+  --gomarkdoc-muted: #59636e;
+  --gomarkdoc-background: #ffffff;
+  --gomarkdoc-border: #d1d9e0;
+  --gomarkdoc-link: #0969da;
+  --gomarkdoc-code-background: #f6f8fa;
+  --gomarkdoc-keyword: #cf222e;
+  --gomarkdoc-string: #0a3069;
+  --gomarkdoc-number: #0550ae;
+  --gomarkdoc-comment: #59636e;
+  --gomarkdoc-builtin: #8250df;
+  --gomarkdoc-font: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif;
+  --gomarkdoc-code-font: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace;
+}
+
+@media (prefers-color-scheme: dark) {
+  :root {
+    --gomarkdoc-text: #f0f6fc;
+    --gomarkdoc-muted: #9198a1;
+    --gomarkdoc-background: #0d1117;
+    --gomarkdoc-border: #3d444d;
+    --gomarkdoc-link: #4493f8;
+    --gomarkdoc-code-background: #151b23;
+    --gomarkdoc-keyword: #ff7b72;
+    --gomarkdoc-string: #a5d6ff;
+    --gomarkdoc-number: #79c0ff;
+    --gomarkdoc-comment: #9198a1;
+    --gomarkdoc-builtin: #d2a8ff;
+  }
+}
+
+body {
+  margin: 0;
+  color: var(--gomarkdoc-text);
+  background: var(--gomarkdoc-background);
+  font-family: var(--gomarkdoc-font);
+  line-height: 1.5;
+}
+
+main {
+  max-width: 960px;
+  margin: 0 auto;
+  padding: 2rem 1rem;
+}
+
+a {
+  color: var(--gomarkdoc-link);
+  text-decoration: none;
+}
+
+a:hover {
+  text-decoration: underline;
+}
+
+h1, h2 {
+  padding-bottom: 0.3em;
+  border-bottom: 1px solid var(--gomarkdoc-border);
+}
+
+code, pre {
+  font-family: var(--gomarkdoc-code-font);
+  font-size: 0.875em;
+}
+
+code {
+  padding: 0.1em 0.3em;
+  border-radius: 4px;
+  background: var(--gomarkdoc-code-background);
+}
+
+pre {
+  padding: 1em;
+  overflow: auto;
+  border-radius: 6px;
+  background: var(--gomarkdoc-code-background);
+}
+
+pre code {
+  padding: 0;
+  font-size: 1em;
+  background: none;
+}
+
+pre .kw { color: var(--gomarkdoc-keyword); }
+pre .str { color: var(--gomarkdoc-string); }
+pre .num { color: var(--gomarkdoc-number); }
+pre .com { color: var(--gomarkdoc-comment); font-style: italic; }
+pre .builtin { color: var(--gomarkdoc-builtin); }
+
+.badge {
+  padding: 0.1em 0.5em;
+  border: 1px solid var(--gomarkdoc-border);
+  border-radius: 1em;
+  color: var(--gomarkdoc-muted);
+  font-size: 0.75em;
+}
+
+details {
+  margin: 1em 0;
+  padding: 0.5em 1em;
+  border: 1px solid var(--gomarkdoc-border);
+  border-radius: 6px;
+}
+
+summary {
+  cursor: pointer;
+}
+
+table {
+  border-collapse: collapse;
+}
+
+th, td {
+  padding: 0.4em 0.8em;
+  border: 1px solid var(--gomarkdoc-border);
+  text-align: left;
+  vertical-align: top;
+}
+
+dd {
+  margin-bottom: 1em;
+}
+
+footer {
+  margin-top: 3em;
+  color: var(--gomarkdoc-muted);
+  font-size: 0.875em;
+}`
+
+// resolveStylesheet provides the stylesheet for the pages of the html format,
+// which is the default stylesheet followed by the contents of the configured
+// stylesheet file, if any.
+func resolveStylesheet(opts commandOptions) (string, error) {
+	if opts.stylesheetFile == "" {
+		return defaultStylesheet, nil
+	}
+
+	b, err := ioutil.ReadFile(opts.stylesheetFile)
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: couldn't resolve stylesheet file: %w", err)
+	}
+
+	return fmt.Sprintf("%s\n\n%s", defaultStylesheet, strings.TrimSpace(string(b))), nil
+}
+
+// wrapPage writes the html documentation for the provided packages into the
+// page shell, titled with the names of the packages.
+func wrapPage(pkgs []*lang.Package, content, stylesheet string) (string, error) {
+	var b strings.Builder
+	err := htmlPage.Execute(&b, struct {
+		Title      string
+		Stylesheet template.CSS
+		Content    template.HTML
+	}{
+		Title:      packageTitle(pkgs),
+		Stylesheet: template.CSS(stylesheet),
+		Content:    template.HTML(strings.TrimSpace(content)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to write html page: %w", err)
+	}
+
+	return b.String(), nil
+}
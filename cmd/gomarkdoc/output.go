@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"text/template"
 	"time"
 
 	"github.com/princjef/gomarkdoc"
@@ -17,56 +19,150 @@ import (
 	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
-func writeOutput(specs []*PackageSpec, opts commandOptions) error {
-	log := logger.New(getLogLevel(opts.verbosity))
+// outputFile holds the rendered contents of a file to write or check.
+type outputFile struct {
+	name string
+	text string
+}
 
+// renderOutput renders the documentation for the packages of the provided
+// specs, along with the site navigation if there is one, without writing
+// anything.
+func renderOutput(specs []*PackageSpec, opts commandOptions) ([]outputFile, error) {
 	overrides, err := resolveOverrides(opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	out, err := gomarkdoc.NewRenderer(overrides...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	header, err := resolveHeader(opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	footer, err := resolveFooter(opts)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Embedded documentation is written into an existing page, so it only
+	// needs a page of its own otherwise.
+	var stylesheet string
+	page := opts.format == "html" && !opts.embed
+	if page {
+		if stylesheet, err = resolveStylesheet(opts); err != nil {
+			return nil, err
+		}
+	}
+
+	var frontMatter *template.Template
+	if opts.site != "" {
+		if frontMatter, err = resolveFrontMatter(opts); err != nil {
+			return nil, err
+		}
 	}
 
 	filePkgs := make(map[string][]*lang.Package)
+	var fileNames []string
 
 	for _, spec := range specs {
 		if spec.pkg == nil {
 			continue
 		}
 
+		if _, ok := filePkgs[spec.outputFile]; !ok {
+			fileNames = append(fileNames, spec.outputFile)
+		}
+
 		filePkgs[spec.outputFile] = append(filePkgs[spec.outputFile], spec.pkg)
 	}
 
-	var checkErr error
-	for fileName, pkgs := range filePkgs {
+	// Files are positioned in the site in the order of their names, which
+	// keeps the files for packages next to each other in the package tree.
+	sort.Strings(fileNames)
+
+	var (
+		files     []outputFile
+		siteFiles []siteFile
+	)
+	for i, fileName := range fileNames {
+		pkgs := filePkgs[fileName]
 		file := lang.NewFile(header, footer, pkgs)
 
 		text, err := out.File(file)
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		if page {
+			if text, err = wrapPage(pkgs, text, stylesheet); err != nil {
+				return nil, err
+			}
+		}
+
+		if frontMatter != nil {
+			if text, err = addFrontMatter(frontMatter, pkgs, i+1, text); err != nil {
+				return nil, err
+			}
+
+			siteFiles = append(siteFiles, siteFile{title: packageTitle(pkgs), path: fileName})
+		}
+
+		files = append(files, outputFile{fileName, text})
+	}
+
+	if opts.siteNav != "" {
+		nav, err := siteNav(opts, siteFiles)
+		if err != nil {
+			return nil, err
 		}
 
-		checkErr, err = handleFile(log, fileName, text, opts)
+		files = append(files, outputFile{opts.siteNav, nav})
+	}
+
+	return files, nil
+}
+
+// writeOutput writes the rendered files, or checks them against the existing
+// files if the check option is set.
+func writeOutput(files []outputFile, opts commandOptions) error {
+	log := logger.New(getLogLevel(opts.verbosity))
+
+	var checkErr error
+	for _, f := range files {
+		fileCheckErr, err := handleFile(log, f.name, f.text, opts)
 		if err != nil {
 			return err
 		}
+
+		if fileCheckErr != nil {
+			checkErr = fileCheckErr
+		}
 	}
 
-	if checkErr != nil {
-		return checkErr
+	return checkErr
+}
+
+// reportUnresolvedLinks prints the doc links that couldn't be resolved while
+// rendering the documentation to stderr. In strict mode, any unresolved links
+// cause an error.
+func reportUnresolvedLinks(links *lang.LinkReport, opts commandOptions) error {
+	unresolved := links.Links()
+	if len(unresolved) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Found %d unresolved doc links:\n", len(unresolved))
+	for _, l := range unresolved {
+		fmt.Fprintf(os.Stderr, "  %s: [%s]\n", locationText(l.Location), l.Text)
+	}
+
+	if opts.strict {
+		return fmt.Errorf("gomarkdoc: documentation contains %d unresolved doc links", len(unresolved))
 	}
 
 	return nil
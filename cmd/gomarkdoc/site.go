@@ -0,0 +1,328 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+// Static site generators that documentation can be generated for.
+const (
+	siteHugo       = "hugo"
+	siteDocusaurus = "docusaurus"
+	siteMkDocs     = "mkdocs"
+)
+
+// Default front matter templates for each static site generator. Values are
+// quoted with Go's quoting rules, which produces valid YAML strings.
+const (
+	hugoFrontMatter = `title: {{ printf "%q" .Title }}
+{{- if .Summary }}
+description: {{ printf "%q" .Summary }}
+{{- end }}
+weight: {{ .SidebarPosition }}
+import_path: {{ printf "%q" .ImportPath }}`
+
+	docusaurusFrontMatter = `title: {{ printf "%q" .Title }}
+{{- if .Summary }}
+description: {{ printf "%q" .Summary }}
+{{- end }}
+sidebar_position: {{ .SidebarPosition }}
+import_path: {{ printf "%q" .ImportPath }}`
+
+	mkdocsFrontMatter = `title: {{ printf "%q" .Title }}
+{{- if .Summary }}
+description: {{ printf "%q" .Summary }}
+{{- end }}
+import_path: {{ printf "%q" .ImportPath }}`
+)
+
+// docusaurusSidebar is the name of the sidebar in the generated sidebars file
+// for Docusaurus.
+const docusaurusSidebar = "api"
+
+type (
+	// frontMatterData is the data available to front matter templates.
+	frontMatterData struct {
+		// Title is the name of the package documented in the file, or the names
+		// of all of them if there are several.
+		Title string
+
+		// Summary is the first sentence of the documentation for the package.
+		Summary string
+
+		// ImportPath is the import path of the package.
+		ImportPath string
+
+		// SidebarPosition is the position of the file among all of the files
+		// generated, starting from 1.
+		SidebarPosition int
+
+		// Packages holds all of the packages documented in the file.
+		Packages []*lang.Package
+	}
+
+	// siteFile is a file generated for the site, which is listed in its
+	// navigation.
+	siteFile struct {
+		title string
+		path  string
+	}
+
+	// navDir is a directory of the package tree in the navigation of the site.
+	navDir struct {
+		name  string
+		files []siteFile
+		dirs  []*navDir
+	}
+
+	docusaurusItem struct {
+		Type  string           `json:"type"`
+		ID    string           `json:"id,omitempty"`
+		Label string           `json:"label"`
+		Link  *docusaurusLink  `json:"link,omitempty"`
+		Items []docusaurusItem `json:"items,omitempty"`
+	}
+
+	docusaurusLink struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+)
+
+// validateSite checks that the options for generating documentation for a
+// static site are consistent with each other.
+func validateSite(opts commandOptions) error {
+	if opts.site == "" {
+		if opts.siteNav != "" || opts.frontMatterFile != "" {
+			return errors.New("gomarkdoc: site options cannot be used without a site")
+		}
+
+		return nil
+	}
+
+	switch opts.site {
+	case siteHugo, siteDocusaurus, siteMkDocs:
+	default:
+		return fmt.Errorf("gomarkdoc: invalid site: %s", opts.site)
+	}
+
+	if opts.format == "html" {
+		return errors.New("gomarkdoc: site documentation must use a markdown format")
+	}
+
+	if opts.embed {
+		return errors.New("gomarkdoc: site documentation cannot be embedded into existing files")
+	}
+
+	if opts.siteNav != "" && opts.site == siteHugo {
+		return errors.New("gomarkdoc: hugo sites don't use a navigation file")
+	}
+
+	return nil
+}
+
+// resolveFrontMatter provides the template for the front matter of each file
+// of the site, which is read from the front matter file if one is configured.
+func resolveFrontMatter(opts commandOptions) (*template.Template, error) {
+	var tmplStr string
+	switch {
+	case opts.frontMatterFile != "":
+		b, err := ioutil.ReadFile(opts.frontMatterFile)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: couldn't resolve front matter file: %w", err)
+		}
+
+		tmplStr = string(b)
+	case opts.site == siteHugo:
+		tmplStr = hugoFrontMatter
+	case opts.site == siteDocusaurus:
+		tmplStr = docusaurusFrontMatter
+	default:
+		tmplStr = mkdocsFrontMatter
+	}
+
+	tmpl, err := template.New("frontMatter").Parse(tmplStr)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: invalid front matter template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// addFrontMatter prefixes the documentation for the provided packages with its
+// YAML front matter.
+func addFrontMatter(tmpl *template.Template, pkgs []*lang.Package, position int, text string) (string, error) {
+	data := frontMatterData{
+		Title:           packageTitle(pkgs),
+		Summary:         pkgs[0].Summary(),
+		ImportPath:      pkgs[0].ImportPath(),
+		SidebarPosition: position,
+		Packages:        pkgs,
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to write front matter: %w", err)
+	}
+
+	return fmt.Sprintf("---\n%s\n---\n\n%s", strings.TrimSpace(b.String()), text), nil
+}
+
+// packageTitle provides the title of the documentation for the provided
+// packages, which is made up of their names.
+func packageTitle(pkgs []*lang.Package) string {
+	names := make([]string, len(pkgs))
+	for i, pkg := range pkgs {
+		if pkg.Name() == "main" {
+			names[i] = pkg.Dirname()
+		} else {
+			names[i] = pkg.Name()
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// siteNav generates the navigation of the site for the provided files, which is
+// a sidebars file for Docusaurus and the nav configuration for MkDocs. The
+// files are arranged into the tree of the directories containing them, which
+// follows the tree of the packages they document.
+func siteNav(opts commandOptions, files []siteFile) (string, error) {
+	root := &navDir{}
+	for _, f := range files {
+		if f.path == "" {
+			return "", errors.New("gomarkdoc: site navigation requires documentation to be written to files")
+		}
+
+		rel, err := filepath.Rel(opts.siteDocsDir, f.path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("gomarkdoc: output file %s is outside of the site docs directory", f.path)
+		}
+
+		rel = filepath.ToSlash(rel)
+		root.add(strings.Split(path.Dir(rel), "/"), siteFile{title: f.title, path: rel})
+	}
+
+	root.sort()
+
+	if opts.site == siteDocusaurus {
+		b, err := json.MarshalIndent(map[string][]docusaurusItem{docusaurusSidebar: root.docusaurusItems()}, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("gomarkdoc: failed to write sidebars: %w", err)
+		}
+
+		return fmt.Sprintf("// Code generated by gomarkdoc. DO NOT EDIT\n\nmodule.exports = %s;\n", b), nil
+	}
+
+	var b strings.Builder
+	b.WriteString("# Code generated by gomarkdoc. DO NOT EDIT\n\nnav:\n")
+	root.writeMkDocsNav(&b, 1)
+	return b.String(), nil
+}
+
+// add adds the file to the directory at the provided path below this one.
+func (d *navDir) add(dirPath []string, f siteFile) {
+	if len(dirPath) == 0 || dirPath[0] == "." {
+		d.files = append(d.files, f)
+		return
+	}
+
+	for _, child := range d.dirs {
+		if child.name == dirPath[0] {
+			child.add(dirPath[1:], f)
+			return
+		}
+	}
+
+	child := &navDir{name: dirPath[0]}
+	d.dirs = append(d.dirs, child)
+	child.add(dirPath[1:], f)
+}
+
+// sort sorts the files and directories of the tree by their paths.
+func (d *navDir) sort() {
+	sort.Slice(d.files, func(i, j int) bool { return d.files[i].path < d.files[j].path })
+	sort.Slice(d.dirs, func(i, j int) bool { return d.dirs[i].name < d.dirs[j].name })
+
+	for _, child := range d.dirs {
+		child.sort()
+	}
+}
+
+// docusaurusItems provides the sidebar items for the contents of the
+// directory.
+func (d *navDir) docusaurusItems() []docusaurusItem {
+	var items []docusaurusItem
+	for _, f := range d.files {
+		items = append(items, docusaurusItem{Type: "doc", ID: docusaurusID(f.path), Label: f.title})
+	}
+
+	for _, child := range d.dirs {
+		items = append(items, child.docusaurusItem())
+	}
+
+	return items
+}
+
+// docusaurusItem provides the sidebar item for the directory. The first file
+// in the directory documents the package of the directory, so it's linked from
+// the directory's category instead of being an item of it.
+func (d *navDir) docusaurusItem() docusaurusItem {
+	if len(d.files) == 0 {
+		return docusaurusItem{Type: "category", Label: d.name, Items: d.docusaurusItems()}
+	}
+
+	first := d.files[0]
+	if len(d.files) == 1 && len(d.dirs) == 0 {
+		return docusaurusItem{Type: "doc", ID: docusaurusID(first.path), Label: first.title}
+	}
+
+	rest := &navDir{files: d.files[1:], dirs: d.dirs}
+	return docusaurusItem{
+		Type:  "category",
+		Label: first.title,
+		Link:  &docusaurusLink{Type: "doc", ID: docusaurusID(first.path)},
+		Items: rest.docusaurusItems(),
+	}
+}
+
+// docusaurusID provides the id Docusaurus gives to the doc at the provided path
+// within the docs directory.
+func docusaurusID(p string) string {
+	return strings.TrimSuffix(p, path.Ext(p))
+}
+
+// writeMkDocsNav writes the nav entries for the contents of the directory at
+// the provided depth. MkDocs doesn't have pages for sections, so the page
+// documenting the package of a directory is the first entry of its section.
+func (d *navDir) writeMkDocsNav(b *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, f := range d.files {
+		fmt.Fprintf(b, "%s- %s: %s\n", indent, strconv.Quote(f.title), strconv.Quote(f.path))
+	}
+
+	for _, child := range d.dirs {
+		if len(child.files) == 1 && len(child.dirs) == 0 {
+			child.writeMkDocsNav(b, depth)
+			continue
+		}
+
+		title := child.name
+		if len(child.files) > 0 {
+			title = child.files[0].title
+		}
+
+		fmt.Fprintf(b, "%s- %s:\n", indent, strconv.Quote(title))
+		child.writeMkDocsNav(b, depth+2)
+	}
+}
@@ -23,29 +23,59 @@
 //
 //	Usage:
 //	  gomarkdoc [flags] [package ...]
+//	  gomarkdoc [command]
+//
+//	Available Commands:
+//	  changelog   report the changes to the exported API of packages between two git revisions
+//	  coverage    report how thoroughly the exported symbols of packages are documented
+//	  help        Help about any command
 //
 //	Flags:
+//	      --build-matrix stringArray           Build context to document packages under, specified as GOOS/GOARCH[,tag...]. Can be repeated to merge the documentation from several contexts.
 //	  -c, --check                              Check the output to see if it matches the generated documentation. --output must be specified to use this.
+//	      --collapse-deprecated                Collapse the documentation for deprecated symbols into an accordion.
 //	      --config string                      File from which to load configuration (default: .gomarkdoc.yml)
+//	      --doc-links stringArray              URL template to use for links to packages with an import path prefix, specified as prefix=template.
 //	  -e, --embed                              Embed documentation into existing markdown files if available, otherwise append to file.
 //	      --exclude-dirs strings               List of package directories to ignore when producing documentation.
+//	      --exclude-kinds strings              Kinds of symbols to leave out of the documentation. Valid options: type, func, const, var, method, field
+//	      --exclude-symbols stringArray        Glob or /regex/ matching the names of symbols to leave out of the documentation. Methods and fields are matched as Type.Name.
+//	      --field-style string                 Style to use for rendering the fields of struct types. Valid options: list (default), table (default "list")
 //	      --footer string                      Additional content to inject at the end of each output file.
 //	      --footer-file string                 File containing additional content to inject at the end of each output file.
-//	  -f, --format string                      Format to use for writing output data. Valid options: github (default), azure-devops, plain (default "github")
+//	  -f, --format string                      Format to use for writing output data. Valid options: github (default), azure-devops, gitlab, plain, html (default "github")
+//	      --front-matter-file string           File containing the template for the front matter of each output file of the site.
 //	      --header string                      Additional content to inject at the beginning of each output file.
 //	      --header-file string                 File containing additional content to inject at the beginning of each output file.
 //	  -h, --help                               help for gomarkdoc
+//	      --hide-deprecated                    Leave deprecated symbols out of the documentation.
+//	      --include-kinds strings              Kinds of top-level symbols to include in the documentation. Valid options: type, func, const, var
+//	      --include-symbols stringArray        Glob or /regex/ matching the names of symbols to include in the documentation. Methods and fields are matched as Type.Name.
 //	  -u, --include-unexported                 Output documentation for unexported symbols, methods and fields in addition to exported ones.
+//	      --mod string                         Module download mode to use when loading packages. Valid options: readonly, vendor, mod
 //	  -o, --output string                      File or pattern specifying where to write documentation output. Defaults to printing to stdout.
 //	      --repository.default-branch string   Manual override for the git repository URL used in place of automatic detection.
+//	      --repository.host string             Manual override for the host of the git repository used in place of automatic detection. Needed for self-managed servers whose hostname doesn't start with the name of the host (e.g. gitlab.). Valid options: github, gitlab, azure-devops, bitbucket-cloud, bitbucket-server, gitea, forgejo
 //	      --repository.path string             Manual override for the path from the root of the git repository used in place of automatic detection.
 //	      --repository.url string              Manual override for the git repository URL used in place of automatic detection.
+//	      --since                              Note the release in which each symbol was added, based on the semantic version tags of the repository.
+//	      --site string                        Static site generator to generate documentation for. Valid options: hugo, docusaurus, mkdocs
+//	      --site-docs-dir string               Directory containing the documents of the site, which the paths in the site navigation are relative to. (default "docs")
+//	      --site-nav string                    File to write the navigation of the site to. Only used by the docusaurus and mkdocs sites.
+//	      --strict                             Fail if any doc links can't be resolved to the symbols they refer to.
+//	      --stylesheet-file string             CSS file to add to the page of each output file after the default stylesheet. Only used by the html format.
+//	      --symbol-order string                Order in which to document symbols. Valid options: alphabetical (default), source, file, category (default "alphabetical")
+//	      --table-of-contents                  Add a table of contents to each output file. Only used by the gitlab format and ignored with --embed.
 //	      --tags strings                       Set of build tags to apply when choosing which files to include for documentation generation.
 //	  -t, --template stringToString            Custom template string to use for the provided template name instead of the default template. (default [])
 //	      --template-file stringToString       Custom template file to use for the provided template name instead of the default template. (default [])
+//	      --type-check                         Type check packages to link identifiers in declarations to their documentation.
 //	  -v, --verbose count                      Log additional output from the execution of the command. Can be chained for additional verbosity.
+//	      --verify-examples                    Run the documented examples and check that their output matches the documented output.
 //	      --version                            Print the version.
 //
+//	Use "gomarkdoc [command] --help" for more information about a command.
+//
 // The gomarkdoc command processes each of the provided packages, generating
 // documentation for the package in markdown format and writing it to console.
 // For example, if you have a package in your current directory and want to
@@ -100,6 +130,35 @@
 // PackageSpec struct in the github.com/princjef/gomarkdoc/cmd/gomarkdoc
 // package.
 //
+// When documentation for multiple packages is generated in the same run, links
+// from one of these packages to another (such as [otherpkg.Symbol] in a
+// documentation comment) point to the documentation that gomarkdoc writes for
+// the other package, using a path relative to the file containing the link.
+// Links to packages outside of the run continue to point to pkg.go.dev.
+//
+// If the output template writes several packages to the same file (or they are
+// all written to stdout), the anchors for their symbols are prefixed with the
+// package name to keep them from colliding. For example, the anchor for a New
+// function in a package named client becomes client.New. Packages that share
+// their name with another package in the file are prefixed with the end of
+// their import path instead, such as v2-client.New for example.com/v2/client:
+//
+//	gomarkdoc --output API.md ./...
+//
+// If some of the packages you link to are documented somewhere other than
+// pkg.go.dev, such as an internal documentation server for private modules, you
+// can map prefixes of their import paths to URL templates with the --doc-links
+// option. Templates have access to the ImportPath of the linked package and the
+// linked Symbol, which is empty for links to the package itself. When more than
+// one prefix matches, the longest one is used:
+//
+//	gomarkdoc --doc-links 'corp.example.com/=https://docs.corp/{{.ImportPath}}{{if .Symbol}}#{{.Symbol}}{{end}}' ./...
+//
+// The same mapping can be provided in the configuration file:
+//
+//	docLinks:
+//	  - corp.example.com/=https://docs.corp/{{.ImportPath}}{{if .Symbol}}#{{.Symbol}}{{end}}
+//
 // # Template Overrides
 //
 // The documentation information that is output is formatted using a series of
@@ -121,6 +180,25 @@
 //     be referenced from within a type, or directly in the package,
 //     depending on nesting.
 //
+//   - fields:  generates documentation for the fields of a struct type, either
+//     as a definition list or as a table depending on the --field-style
+//     option. Each field gets its own anchor, so links to fields land on
+//     the field itself.
+//
+//   - typeparams: generates documentation for the type parameters of a generic
+//     func or type, linking each constraint to its definition where
+//     possible.
+//
+//   - inherited: generates a collapsible section listing the methods and
+//     fields that a type inherits from one of the types embedded within it.
+//     Types embedded from other packages are only resolved when the
+//     --type-check flag is provided.
+//
+//   - group:   generates documentation for a group of symbols within a
+//     package, such as the constants of the package or the symbols
+//     declared in one of its files, depending on the --symbol-order
+//     option.
+//
 //   - value:   generates documentation for a single variable or constant
 //     declaration block within a package.
 //
@@ -146,6 +224,57 @@
 //
 //	gomarkdoc --template-file package=custom-package.gotxt --template-file doc=custom-doc.gotxt .
 //
+// The html format writes each output file as a standalone HTML page instead of
+// markdown, for hosting documentation on sites that don't render markdown.
+// Headers get ids that can be linked to and go code is syntax highlighted.
+// Since the default templates produce markdown, this format uses its own set of
+// templates with the same names, which can be overridden in the same way. The
+// page comes with a default stylesheet that adapts to light and dark color
+// schemes. Its colors are CSS custom properties (e.g. --gomarkdoc-link), so a
+// theme provided with the --stylesheet-file option only needs to override
+// them. In embed mode, only the documentation itself is written into the page
+// that embeds it:
+//
+//	gomarkdoc -f html --stylesheet-file theme.css -o '{{.Dir}}/index.html' ./...
+//
+// The gitlab format targets repositories hosted on GitLab, including
+// self-managed instances. Links to headers use GitLab's rules for header ids,
+// links to the source code use GitLab's URL scheme for blobs and line ranges,
+// and collapsible sections leave blank lines around their contents so GitLab
+// renders the markdown inside them. The --table-of-contents flag adds GitLab's
+// [[_TOC_]] tag to the top of each file, which GitLab renders as a table of
+// contents for the whole file. It's left out when embedding, since the
+// documentation is then only part of the file:
+//
+//	gomarkdoc -f gitlab --table-of-contents -o README.md ./...
+//
+// # Static Sites
+//
+// The --site option prepares the documentation to be published with a static
+// site generator. Valid options are hugo, docusaurus and mkdocs. Each output
+// file starts with YAML front matter holding the title of the package, its
+// summary (the first sentence of its documentation) and its import path. For
+// hugo and docusaurus, the front matter also sets the position of the file
+// among all of the generated files, so the pages are listed in the order of
+// the package tree. The front matter can be customized with a template file
+// provided with the --front-matter-file option, which has access to the
+// Title, Summary, ImportPath, SidebarPosition and Packages fields:
+//
+//	title: {{ .Title }}
+//	description: {{ printf "%q" .Summary }}
+//	weight: {{ .SidebarPosition }}
+//
+// Docusaurus compiles markdown as MDX, so documentation for a docusaurus site
+// uses anchors, accordions and comments that are valid MDX, and its code blocks
+// don't contain links. With the --site-nav option, gomarkdoc also writes the
+// navigation for the generated files, following the tree of the packages. For
+// docusaurus this is a sidebars file with an "api" sidebar, and for mkdocs it
+// is a configuration file with the nav, which can be added to mkdocs.yml with
+// its INHERIT key. Paths in the navigation are relative to the docs directory
+// of the site, which is set with the --site-docs-dir option:
+//
+//	gomarkdoc --site docusaurus --site-nav sidebars.js -o 'docs/api/{{.Dir}}/index.md' ./...
+//
 // # Additional Options
 //
 // As with the godoc tool itself, only exported symbols will be shown in
@@ -184,6 +313,114 @@
 //
 //	gomarkdoc --tags sometag .
 //
+// Packages are loaded through the go command, so the same module resolution
+// rules apply as when building your code: go.mod and go.work files are
+// honored, and vendor directories are used when present. Remote import paths
+// are resolved through your module's dependencies. The module download mode
+// can be changed with the --mod flag, which is also picked up from GOFLAGS:
+//
+//	gomarkdoc --mod vendor ./...
+//
+// If your packages have files for specific platforms (e.g. foo_linux.go) or
+// files behind custom build tags, you can document them under several build
+// contexts at once with the --build-matrix flag. Each context is specified as
+// GOOS/GOARCH, optionally followed by a comma-separated list of build tags. The
+// documentation includes the symbols available under any of the contexts, and
+// symbols that are only available under some of them are labeled with a badge
+// such as "linux, darwin only" or "requires tag: enterprise". Packages are
+// loaded under each of the contexts, so a package only needs to build under
+// one of them:
+//
+//	gomarkdoc --build-matrix linux/amd64 --build-matrix windows/amd64 --build-matrix linux/amd64,enterprise ./...
+//
+// By default, the declarations and signatures of symbols are rendered as plain
+// code blocks. If you provide the --type-check flag, gomarkdoc type checks the
+// packages it documents and links each identifier in a declaration to the
+// documentation for the symbol it refers to. Symbols in the same package link
+// to their section of the generated documentation, while everything else links
+// to pkg.go.dev. Formats that support it render these declarations as HTML
+// preformatted blocks, since markdown code blocks cannot contain links. Type
+// checking requires loading the package's dependencies, so it is slower than
+// the default mode:
+//
+//	gomarkdoc --type-check ./...
+//
+// Type checking also lets gomarkdoc cross-reference interfaces with the types
+// that implement them. Each type lists the interfaces it implements, and each
+// interface lists the types implementing it, under "Implements" and
+// "Implemented by" respectively. Only interfaces and types from the packages
+// documented in the same run are considered, so running gomarkdoc on ./...
+// finds implementations across all of the packages in your module.
+//
+// The fields of struct types are documented individually after the type's
+// declaration, along with their types, struct tags and documentation. They are
+// rendered as a definition list by default. If you prefer a more compact
+// layout, the --field-style option renders them as a table instead:
+//
+//	gomarkdoc --field-style table ./...
+//
+// Generic funcs and types list their type parameters along with the constraint
+// of each one, linked to the constraint's definition when it is a named type.
+// Constraint interfaces such as one embedding ~int | ~string list the terms of
+// their type set as well.
+//
+// Symbols whose documentation contains a paragraph starting with
+// "Deprecated: " are treated as deprecated, following the Go convention.
+// Deprecated symbols are marked as such in their headers and in the index. If
+// you would rather keep them out of the way, the --collapse-deprecated flag
+// collapses their documentation into an accordion like pkg.go.dev does, while
+// the --hide-deprecated flag leaves them out of the documentation entirely:
+//
+//	gomarkdoc --collapse-deprecated ./...
+//
+// To choose which symbols are documented, the --include-symbols and
+// --exclude-symbols flags accept glob patterns, or regular expressions
+// surrounded by slashes, that are matched against the names of symbols.
+// Methods and fields are matched along with the name of their type (e.g.
+// Client.Do). The --include-kinds and --exclude-kinds flags filter symbols by
+// kind instead, which can be any of type, func, const, var, method and field.
+// Included kinds only select top-level symbols, so the methods, fields and
+// constructors of an included type are kept unless their kind is excluded.
+// Links to symbols that are left out of the documentation are rendered as plain
+// text:
+//
+//	gomarkdoc --exclude-symbols 'Internal*' --exclude-kinds var ./...
+//
+// Package authors can also curate the documentation from the source code using
+// gomarkdoc directives in doc comments. Like other Go directives, they are
+// written without a space after the slashes and don't show up in the rendered
+// documentation. //gomarkdoc:hide leaves a symbol out of the documentation, and
+// //gomarkdoc:example-only documents a func or type through its examples alone.
+// //gomarkdoc:group Name and //gomarkdoc:order 10 assign the symbol to a named
+// group and give it a position within its group, which the --symbol-order
+// option uses to arrange the documentation:
+//
+//	// Reset resets the parser. It is only used internally.
+//	//
+//	//gomarkdoc:hide
+//	func (p *Parser) Reset() {}
+//
+// Symbols are documented in the order used by pkg.go.dev by default:
+// constants, variables, funcs and types, with funcs and types sorted by name.
+// The --symbol-order option can document them in the order they are declared
+// (source), grouped by the file declaring them (file), or grouped by the
+// categories assigned to them with //gomarkdoc:group directives (category).
+// For all but the default order, the symbols of each group are sorted by the
+// number in their //gomarkdoc:order directive, or 0 if they have none, and then
+// by declaration order. This lets large packages read like a guided tour:
+//
+//	gomarkdoc --symbol-order category ./...
+//
+// To let readers know which release introduced an API, the --since flag notes
+// the release in which each func and type was added, like the "Added in"
+// notes of the standard library. Releases are found from the tags of the git
+// repository containing the working directory that are semantic versions
+// without a prerelease suffix (e.g. v1.2.0, or tools/v1.2.0 for a module in
+// the tools directory). Symbols that were part of the first release aren't
+// annotated:
+//
+//	gomarkdoc --since ./...
+//
 // You can also run gomarkdoc in a verification mode with the --check/-c flag.
 // This is particularly useful for continuous integration when you want to make
 // sure that a commit correctly updated the generated documentation. This flag
@@ -192,6 +429,67 @@
 //
 //	gomarkdoc -o README.md -c .
 //
+// The --check flag only compares the generated documentation, so the output
+// shown for examples can still go stale if their tests aren't run. The
+// --verify-examples flag runs the examples gomarkdoc documents with go test,
+// which compares what they print with their "Output:" comments, including
+// unordered output. Examples declared in both the package itself and its
+// external test package (e.g. package foo_test) are run. They're run with the
+// local toolchain in a temporary module holding a copy of the package and the
+// test files declaring the examples, so other test files aren't built and the
+// go.mod and go.sum files of your module are left alone. Any mismatches are
+// reported with the file and line of the example, and gomarkdoc exits with an
+// error. Examples in test files that aren't built with the tags provided by the
+// --tags option are reported as unverified:
+//
+//	gomarkdoc --verify-examples -o README.md -c .
+//
+// Doc links (e.g. [Client.Do]) to symbols that exist but are left out of the
+// documentation, such as by filters, --hide-deprecated or the gomarkdoc:hide
+// directive, are written as plain text. Links that can't be resolved because
+// the symbol doesn't exist at all, such as a typo in a link to another package
+// documented in the same run, are written as plain text too, but gomarkdoc
+// also lists all of them together with the file and line of the doc comment
+// that contains them before writing or checking any files. The --strict flag
+// makes gomarkdoc exit with an error without writing anything if there are
+// any, so typos in doc links can be caught in continuous integration as well:
+//
+//	gomarkdoc --strict -o README.md -c .
+//
+// The changelog command reports the changes to the exported API of your
+// packages between two git revisions, such as your last release and the
+// current commit. The packages are read straight from the git objects of each
+// revision, so nothing is checked out. The report lists the symbols that were
+// added and removed from each package along with a diff of the declarations
+// that changed. Changes to documentation alone are not reported. It supports
+// the same --format/-f and --output/-o options as the main command, but only
+// for local directories:
+//
+//	gomarkdoc changelog --from v1.2.0 --to HEAD -o CHANGES.md ./...
+//
+// With the --check/-c flag, the changelog command instead checks that the
+// changes are compatible with existing users of your packages. Both revisions
+// are type checked, and changes such as removed symbols, changed parameter
+// types, removed struct fields and methods added to interfaces are reported
+// as breaking. The command fails if there are breaking changes to a package
+// unless the major version in its module path changed as well (e.g. from
+// example.com/mod to example.com/mod/v2), so it can gate releases in
+// continuous integration:
+//
+//	gomarkdoc changelog --from v1.2.0 -c ./...
+//
+// The coverage command reports how thoroughly the exported symbols of your
+// packages are documented, including the fields of struct types and the
+// methods of interfaces. For each kind of symbol in each package, it counts
+// the symbols without a doc comment, the funcs, types and methods without any
+// examples and the doc comments that don't start with the name of the symbol.
+// The report is a markdown table by default, or JSON with the --json flag. With
+// the --threshold flag, the command fails if less than the provided percentage
+// of the symbols are documented. The threshold can also be set with the
+// coverage.threshold key of the configuration file:
+//
+//	gomarkdoc coverage --threshold 80 ./...
+//
 // If you're experiencing difficulty with gomarkdoc or just want to get more
 // information about how it's executing underneath, you can add -v to show more
 // logs. This can be chained a second time to show even more verbose logs:
@@ -208,6 +506,23 @@
 //
 //	gomarkdoc --repository.url "https://github.com/princjef/gomarkdoc" --repository.default-branch master --repository.path / -o README.md .
 //
+// Remotes are detected in both their HTTPS and SSH forms, including SSH URLs
+// with a custom port (e.g. ssh://git@gitlab.example.com:2222/group/repo.git)
+// and GitLab projects nested in subgroups.
+//
+// Links to the source code follow the URL scheme of the server hosting the
+// repository. GitHub, GitLab, Azure DevOps, Bitbucket Cloud, Bitbucket Server
+// and Gitea (including Forgejo) are supported. The host is detected from the
+// remote for well-known domains (e.g. gitlab.com, bitbucket.org and
+// codeberg.org), domains starting with the name of the host (e.g.
+// gitlab.example.com) and Bitbucket Server remotes. Otherwise, the scheme of
+// the selected format is used (GitHub's for the github format), and the
+// fallback is logged at the info level (shown with -v). Self-managed servers
+// under any other hostname (e.g. git.example.com) therefore need the
+// --repository.host option, which sets the host explicitly:
+//
+//	gomarkdoc --repository.host gitlab -o README.md ./...
+//
 // # Configuring via File
 //
 // If you want to reuse configuration options across multiple invocations, you
@@ -3,7 +3,6 @@ package format
 import (
 	"fmt"
 	"net/url"
-	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -28,6 +27,29 @@ func (f *AzureDevOpsMarkdown) CodeBlock(language, code string) (string, error) {
 	return formatcore.GFMCodeBlock(language, code), nil
 }
 
+// Code formats the provided text as inline code.
+func (f *AzureDevOpsMarkdown) Code(code string) (string, error) {
+	return formatcore.Code(code), nil
+}
+
+// Badge formats the provided text as a short label that stands out from the
+// surrounding text.
+func (f *AzureDevOpsMarkdown) Badge(text string) (string, error) {
+	return formatcore.Badge(text), nil
+}
+
+// LinkedCodeBlock wraps the code represented by the provided spans as a code
+// block. If any of the spans are links, the code block is rendered as an HTML
+// preformatted block so that the links are navigable. Otherwise, the result is
+// the same as CodeBlock.
+func (f *AzureDevOpsMarkdown) LinkedCodeBlock(language string, spans []*lang.Span) (string, error) {
+	if !hasLinks(spans) {
+		return f.CodeBlock(language, spanText(spans))
+	}
+
+	return formatcore.HTMLCodeBlock(spanHTML(spans)), nil
+}
+
 // Anchor produces an anchor for the provided link.
 func (f *AzureDevOpsMarkdown) Anchor(anchor string) string {
 	return formatcore.Anchor(anchor)
@@ -81,42 +103,11 @@ func (f *AzureDevOpsMarkdown) RawLocalHref(anchor string) string {
 	return fmt.Sprintf("#%s", anchor)
 }
 
-// CodeHref generates an href to the provided code entry.
+// CodeHref generates an href to the provided code entry. The link follows the
+// URL scheme of the repository's host, falling back to Azure DevOps's scheme if
+// the host isn't known.
 func (f *AzureDevOpsMarkdown) CodeHref(loc lang.Location) (string, error) {
-	// If there's no repo, we can't compute an href
-	if loc.Repo == nil {
-		return "", nil
-	}
-
-	var (
-		relative string
-		err      error
-	)
-	if filepath.IsAbs(loc.Filepath) {
-		relative, err = filepath.Rel(loc.WorkDir, loc.Filepath)
-		if err != nil {
-			return "", err
-		}
-	} else {
-		relative = loc.Filepath
-	}
-
-	full := filepath.Join(loc.Repo.PathFromRoot, relative)
-	p, err := filepath.Rel(string(filepath.Separator), full)
-	if err != nil {
-		return "", err
-	}
-
-	return fmt.Sprintf(
-		"%s?path=%s&version=GB%s&lineStyle=plain&line=%d&lineEnd=%d&lineStartColumn=%d&lineEndColumn=%d",
-		loc.Repo.Remote,
-		url.PathEscape(filepath.ToSlash(p)),
-		loc.Repo.DefaultBranch,
-		loc.Start.Line,
-		loc.End.Line,
-		loc.Start.Col,
-		loc.End.Col,
-	), nil
+	return loc.SourceHref(&lang.AzureDevOpsHost{})
 }
 
 // Link generates a link with the given text and href values.
@@ -131,6 +122,19 @@ func (f *AzureDevOpsMarkdown) ListEntry(depth int, text string) (string, error)
 	return formatcore.ListEntry(depth, text), nil
 }
 
+// TableHeader generates the header row of a table with the provided column
+// names. It is expected to be followed by rows generated with TableRow, each on
+// their own line.
+func (f *AzureDevOpsMarkdown) TableHeader(columns ...string) (string, error) {
+	return formatcore.TableHeader(columns...), nil
+}
+
+// TableRow generates a row of a table with the provided cells, which are
+// expected to already be formatted.
+func (f *AzureDevOpsMarkdown) TableRow(cells ...string) (string, error) {
+	return formatcore.TableRow(cells...), nil
+}
+
 // Accordion generates a collapsible content. The accordion's visible title
 // while collapsed is the provided title and the expanded content is the body.
 func (f *AzureDevOpsMarkdown) Accordion(title, body string) (string, error) {
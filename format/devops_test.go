@@ -37,6 +37,30 @@ func TestCodeBlock_noLanguage(t *testing.T) {
 	is.Equal(res, "```\nLine 1\nLine 2\n```")
 }
 
+func TestLinkedCodeBlock(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AzureDevOpsMarkdown
+	res, err := f.LinkedCodeBlock("go", []*lang.Span{
+		lang.NewSpan(nil, lang.RawTextSpan, "func New() *", ""),
+		lang.NewSpan(nil, lang.LinkSpan, "Thing", "#Thing"),
+		lang.NewSpan(nil, lang.RawTextSpan, " // a < b", ""),
+	})
+	is.NoErr(err)
+	is.Equal(res, "<pre>\nfunc New() *<a href=\"#Thing\">Thing</a> // a &lt; b\n</pre>")
+}
+
+func TestLinkedCodeBlock_noLinks(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AzureDevOpsMarkdown
+	res, err := f.LinkedCodeBlock("go", []*lang.Span{
+		lang.NewSpan(nil, lang.RawTextSpan, "func New() *Thing", ""),
+	})
+	is.NoErr(err)
+	is.Equal(res, "```go\nfunc New() *Thing\n```")
+}
+
 func TestHeader(t *testing.T) {
 	tests := []struct {
 		text   string
@@ -167,6 +191,37 @@ func TestLink(t *testing.T) {
 	is.Equal(res, "[link text](<https://test.com/a/b/c>)")
 }
 
+func TestCode(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AzureDevOpsMarkdown
+	res, err := f.Code("map[string]int")
+	is.NoErr(err)
+	is.Equal(res, "`map[string]int`")
+}
+
+func TestBadge(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AzureDevOpsMarkdown
+	res, err := f.Badge("linux only")
+	is.NoErr(err)
+	is.Equal(res, "<kbd>linux only</kbd>")
+}
+
+func TestTable(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AzureDevOpsMarkdown
+	header, err := f.TableHeader("Field", "Description")
+	is.NoErr(err)
+	is.Equal(header, "| Field | Description |\n| --- | --- |")
+
+	row, err := f.TableRow("Name", "Name of the thing.")
+	is.NoErr(err)
+	is.Equal(row, "| Name | Name of the thing. |")
+}
+
 func TestListEntry(t *testing.T) {
 	is := is.New(t)
 
@@ -1,6 +1,12 @@
 package format
 
-import "github.com/princjef/gomarkdoc/lang"
+import (
+	"html"
+	"strings"
+
+	"github.com/princjef/gomarkdoc/format/formatcore"
+	"github.com/princjef/gomarkdoc/lang"
+)
 
 // Format is a generic interface for formatting documentation contents in a
 // particular way.
@@ -75,3 +81,147 @@ type Format interface {
 	// Escape escapes special markdown characters from the provided text.
 	Escape(text string) string
 }
+
+// LinkedCodeBlocker is implemented by formats that can render links within
+// code blocks. It is optional, so formats written before it was added keep
+// working. See LinkedCodeBlock for how formats without it are handled.
+type LinkedCodeBlocker interface {
+	// LinkedCodeBlock wraps the code represented by the provided spans as a
+	// code block. Spans of the link kind are rendered as links within the code
+	// block if the format supports it. Otherwise, the result is equivalent to
+	// calling CodeBlock with the combined text of the spans.
+	LinkedCodeBlock(language string, spans []*lang.Span) (string, error)
+}
+
+// LinkedCodeBlock wraps the code represented by the provided spans as a code
+// block using the provided format. If the format doesn't implement
+// LinkedCodeBlocker, the combined text of the spans is wrapped with CodeBlock
+// without any links.
+func LinkedCodeBlock(f Format, language string, spans []*lang.Span) (string, error) {
+	if l, ok := f.(LinkedCodeBlocker); ok {
+		return l.LinkedCodeBlock(language, spans)
+	}
+
+	return f.CodeBlock(language, spanText(spans))
+}
+
+// Coder is implemented by formats that can format text as inline code. It is
+// optional, so formats written before it was added keep working. See Code for
+// how formats without it are handled.
+type Coder interface {
+	// Code formats the provided text as inline code.
+	Code(code string) (string, error)
+}
+
+// Code formats the provided text as inline code using the provided format. If
+// the format doesn't implement Coder, the text is escaped as plain text
+// instead.
+func Code(f Format, code string) (string, error) {
+	if c, ok := f.(Coder); ok {
+		return c.Code(code)
+	}
+
+	return f.Escape(code), nil
+}
+
+// Badger is implemented by formats that can format text as a badge. It is
+// optional, so formats written before it was added keep working. See Badge for
+// how formats without it are handled.
+type Badger interface {
+	// Badge formats the provided text as a short label that stands out from
+	// the surrounding text, such as a note of the platforms a symbol is
+	// available on.
+	Badge(text string) (string, error)
+}
+
+// Badge formats the provided text as a badge using the provided format. If the
+// format doesn't implement Badger, the text is escaped as plain text instead.
+func Badge(f Format, text string) (string, error) {
+	if b, ok := f.(Badger); ok {
+		return b.Badge(text)
+	}
+
+	return f.Escape(text), nil
+}
+
+// Tabler is implemented by formats that can generate tables. It is optional,
+// so formats written before it was added keep working. See TableHeader and
+// TableRow for how formats without it are handled.
+type Tabler interface {
+	// TableHeader generates the header row of a table with the provided
+	// column names. It is expected to be followed by rows generated with
+	// TableRow, each on their own line.
+	TableHeader(columns ...string) (string, error)
+
+	// TableRow generates a row of a table with the provided cells, which are
+	// expected to already be formatted.
+	TableRow(cells ...string) (string, error)
+}
+
+// TableHeader generates the header row of a table with the provided column
+// names using the provided format. If the format doesn't implement Tabler, a
+// markdown table is generated.
+func TableHeader(f Format, columns ...string) (string, error) {
+	if t, ok := f.(Tabler); ok {
+		return t.TableHeader(columns...)
+	}
+
+	return formatcore.TableHeader(columns...), nil
+}
+
+// TableRow generates a row of a table with the provided cells using the
+// provided format. If the format doesn't implement Tabler, a row of a markdown
+// table is generated.
+func TableRow(f Format, cells ...string) (string, error) {
+	if t, ok := f.(Tabler); ok {
+		return t.TableRow(cells...)
+	}
+
+	return formatcore.TableRow(cells...), nil
+}
+
+// Resetter is implemented by formats that keep track of what they generated
+// for the current output, such as the ids of its headers. It is optional, so
+// formats without any such state don't need it. Renderers reset the format
+// before rendering each output.
+type Resetter interface {
+	// Reset clears the state kept for the current output.
+	Reset()
+}
+
+// hasLinks identifies whether any of the provided spans are links.
+func hasLinks(spans []*lang.Span) bool {
+	for _, s := range spans {
+		if s.Kind() == lang.LinkSpan && s.URL() != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// spanText combines the raw text of the provided spans.
+func spanText(spans []*lang.Span) string {
+	var b strings.Builder
+	for _, s := range spans {
+		b.WriteString(s.Text())
+	}
+
+	return b.String()
+}
+
+// spanHTML combines the provided spans into HTML, rendering link spans as
+// anchor elements and escaping all other text.
+func spanHTML(spans []*lang.Span) string {
+	var b strings.Builder
+	for _, s := range spans {
+		if s.Kind() == lang.LinkSpan && s.URL() != "" {
+			b.WriteString(formatcore.HTMLLink(s.Text(), s.URL()))
+			continue
+		}
+
+		b.WriteString(html.EscapeString(s.Text()))
+	}
+
+	return b.String()
+}
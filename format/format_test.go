@@ -0,0 +1,67 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/format"
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+// baseFormat only implements Format, like formats written before the optional
+// format interfaces were added.
+type baseFormat struct {
+	format.Format
+}
+
+func TestLinkedCodeBlock_fallback(t *testing.T) {
+	is := is.New(t)
+
+	f := baseFormat{&format.GitHubFlavoredMarkdown{}}
+	_, ok := format.Format(f).(format.LinkedCodeBlocker)
+	is.True(!ok)
+
+	res, err := format.LinkedCodeBlock(f, "go", []*lang.Span{
+		lang.NewSpan(nil, lang.RawTextSpan, "var x ", ""),
+		lang.NewSpan(nil, lang.LinkSpan, "Type", "#Type"),
+	})
+	is.NoErr(err)
+	is.Equal(res, "```go\nvar x Type\n```")
+}
+
+func TestCode_fallback(t *testing.T) {
+	is := is.New(t)
+
+	res, err := format.Code(baseFormat{&format.GitHubFlavoredMarkdown{}}, "a*b")
+	is.NoErr(err)
+	is.Equal(res, "a\\*b")
+
+	res, err = format.Code(&format.GitHubFlavoredMarkdown{}, "a*b")
+	is.NoErr(err)
+	is.Equal(res, "`a*b`")
+}
+
+func TestBadge_fallback(t *testing.T) {
+	is := is.New(t)
+
+	res, err := format.Badge(baseFormat{&format.GitHubFlavoredMarkdown{}}, "linux, windows")
+	is.NoErr(err)
+	is.Equal(res, "linux, windows")
+
+	res, err = format.Badge(&format.GitHubFlavoredMarkdown{}, "linux, windows")
+	is.NoErr(err)
+	is.Equal(res, "<kbd>linux, windows</kbd>")
+}
+
+func TestTable_fallback(t *testing.T) {
+	is := is.New(t)
+
+	f := baseFormat{&format.HTML{}}
+	header, err := format.TableHeader(f, "Name", "Type")
+	is.NoErr(err)
+	is.Equal(header, "| Name | Type |\n| --- | --- |")
+
+	row, err := format.TableRow(f, "`Field`", "first\nsecond")
+	is.NoErr(err)
+	is.Equal(row, "| `Field` | first<br>second |")
+}
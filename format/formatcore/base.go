@@ -38,6 +38,45 @@ func CodeBlock(code string) string {
 	return builder.String()
 }
 
+// Code wraps the provided text as inline code. The text is delimited by a run
+// of backticks longer than any run of backticks within it, so that it may
+// safely contain backticks itself.
+func Code(code string) string {
+	if code == "" {
+		return ""
+	}
+
+	longest, current := 0, 0
+	for _, r := range code {
+		if r != '`' {
+			current = 0
+			continue
+		}
+
+		current++
+		if current > longest {
+			longest = current
+		}
+	}
+
+	fence := strings.Repeat("`", longest+1)
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		return fmt.Sprintf("%s %s %s", fence, code, fence)
+	}
+
+	return fmt.Sprintf("%s%s%s", fence, code, fence)
+}
+
+// Badge formats the provided text as a short label using the HTML keyboard
+// input element, which is rendered with a border by most markdown renderers.
+func Badge(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("<kbd>%s</kbd>", Escape(text))
+}
+
 // GFMCodeBlock wraps the provided code as a code block and tags it with the
 // provided language (or no language if the empty string is provided), using
 // the triple backtick format from GitHub Flavored Markdown.
@@ -45,6 +84,23 @@ func GFMCodeBlock(language, code string) string {
 	return fmt.Sprintf("```%s\n%s\n```", language, strings.TrimSpace(code))
 }
 
+// HTMLCodeBlock wraps the provided HTML as a preformatted code block. The
+// contents are expected to already be escaped, which allows them to contain
+// HTML elements such as links.
+func HTMLCodeBlock(contents string) string {
+	return fmt.Sprintf("<pre>\n%s\n</pre>", strings.TrimSpace(contents))
+}
+
+// HTMLLink generates an HTML link with the given text and href values. The
+// text is escaped for use in HTML.
+func HTMLLink(text, href string) string {
+	if href == "" {
+		return html.EscapeString(text)
+	}
+
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(href), html.EscapeString(text))
+}
+
 // Anchor produces an anchor for the provided link.
 func Anchor(anchor string) string {
 	return fmt.Sprintf(
@@ -114,6 +170,38 @@ func ListEntry(depth int, text string) string {
 	return fmt.Sprintf("%s- %s", prefix, text)
 }
 
+// TableHeader generates the header row of a table with the provided column
+// names, along with the row separating the header from the body of the table.
+// The column names are escaped.
+func TableHeader(columns ...string) string {
+	names := make([]string, len(columns))
+	separators := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = Escape(c)
+		separators[i] = "---"
+	}
+
+	return fmt.Sprintf("%s\n%s", tableRow(names), tableRow(separators))
+}
+
+// TableRow generates a row in the body of a table with the provided cells.
+// Cells are expected to already be formatted, so they are not escaped, but any
+// pipe characters are escaped and line breaks are converted to HTML line
+// breaks so that the contents remain within the cell.
+func TableRow(cells ...string) string {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		c = strings.ReplaceAll(strings.TrimSpace(c), "|", "\\|")
+		escaped[i] = strings.ReplaceAll(c, "\n", "<br>")
+	}
+
+	return tableRow(escaped)
+}
+
+func tableRow(cells []string) string {
+	return fmt.Sprintf("| %s |", strings.Join(cells, " | "))
+}
+
 // GFMAccordion generates a collapsible content. The accordion's visible title
 // while collapsed is the provided title and the expanded content is the body.
 func GFMAccordion(title, body string) string {
@@ -56,6 +56,42 @@ func TestPlainText(t *testing.T) {
 	}
 }
 
+func TestCode(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{"", ""},
+		{"int", "`int`"},
+		{`json:"name"`, "`json:\"name\"`"},
+		{"a ` b", "``a ` b``"},
+		{"`quoted`", "`` `quoted` ``"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(Code(test.in), test.out)
+		})
+	}
+}
+
+func TestBadge(t *testing.T) {
+	is := is.New(t)
+	is.Equal(Badge("linux, darwin only"), "<kbd>linux, darwin only</kbd>")
+	is.Equal(Badge("requires tag: *beta*"), "<kbd>requires tag: \\*beta\\*</kbd>")
+	is.Equal(Badge(""), "")
+}
+
+func TestTableHeader(t *testing.T) {
+	is := is.New(t)
+	is.Equal(TableHeader("Field", "Type [1]"), "| Field | Type \\[1\\] |\n| --- | --- |")
+}
+
+func TestTableRow(t *testing.T) {
+	is := is.New(t)
+	is.Equal(TableRow("`a|b`", "first\n\nsecond\n"), "| `a\\|b` | first<br><br>second |")
+}
+
 func TestEscape(t *testing.T) {
 	tests := []struct {
 		in, out string
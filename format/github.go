@@ -2,7 +2,6 @@ package format
 
 import (
 	"fmt"
-	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -27,6 +26,29 @@ func (f *GitHubFlavoredMarkdown) CodeBlock(language, code string) (string, error
 	return formatcore.GFMCodeBlock(language, code), nil
 }
 
+// Code formats the provided text as inline code.
+func (f *GitHubFlavoredMarkdown) Code(code string) (string, error) {
+	return formatcore.Code(code), nil
+}
+
+// Badge formats the provided text as a short label that stands out from the
+// surrounding text.
+func (f *GitHubFlavoredMarkdown) Badge(text string) (string, error) {
+	return formatcore.Badge(text), nil
+}
+
+// LinkedCodeBlock wraps the code represented by the provided spans as a code
+// block. If any of the spans are links, the code block is rendered as an HTML
+// preformatted block so that the links are navigable. Otherwise, the result is
+// the same as CodeBlock.
+func (f *GitHubFlavoredMarkdown) LinkedCodeBlock(language string, spans []*lang.Span) (string, error) {
+	if !hasLinks(spans) {
+		return f.CodeBlock(language, spanText(spans))
+	}
+
+	return formatcore.HTMLCodeBlock(spanHTML(spans)), nil
+}
+
 // Anchor produces an anchor for the provided link.
 func (f *GitHubFlavoredMarkdown) Anchor(anchor string) string {
 	return formatcore.Anchor(anchor)
@@ -85,46 +107,11 @@ func (f *GitHubFlavoredMarkdown) Link(text, href string) (string, error) {
 	return formatcore.Link(text, href), nil
 }
 
-// CodeHref generates an href to the provided code entry.
+// CodeHref generates an href to the provided code entry. The link follows the
+// URL scheme of the repository's host, falling back to GitHub's scheme if
+// the host isn't known.
 func (f *GitHubFlavoredMarkdown) CodeHref(loc lang.Location) (string, error) {
-	// If there's no repo, we can't compute an href
-	if loc.Repo == nil {
-		return "", nil
-	}
-
-	var (
-		relative string
-		err      error
-	)
-	if filepath.IsAbs(loc.Filepath) {
-		relative, err = filepath.Rel(loc.WorkDir, loc.Filepath)
-		if err != nil {
-			return "", err
-		}
-	} else {
-		relative = loc.Filepath
-	}
-
-	full := filepath.Join(loc.Repo.PathFromRoot, relative)
-	p, err := filepath.Rel(string(filepath.Separator), full)
-	if err != nil {
-		return "", err
-	}
-
-	var locStr string
-	if loc.Start.Line == loc.End.Line {
-		locStr = fmt.Sprintf("L%d", loc.Start.Line)
-	} else {
-		locStr = fmt.Sprintf("L%d-L%d", loc.Start.Line, loc.End.Line)
-	}
-
-	return fmt.Sprintf(
-		"%s/blob/%s/%s#%s",
-		loc.Repo.Remote,
-		loc.Repo.DefaultBranch,
-		filepath.ToSlash(p),
-		locStr,
-	), nil
+	return loc.SourceHref(&lang.GitHubHost{})
 }
 
 // ListEntry generates an unordered list entry with the provided text at the
@@ -134,6 +121,19 @@ func (f *GitHubFlavoredMarkdown) ListEntry(depth int, text string) (string, erro
 	return formatcore.ListEntry(depth, text), nil
 }
 
+// TableHeader generates the header row of a table with the provided column
+// names. It is expected to be followed by rows generated with TableRow, each on
+// their own line.
+func (f *GitHubFlavoredMarkdown) TableHeader(columns ...string) (string, error) {
+	return formatcore.TableHeader(columns...), nil
+}
+
+// TableRow generates a row of a table with the provided cells, which are
+// expected to already be formatted.
+func (f *GitHubFlavoredMarkdown) TableRow(cells ...string) (string, error) {
+	return formatcore.TableRow(cells...), nil
+}
+
 // Accordion generates a collapsible content. The accordion's visible title
 // while collapsed is the provided title and the expanded content is the body.
 func (f *GitHubFlavoredMarkdown) Accordion(title, body string) (string, error) {
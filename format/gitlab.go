@@ -0,0 +1,171 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/princjef/gomarkdoc/format/formatcore"
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+// GitLabFlavoredMarkdown provides a Format which is compatible with GitLab
+// Flavored Markdown's syntax and semantics. See GitLab's documentation for
+// more details about their markdown format:
+// https://docs.gitlab.com/ee/user/markdown.html
+type GitLabFlavoredMarkdown struct{}
+
+// Bold converts the provided text to bold
+func (f *GitLabFlavoredMarkdown) Bold(text string) (string, error) {
+	return formatcore.Bold(text), nil
+}
+
+// CodeBlock wraps the provided code as a code block and tags it with the
+// provided language (or no language if the empty string is provided).
+func (f *GitLabFlavoredMarkdown) CodeBlock(language, code string) (string, error) {
+	return formatcore.GFMCodeBlock(language, code), nil
+}
+
+// Code formats the provided text as inline code.
+func (f *GitLabFlavoredMarkdown) Code(code string) (string, error) {
+	return formatcore.Code(code), nil
+}
+
+// Badge formats the provided text as a short label that stands out from the
+// surrounding text.
+func (f *GitLabFlavoredMarkdown) Badge(text string) (string, error) {
+	return formatcore.Badge(text), nil
+}
+
+// LinkedCodeBlock wraps the code represented by the provided spans as a code
+// block. If any of the spans are links, the code block is rendered as an HTML
+// preformatted block so that the links are navigable. Otherwise, the result is
+// the same as CodeBlock.
+func (f *GitLabFlavoredMarkdown) LinkedCodeBlock(language string, spans []*lang.Span) (string, error) {
+	if !hasLinks(spans) {
+		return f.CodeBlock(language, spanText(spans))
+	}
+
+	return formatcore.HTMLCodeBlock(spanHTML(spans)), nil
+}
+
+// Anchor produces an anchor for the provided link.
+func (f *GitLabFlavoredMarkdown) Anchor(anchor string) string {
+	return formatcore.Anchor(anchor)
+}
+
+// AnchorHeader converts the provided text and custom anchor link into a header
+// of the provided level. The level is expected to be at least 1.
+func (f *GitLabFlavoredMarkdown) AnchorHeader(level int, text, anchor string) (string, error) {
+	return formatcore.AnchorHeader(level, formatcore.Escape(text), anchor)
+}
+
+// Header converts the provided text into a header of the provided level. The
+// level is expected to be at least 1.
+func (f *GitLabFlavoredMarkdown) Header(level int, text string) (string, error) {
+	return formatcore.Header(level, formatcore.Escape(text))
+}
+
+// RawAnchorHeader converts the provided text and custom anchor link into a
+// header of the provided level without escaping the header text. The level is
+// expected to be at least 1.
+func (f *GitLabFlavoredMarkdown) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	return formatcore.AnchorHeader(level, text, anchor)
+}
+
+// RawHeader converts the provided text into a header of the provided level
+// without escaping the header text. The level is expected to be at least 1.
+func (f *GitLabFlavoredMarkdown) RawHeader(level int, text string) (string, error) {
+	return formatcore.Header(level, text)
+}
+
+var (
+	gitLabRemoveRegex = regexp.MustCompile(`[^\p{L}\p{M}\p{N}\p{Pc}\- ]+`)
+	gitLabHyphenRegex = regexp.MustCompile(`-{2,}`)
+)
+
+// LocalHref generates an href for navigating to a header with the given
+// headerText located within the same document as the href itself. Link
+// generation follows the rules GitLab uses for the ids of headers:
+// https://docs.gitlab.com/ee/user/markdown.html#heading-ids-and-links
+func (f *GitLabFlavoredMarkdown) LocalHref(headerText string) (string, error) {
+	result := formatcore.PlainText(headerText)
+	result = strings.ToLower(result)
+	result = strings.TrimSpace(result)
+	result = gitLabRemoveRegex.ReplaceAllString(result, "")
+	result = strings.ReplaceAll(result, " ", "-")
+	result = gitLabHyphenRegex.ReplaceAllString(result, "-")
+
+	return fmt.Sprintf("#%s", result), nil
+}
+
+// RawLocalHref generates an href within the same document but with a direct
+// link provided instead of text to slugify.
+func (f *GitLabFlavoredMarkdown) RawLocalHref(anchor string) string {
+	return fmt.Sprintf("#%s", anchor)
+}
+
+// Link generates a link with the given text and href values.
+func (f *GitLabFlavoredMarkdown) Link(text, href string) (string, error) {
+	return formatcore.Link(text, href), nil
+}
+
+// CodeHref generates an href to the provided code entry. The link follows the
+// URL scheme of the repository's host, falling back to GitLab's scheme if
+// the host isn't known.
+func (f *GitLabFlavoredMarkdown) CodeHref(loc lang.Location) (string, error) {
+	return loc.SourceHref(&lang.GitLabHost{})
+}
+
+// ListEntry generates an unordered list entry with the provided text at the
+// provided zero-indexed depth. A depth of 0 is considered the topmost level of
+// list.
+func (f *GitLabFlavoredMarkdown) ListEntry(depth int, text string) (string, error) {
+	return formatcore.ListEntry(depth, text), nil
+}
+
+// TableHeader generates the header row of a table with the provided column
+// names. It is expected to be followed by rows generated with TableRow, each on
+// their own line.
+func (f *GitLabFlavoredMarkdown) TableHeader(columns ...string) (string, error) {
+	return formatcore.TableHeader(columns...), nil
+}
+
+// TableRow generates a row of a table with the provided cells, which are
+// expected to already be formatted.
+func (f *GitLabFlavoredMarkdown) TableRow(cells ...string) (string, error) {
+	return formatcore.TableRow(cells...), nil
+}
+
+// Accordion generates a collapsible content. The accordion's visible title
+// while collapsed is the provided title and the expanded content is the body.
+// GitLab only renders markdown within the body when it's separated from the
+// surrounding tags by blank lines, so the body isn't wrapped in a paragraph.
+func (f *GitLabFlavoredMarkdown) Accordion(title, body string) (string, error) {
+	return fmt.Sprintf("<details><summary>%s</summary>\n\n%s\n\n</details>", title, formatcore.Escape(body)), nil
+}
+
+// AccordionHeader generates the header visible when an accordion is collapsed.
+//
+// The AccordionHeader is expected to be used in conjunction with
+// AccordionTerminator() when the demands of the body's rendering requires it to
+// be generated independently. The body must be separated from the header and
+// terminator by blank lines for its markdown to be rendered. The result looks
+// conceptually like the following:
+//
+//	accordion := format.AccordionHeader("Accordion Title") + "\n\nAccordion Body\n\n" + format.AccordionTerminator()
+func (f *GitLabFlavoredMarkdown) AccordionHeader(title string) (string, error) {
+	return fmt.Sprintf("<details><summary>%s</summary>", title), nil
+}
+
+// AccordionTerminator generates the code necessary to terminate an accordion
+// after the body. It is expected to be used in conjunction with
+// AccordionHeader(). See AccordionHeader for a full description.
+func (f *GitLabFlavoredMarkdown) AccordionTerminator() (string, error) {
+	return "</details>", nil
+}
+
+// Escape escapes special markdown characters from the provided text.
+func (f *GitLabFlavoredMarkdown) Escape(text string) string {
+	return formatcore.Escape(text)
+}
@@ -0,0 +1,120 @@
+package format_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/format"
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+func TestGitLabFlavoredMarkdown_Header(t *testing.T) {
+	is := is.New(t)
+
+	var f format.GitLabFlavoredMarkdown
+	res, err := f.Header(2, "with * escape")
+	is.NoErr(err)
+	is.Equal(res, "## with \\* escape")
+}
+
+func TestGitLabFlavoredMarkdown_LocalHref(t *testing.T) {
+	tests := map[string]string{
+		"Normal Header":          "#normal-header",
+		" Leading whitespace":    "#leading-whitespace",
+		"Multiple  spaces":       "#multiple-spaces",
+		"Tab	separated":          "#tabseparated",
+		"Dashes -- squeezed":     "#dashes-squeezed",
+		"Special(#)%^Characters": "#specialcharacters",
+		"func (T) Method":        "#func-t-method",
+		"snake_case Ünïcode":     "#snake_case-ünïcode",
+	}
+
+	for input, output := range tests {
+		t.Run(input, func(t *testing.T) {
+			is := is.New(t)
+
+			var f format.GitLabFlavoredMarkdown
+			res, err := f.LocalHref(input)
+			is.NoErr(err)
+			is.Equal(res, output)
+		})
+	}
+}
+
+func TestGitLabFlavoredMarkdown_CodeHref(t *testing.T) {
+	is := is.New(t)
+
+	wd, err := filepath.Abs(".")
+	is.NoErr(err)
+	locPath := filepath.Join(wd, "subdir", "file.go")
+
+	var f format.GitLabFlavoredMarkdown
+	res, err := f.CodeHref(lang.Location{
+		Start:    lang.Position{Line: 12, Col: 1},
+		End:      lang.Position{Line: 14, Col: 43},
+		Filepath: locPath,
+		WorkDir:  wd,
+		Repo: &lang.Repo{
+			Remote:        "https://gitlab.example.com/group/subgroup/repo",
+			DefaultBranch: "main",
+			PathFromRoot:  "/",
+		},
+	})
+	is.NoErr(err)
+	is.Equal(res, "https://gitlab.example.com/group/subgroup/repo/-/blob/main/subdir/file.go#L12-14")
+}
+
+func TestGitLabFlavoredMarkdown_CodeHref_singleLine(t *testing.T) {
+	is := is.New(t)
+
+	wd, err := filepath.Abs(".")
+	is.NoErr(err)
+	locPath := filepath.Join(wd, "subdir", "file.go")
+
+	var f format.GitLabFlavoredMarkdown
+	res, err := f.CodeHref(lang.Location{
+		Start:    lang.Position{Line: 12, Col: 1},
+		End:      lang.Position{Line: 12, Col: 43},
+		Filepath: locPath,
+		WorkDir:  wd,
+		Repo: &lang.Repo{
+			Remote:        "https://gitlab.example.com/group/repo",
+			DefaultBranch: "main",
+			PathFromRoot:  "/nested",
+		},
+	})
+	is.NoErr(err)
+	is.Equal(res, "https://gitlab.example.com/group/repo/-/blob/main/nested/subdir/file.go#L12")
+}
+
+func TestGitLabFlavoredMarkdown_CodeHref_noRepo(t *testing.T) {
+	is := is.New(t)
+
+	var f format.GitLabFlavoredMarkdown
+	res, err := f.CodeHref(lang.Location{
+		Start:    lang.Position{Line: 12, Col: 1},
+		End:      lang.Position{Line: 14, Col: 43},
+		Filepath: "file.go",
+		Repo:     nil,
+	})
+	is.NoErr(err)
+	is.Equal(res, "")
+}
+
+func TestGitLabFlavoredMarkdown_Accordion(t *testing.T) {
+	is := is.New(t)
+
+	var f format.GitLabFlavoredMarkdown
+	res, err := f.Accordion("Example", "some *body*")
+	is.NoErr(err)
+	is.Equal(res, "<details><summary>Example</summary>\n\nsome \\*body\\*\n\n</details>")
+
+	header, err := f.AccordionHeader("Deprecated")
+	is.NoErr(err)
+	is.Equal(header, "<details><summary>Deprecated</summary>")
+
+	terminator, err := f.AccordionTerminator()
+	is.NoErr(err)
+	is.Equal(terminator, "</details>")
+}
@@ -0,0 +1,345 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/princjef/gomarkdoc/format/formatcore"
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+// HTML provides a Format which produces HTML instead of markdown, for sites
+// that don't render markdown. Headers have ids that can be linked to, code
+// blocks tagged with the go language are syntax highlighted and accordions
+// use the details element. Like on GitHub, ids generated from header text are
+// kept unique within each output by appending -1, -2 and so on to repeats.
+// Highlighted tokens are wrapped in span elements with one of the following
+// classes, which can be styled with CSS:
+//
+//   - kw: keywords
+//   - str: string and rune literals
+//   - num: numeric literals
+//   - com: comments
+//   - builtin: predeclared identifiers such as string, error and nil
+//
+// The templates used for the other formats produce markdown, so renderers use
+// a set of templates specific to this format when it is selected.
+type HTML struct {
+	// ids counts the ids generated from header text in the current output
+	ids map[string]int
+}
+
+// Reset forgets the header ids generated so far, so the next output starts
+// over.
+func (f *HTML) Reset() {
+	f.ids = nil
+}
+
+// Bold converts the provided text to bold
+func (f *HTML) Bold(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("<strong>%s</strong>", html.EscapeString(text)), nil
+}
+
+// CodeBlock wraps the provided code as a code block and tags it with the
+// provided language (or no language if the empty string is provided). Go code
+// is syntax highlighted.
+func (f *HTML) CodeBlock(language, code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if language == "go" {
+		return htmlCodeBlock(language, highlightGo(code)), nil
+	}
+
+	return htmlCodeBlock(language, html.EscapeString(code)), nil
+}
+
+// Code formats the provided text as inline code.
+func (f *HTML) Code(code string) (string, error) {
+	if code == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("<code>%s</code>", html.EscapeString(code)), nil
+}
+
+// Badge formats the provided text as a short label that stands out from the
+// surrounding text.
+func (f *HTML) Badge(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("<span class=\"badge\">%s</span>", html.EscapeString(text)), nil
+}
+
+// LinkedCodeBlock wraps the code represented by the provided spans as a code
+// block, with the link spans rendered as links. Go code is syntax highlighted
+// around the links.
+func (f *HTML) LinkedCodeBlock(language string, spans []*lang.Span) (string, error) {
+	var b strings.Builder
+	for _, s := range spans {
+		switch {
+		case s.Kind() == lang.LinkSpan && s.URL() != "":
+			b.WriteString(formatcore.HTMLLink(s.Text(), s.URL()))
+		case language == "go":
+			b.WriteString(highlightGo(s.Text()))
+		default:
+			b.WriteString(html.EscapeString(s.Text()))
+		}
+	}
+
+	return htmlCodeBlock(language, strings.TrimSpace(b.String())), nil
+}
+
+// Anchor produces an anchor for the provided link.
+func (f *HTML) Anchor(anchor string) string {
+	return fmt.Sprintf("<a id=\"%s\"></a>", html.EscapeString(anchor))
+}
+
+// AnchorHeader converts the provided text and custom anchor link into a header
+// of the provided level. The level is expected to be at least 1.
+func (f *HTML) AnchorHeader(level int, text, anchor string) (string, error) {
+	return htmlHeader(level, html.EscapeString(text), anchor)
+}
+
+// Header converts the provided text into a header of the provided level. The
+// level is expected to be at least 1.
+func (f *HTML) Header(level int, text string) (string, error) {
+	return htmlHeader(level, html.EscapeString(text), f.uniqueID(htmlID(text)))
+}
+
+// RawAnchorHeader converts the provided text and custom anchor link into a
+// header of the provided level without escaping the header text. The level is
+// expected to be at least 1.
+func (f *HTML) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	return htmlHeader(level, text, anchor)
+}
+
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// RawHeader converts the provided text into a header of the provided level
+// without escaping the header text. The level is expected to be at least 1.
+func (f *HTML) RawHeader(level int, text string) (string, error) {
+	plain := html.UnescapeString(htmlTagRegex.ReplaceAllString(text, ""))
+	return htmlHeader(level, text, f.uniqueID(htmlID(plain)))
+}
+
+// LocalHref generates an href for navigating to a header with the given
+// headerText located within the same document as the href itself.
+func (f *HTML) LocalHref(headerText string) (string, error) {
+	return fmt.Sprintf("#%s", htmlID(headerText)), nil
+}
+
+// RawLocalHref generates an href within the same document but with a direct
+// link provided instead of text to slugify.
+func (f *HTML) RawLocalHref(anchor string) string {
+	return fmt.Sprintf("#%s", anchor)
+}
+
+// Link generates a link with the given text and href values.
+func (f *HTML) Link(text, href string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return formatcore.HTMLLink(text, href), nil
+}
+
+// CodeHref generates an href to the provided code entry.
+func (f *HTML) CodeHref(loc lang.Location) (string, error) {
+	// The code is hosted in the same place regardless of the documentation's
+	// format.
+	var gfm GitHubFlavoredMarkdown
+	return gfm.CodeHref(loc)
+}
+
+// ListEntry generates an unordered list item with the provided text. Nesting is
+// expressed by the lists that contain the items, so the depth is ignored.
+func (f *HTML) ListEntry(depth int, text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("<li>%s</li>", text), nil
+}
+
+// TableHeader generates the start of a table and its header row with the
+// provided column names. It is expected to be followed by rows generated with
+// TableRow. The table is left open for the rows, so it ends along with the
+// element containing it unless it is closed explicitly.
+func (f *HTML) TableHeader(columns ...string) (string, error) {
+	var b strings.Builder
+	b.WriteString("<table>\n<tr>")
+	for _, c := range columns {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(c))
+	}
+
+	b.WriteString("</tr>")
+	return b.String(), nil
+}
+
+// TableRow generates a row of a table with the provided cells, which are
+// expected to already be formatted.
+func (f *HTML) TableRow(cells ...string) (string, error) {
+	var b strings.Builder
+	b.WriteString("<tr>")
+	for _, c := range cells {
+		fmt.Fprintf(&b, "<td>%s</td>", strings.TrimSpace(c))
+	}
+
+	b.WriteString("</tr>")
+	return b.String(), nil
+}
+
+// Accordion generates a collapsible content. The accordion's visible title
+// while collapsed is the provided title and the expanded content is the body.
+func (f *HTML) Accordion(title, body string) (string, error) {
+	return fmt.Sprintf(
+		"<details><summary>%s</summary>\n<p>%s</p>\n</details>",
+		html.EscapeString(title),
+		html.EscapeString(body),
+	), nil
+}
+
+// AccordionHeader generates the header visible when an accordion is collapsed.
+//
+// The AccordionHeader is expected to be used in conjunction with
+// AccordionTerminator() when the demands of the body's rendering requires it to
+// be generated independently. The result looks conceptually like the following:
+//
+//	accordion := format.AccordionHeader("Accordion Title") + "Accordion Body" + format.AccordionTerminator()
+func (f *HTML) AccordionHeader(title string) (string, error) {
+	return fmt.Sprintf("<details><summary>%s</summary>", html.EscapeString(title)), nil
+}
+
+// AccordionTerminator generates the code necessary to terminate an accordion
+// after the body. It is expected to be used in conjunction with
+// AccordionHeader(). See AccordionHeader for a full description.
+func (f *HTML) AccordionTerminator() (string, error) {
+	return "</details>", nil
+}
+
+// Escape escapes special HTML characters from the provided text.
+func (f *HTML) Escape(text string) string {
+	return html.EscapeString(text)
+}
+
+// htmlHeader produces a header element of the provided level with the provided
+// id. The text is expected to already be escaped.
+func htmlHeader(level int, text, id string) (string, error) {
+	if level < 1 {
+		return "", errors.New("format: header level cannot be less than 1")
+	}
+
+	// Only go up to 6 levels. Anything higher is also level 6
+	if level > 6 {
+		level = 6
+	}
+
+	return fmt.Sprintf("<h%d id=\"%s\">%s</h%d>", level, html.EscapeString(id), text, level), nil
+}
+
+// htmlID produces the id of a header with the provided text, using the same
+// rules as GitHub uses for the anchors of markdown headers.
+func htmlID(text string) string {
+	result := strings.ToLower(strings.TrimSpace(text))
+	result = gfmWhitespaceRegex.ReplaceAllString(result, "-")
+	return gfmRemoveRegex.ReplaceAllString(result, "")
+}
+
+// uniqueID makes the provided header id unique within the current output by
+// appending -1, -2 and so on to ids that were already generated.
+func (f *HTML) uniqueID(id string) string {
+	if f.ids == nil {
+		f.ids = make(map[string]int)
+	}
+
+	n := f.ids[id]
+	f.ids[id]++
+	if n == 0 {
+		return id
+	}
+
+	return fmt.Sprintf("%s-%d", id, n)
+}
+
+// htmlCodeBlock wraps the provided HTML as a preformatted code block tagged
+// with the provided language, if any.
+func htmlCodeBlock(language, contents string) string {
+	if language == "" {
+		return fmt.Sprintf("<pre><code>%s</code></pre>", contents)
+	}
+
+	return fmt.Sprintf("<pre><code class=\"language-%s\">%s</code></pre>", html.EscapeString(language), contents)
+}
+
+// highlightGo escapes the provided go code for use in HTML, wrapping tokens that
+// are highlighted in span elements classed by the kind of token. The code may
+// be a fragment of a larger piece of code, as long as it doesn't start or end
+// in the middle of a token.
+func highlightGo(code string) string {
+	src := []byte(code)
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var s scanner.Scanner
+	s.Init(file, src, nil, scanner.ScanComments)
+
+	var (
+		b      strings.Builder
+		cursor int
+	)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		class := goTokenClass(tok, lit)
+		if class == "" {
+			// Everything else is written along with the text between tokens
+			continue
+		}
+
+		start := file.Offset(pos)
+		end := start + len(lit)
+		if end > len(code) || start < cursor {
+			continue
+		}
+
+		b.WriteString(html.EscapeString(code[cursor:start]))
+		fmt.Fprintf(&b, "<span class=\"%s\">%s</span>", class, html.EscapeString(code[start:end]))
+		cursor = end
+	}
+
+	b.WriteString(html.EscapeString(code[cursor:]))
+	return b.String()
+}
+
+// goTokenClass provides the class used to highlight the token with the provided
+// literal text, or an empty string if the token isn't highlighted.
+func goTokenClass(tok token.Token, lit string) string {
+	switch {
+	case tok.IsKeyword():
+		return "kw"
+	case tok == token.STRING || tok == token.CHAR:
+		return "str"
+	case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+		return "num"
+	case tok == token.COMMENT:
+		return "com"
+	case tok == token.IDENT && types.Universe.Lookup(lit) != nil:
+		return "builtin"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,226 @@
+package format_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/format"
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+func TestHTML_Bold(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.Bold("a < b")
+	is.NoErr(err)
+	is.Equal(res, "<strong>a &lt; b</strong>")
+}
+
+func TestHTML_CodeBlock(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.CodeBlock("go", "// New makes a thing\nfunc New(s string) int {\n\treturn 1\n}\n")
+	is.NoErr(err)
+	is.Equal(res, ""+
+		"<pre><code class=\"language-go\"><span class=\"com\">// New makes a thing</span>\n"+
+		"<span class=\"kw\">func</span> New(s <span class=\"builtin\">string</span>) "+
+		"<span class=\"builtin\">int</span> {\n"+
+		"\t<span class=\"kw\">return</span> <span class=\"num\">1</span>\n"+
+		"}</code></pre>")
+}
+
+func TestHTML_CodeBlock_noLanguage(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.CodeBlock("", "func <T>\nreturn")
+	is.NoErr(err)
+	is.Equal(res, "<pre><code>func &lt;T&gt;\nreturn</code></pre>")
+}
+
+func TestHTML_LinkedCodeBlock(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.LinkedCodeBlock("go", []*lang.Span{
+		lang.NewSpan(nil, lang.RawTextSpan, "func New() *", ""),
+		lang.NewSpan(nil, lang.LinkSpan, "Thing", "#Thing"),
+		lang.NewSpan(nil, lang.RawTextSpan, " // a < b", ""),
+	})
+	is.NoErr(err)
+	is.Equal(res, ""+
+		"<pre><code class=\"language-go\"><span class=\"kw\">func</span> New() *<a href=\"#Thing\">Thing</a> "+
+		"<span class=\"com\">// a &lt; b</span></code></pre>")
+}
+
+func TestHTML_Header(t *testing.T) {
+	tests := []struct {
+		text   string
+		level  int
+		result string
+	}{
+		{"header text", 1, "<h1 id=\"header-text\">header text</h1>"},
+		{"level 3", 3, "<h3 id=\"level-3\">level 3</h3>"},
+		{"other level", 12, "<h6 id=\"other-level\">other level</h6>"},
+		{"with <b> escape", 2, "<h2 id=\"with-b-escape\">with &lt;b&gt; escape</h2>"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s (level %d)", test.text, test.level), func(t *testing.T) {
+			is := is.New(t)
+
+			var f format.HTML
+			res, err := f.Header(test.level, test.text)
+			is.NoErr(err)
+			is.Equal(res, test.result)
+		})
+	}
+}
+
+func TestHTML_Header_repeated(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	for _, expected := range []string{"output", "output-1", "output-2"} {
+		res, err := f.Header(4, "Output")
+		is.NoErr(err)
+		is.Equal(res, fmt.Sprintf("<h4 id=\"%s\">Output</h4>", expected))
+	}
+
+	res, err := f.RawHeader(4, "<code>Output</code>")
+	is.NoErr(err)
+	is.Equal(res, "<h4 id=\"output-3\"><code>Output</code></h4>")
+
+	// Each output starts over
+	f.Reset()
+	res, err = f.Header(4, "Output")
+	is.NoErr(err)
+	is.Equal(res, "<h4 id=\"output\">Output</h4>")
+}
+
+func TestHTML_Header_invalidLevel(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	_, err := f.Header(0, "invalid")
+	is.Equal(err.Error(), "format: header level cannot be less than 1")
+}
+
+func TestHTML_RawHeader(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.RawHeader(2, "type <a href=\"#x\">Thing</a>")
+	is.NoErr(err)
+	is.Equal(res, "<h2 id=\"type-thing\">type <a href=\"#x\">Thing</a></h2>")
+}
+
+func TestHTML_AnchorHeader(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.AnchorHeader(3, "func New", "pkg.New")
+	is.NoErr(err)
+	is.Equal(res, "<h3 id=\"pkg.New\">func New</h3>")
+
+	is.Equal(f.Anchor("pkg.Value"), "<a id=\"pkg.Value\"></a>")
+	is.Equal(f.RawLocalHref("pkg.New"), "#pkg.New")
+}
+
+func TestHTML_LocalHref(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.LocalHref("Normal Header")
+	is.NoErr(err)
+	is.Equal(res, "#normal-header")
+
+	// Links to headers point to their ids
+	header, err := f.Header(2, "Normal Header")
+	is.NoErr(err)
+	is.Equal(header, "<h2 id=\"normal-header\">Normal Header</h2>")
+}
+
+func TestHTML_Link(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.Link("a & b", "https://test.com/?a=1&b=2")
+	is.NoErr(err)
+	is.Equal(res, "<a href=\"https://test.com/?a=1&amp;b=2\">a &amp; b</a>")
+
+	res, err = f.Link("no href", "")
+	is.NoErr(err)
+	is.Equal(res, "no href")
+}
+
+func TestHTML_Code(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.Code("map[string]<-chan int")
+	is.NoErr(err)
+	is.Equal(res, "<code>map[string]&lt;-chan int</code>")
+}
+
+func TestHTML_Badge(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.Badge("linux only")
+	is.NoErr(err)
+	is.Equal(res, "<span class=\"badge\">linux only</span>")
+}
+
+func TestHTML_Table(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	header, err := f.TableHeader("Field", "Description")
+	is.NoErr(err)
+	is.Equal(header, "<table>\n<tr><th>Field</th><th>Description</th></tr>")
+
+	row, err := f.TableRow("Name", "<code>string</code>")
+	is.NoErr(err)
+	is.Equal(row, "<tr><td>Name</td><td><code>string</code></td></tr>")
+}
+
+func TestHTML_ListEntry(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.ListEntry(1, "list entry text")
+	is.NoErr(err)
+	is.Equal(res, "<li>list entry text</li>")
+
+	res, err = f.ListEntry(0, "")
+	is.NoErr(err)
+	is.Equal(res, "")
+}
+
+func TestHTML_Accordion(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	res, err := f.Accordion("Example (a < b)", "body & more")
+	is.NoErr(err)
+	is.Equal(res, "<details><summary>Example (a &lt; b)</summary>\n<p>body &amp; more</p>\n</details>")
+
+	header, err := f.AccordionHeader("Deprecated")
+	is.NoErr(err)
+	is.Equal(header, "<details><summary>Deprecated</summary>")
+
+	terminator, err := f.AccordionTerminator()
+	is.NoErr(err)
+	is.Equal(terminator, "</details>")
+}
+
+func TestHTML_Escape(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTML
+	is.Equal(f.Escape("*a* <b> & [c]"), "*a* &lt;b&gt; &amp; [c]")
+}
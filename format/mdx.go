@@ -0,0 +1,149 @@
+package format
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+// MDX adapts another markdown Format so that its output can be compiled as
+// MDX, which is how sites built with Docusaurus read markdown. MDX parses
+// HTML as JSX, so the HTML produced by the other formats is adjusted to be
+// valid JSX:
+//
+//   - anchors use the id attribute, which also lets sites check links to them
+//   - accordions don't wrap their contents in a paragraph
+//   - line breaks in table cells are self-closing
+//   - links don't use angle brackets, which MDX would parse as JSX
+//   - code blocks never contain links, since JSX doesn't preserve the
+//     whitespace of preformatted elements
+//
+// The HTML comment at the top of the generated documentation is also replaced
+// with an MDX comment by the templates used for this format.
+type MDX struct {
+	Format
+}
+
+// LinkedCodeBlock wraps the code represented by the provided spans as a code
+// block without any links.
+func (f *MDX) LinkedCodeBlock(language string, spans []*lang.Span) (string, error) {
+	return f.Format.CodeBlock(language, spanText(spans))
+}
+
+// Anchor produces an anchor for the provided link.
+func (f *MDX) Anchor(anchor string) string {
+	return fmt.Sprintf("<a id=\"%s\"></a>", html.EscapeString(anchor))
+}
+
+// AnchorHeader converts the provided text and custom anchor link into a header
+// of the provided level. The level is expected to be at least 1.
+func (f *MDX) AnchorHeader(level int, text, anchor string) (string, error) {
+	header, err := f.Format.Header(level, text)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s\n%s", f.Anchor(anchor), header), nil
+}
+
+// RawAnchorHeader converts the provided text and custom anchor link into a
+// header of the provided level without escaping the header text. The level is
+// expected to be at least 1.
+func (f *MDX) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	header, err := f.Format.RawHeader(level, text)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s\n%s", f.Anchor(anchor), header), nil
+}
+
+var mdxHrefReplacer = strings.NewReplacer(
+	" ", "%20",
+	"(", "%28",
+	")", "%29",
+	"<", "%3C",
+	">", "%3E",
+)
+
+// Link generates a link with the given text and href values. Characters that
+// can't appear in the href of a link without angle brackets are
+// percent-encoded.
+func (f *MDX) Link(text, href string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	if href == "" {
+		return text, nil
+	}
+
+	return fmt.Sprintf("[%s](%s)", text, mdxHrefReplacer.Replace(href)), nil
+}
+
+// TableRow generates a row of a table with the provided cells, which are
+// expected to already be formatted.
+func (f *MDX) TableRow(cells ...string) (string, error) {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		escaped[i] = strings.ReplaceAll(c, "\n", "<br />")
+	}
+
+	return TableRow(f.Format, escaped...)
+}
+
+// TableHeader generates the header row of a table with the provided column
+// names.
+func (f *MDX) TableHeader(columns ...string) (string, error) {
+	return TableHeader(f.Format, columns...)
+}
+
+// Badge formats the provided text as a short label that stands out from the
+// surrounding text.
+func (f *MDX) Badge(text string) (string, error) {
+	return Badge(f.Format, text)
+}
+
+// Code formats the provided text as inline code.
+func (f *MDX) Code(code string) (string, error) {
+	return Code(f.Format, code)
+}
+
+var mdxTextReplacer = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"{", "&#123;",
+	"}", "&#125;",
+)
+
+// Accordion generates a collapsible content. The accordion's visible title
+// while collapsed is the provided title and the expanded content is the body.
+func (f *MDX) Accordion(title, body string) (string, error) {
+	return fmt.Sprintf(
+		"<details>\n<summary>%s</summary>\n\n%s\n\n</details>",
+		mdxTextReplacer.Replace(title),
+		f.Format.Escape(body),
+	), nil
+}
+
+// AccordionHeader generates the header visible when an accordion is collapsed.
+//
+// The AccordionHeader is expected to be used in conjunction with
+// AccordionTerminator() when the demands of the body's rendering requires it to
+// be generated independently. The body must be separated from the header and
+// terminator by blank lines. The result looks conceptually like the following:
+//
+//	accordion := format.AccordionHeader("Accordion Title") + "\n\nAccordion Body\n\n" + format.AccordionTerminator()
+func (f *MDX) AccordionHeader(title string) (string, error) {
+	return fmt.Sprintf("<details>\n<summary>%s</summary>", mdxTextReplacer.Replace(title)), nil
+}
+
+// AccordionTerminator generates the code necessary to terminate an accordion
+// after the body. It is expected to be used in conjunction with
+// AccordionHeader(). See AccordionHeader for a full description.
+func (f *MDX) AccordionTerminator() (string, error) {
+	return "</details>", nil
+}
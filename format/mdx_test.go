@@ -0,0 +1,87 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/format"
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+func TestMDX_Anchor(t *testing.T) {
+	is := is.New(t)
+
+	f := format.MDX{Format: &format.GitHubFlavoredMarkdown{}}
+	is.Equal(f.Anchor("Type.Func"), "<a id=\"Type.Func\"></a>")
+
+	res, err := f.AnchorHeader(2, "func (t *Type) Func()", "Type.Func")
+	is.NoErr(err)
+	is.Equal(res, "<a id=\"Type.Func\"></a>\n## func \\(t \\*Type\\) Func\\(\\)")
+
+	res, err = f.RawAnchorHeader(3, "type [Type](<#Type>)", "Type")
+	is.NoErr(err)
+	is.Equal(res, "<a id=\"Type\"></a>\n### type [Type](<#Type>)")
+}
+
+func TestMDX_LinkedCodeBlock(t *testing.T) {
+	is := is.New(t)
+
+	f := format.MDX{Format: &format.GitHubFlavoredMarkdown{}}
+	res, err := f.LinkedCodeBlock("go", []*lang.Span{
+		lang.NewSpan(nil, lang.RawTextSpan, "type Outer struct {\n\tInner ", ""),
+		lang.NewSpan(nil, lang.LinkSpan, "Inner", "#Inner"),
+		lang.NewSpan(nil, lang.RawTextSpan, "\n}", ""),
+	})
+	is.NoErr(err)
+	is.Equal(res, "```go\ntype Outer struct {\n\tInner Inner\n}\n```")
+}
+
+func TestMDX_Link(t *testing.T) {
+	is := is.New(t)
+
+	f := format.MDX{Format: &format.GitHubFlavoredMarkdown{}}
+	res, err := f.Link("Type", "https://test.com/a b/(c)")
+	is.NoErr(err)
+	is.Equal(res, "[Type](https://test.com/a%20b/%28c%29)")
+
+	res, err = f.Link("no href", "")
+	is.NoErr(err)
+	is.Equal(res, "no href")
+}
+
+func TestMDX_TableRow(t *testing.T) {
+	is := is.New(t)
+
+	f := format.MDX{Format: &format.GitHubFlavoredMarkdown{}}
+	res, err := f.TableRow("`Field`", "first line\nsecond line")
+	is.NoErr(err)
+	is.Equal(res, "| `Field` | first line<br />second line |")
+}
+
+func TestMDX_Accordion(t *testing.T) {
+	is := is.New(t)
+
+	f := format.MDX{Format: &format.GitHubFlavoredMarkdown{}}
+	res, err := f.Accordion("Inherited from Base[T]{}", "body text")
+	is.NoErr(err)
+	is.Equal(res, "<details>\n<summary>Inherited from Base[T]&#123;&#125;</summary>\n\nbody text\n\n</details>")
+
+	header, err := f.AccordionHeader("Example (<T>)")
+	is.NoErr(err)
+	is.Equal(header, "<details>\n<summary>Example (&lt;T&gt;)</summary>")
+
+	terminator, err := f.AccordionTerminator()
+	is.NoErr(err)
+	is.Equal(terminator, "</details>")
+}
+
+func TestMDX_delegates(t *testing.T) {
+	is := is.New(t)
+
+	f := format.MDX{Format: &format.GitLabFlavoredMarkdown{}}
+	res, err := f.LocalHref("Dashes -- squeezed")
+	is.NoErr(err)
+	is.Equal(res, "#dashes-squeezed")
+
+	is.Equal(f.Escape("{value}"), "\\{value\\}")
+}
@@ -22,6 +22,25 @@ func (f *PlainMarkdown) CodeBlock(language, code string) (string, error) {
 	return formatcore.CodeBlock(code), nil
 }
 
+// Code formats the provided text as inline code.
+func (f *PlainMarkdown) Code(code string) (string, error) {
+	return formatcore.Code(code), nil
+}
+
+// Badge formats the provided text as a short label that stands out from the
+// surrounding text. Plain markdown has no dedicated element for this, so the
+// text is made bold instead.
+func (f *PlainMarkdown) Badge(text string) (string, error) {
+	return formatcore.Bold(text), nil
+}
+
+// LinkedCodeBlock wraps the code represented by the provided spans as a code
+// block. Links are not supported within code blocks in plain markdown, so the
+// result is the same as CodeBlock with the combined text of the spans.
+func (f *PlainMarkdown) LinkedCodeBlock(language string, spans []*lang.Span) (string, error) {
+	return f.CodeBlock(language, spanText(spans))
+}
+
 // Anchor produces an anchor for the provided link.
 func (f *PlainMarkdown) Anchor(anchor string) string {
 	return formatcore.Anchor(anchor)
@@ -82,6 +101,20 @@ func (f *PlainMarkdown) ListEntry(depth int, text string) (string, error) {
 	return formatcore.ListEntry(depth, text), nil
 }
 
+// TableHeader generates the header row of a table with the provided column
+// names. It is expected to be followed by rows generated with TableRow, each on
+// their own line. Tables are not part of the base Markdown specification, but
+// the pipe table syntax used here is supported by most renderers.
+func (f *PlainMarkdown) TableHeader(columns ...string) (string, error) {
+	return formatcore.TableHeader(columns...), nil
+}
+
+// TableRow generates a row of a table with the provided cells, which are
+// expected to already be formatted.
+func (f *PlainMarkdown) TableRow(cells ...string) (string, error) {
+	return formatcore.TableRow(cells...), nil
+}
+
 // Accordion generates a collapsible content. Since accordions are not supported
 // by plain markdown, this generates a level 6 header followed by a paragraph.
 func (f *PlainMarkdown) Accordion(title, body string) (string, error) {
@@ -37,6 +37,30 @@ func TestPlainMarkdown_CodeBlock_noLanguage(t *testing.T) {
 	is.Equal(res, "\tLine 1\n\tLine 2")
 }
 
+func TestPlainMarkdown_LinkedCodeBlock(t *testing.T) {
+	is := is.New(t)
+
+	var f format.PlainMarkdown
+	res, err := f.LinkedCodeBlock("go", []*lang.Span{
+		lang.NewSpan(nil, lang.RawTextSpan, "func New() *", ""),
+		lang.NewSpan(nil, lang.LinkSpan, "Thing", "#Thing"),
+		lang.NewSpan(nil, lang.RawTextSpan, " // a < b", ""),
+	})
+	is.NoErr(err)
+	is.Equal(res, "\tfunc New() *Thing // a < b")
+}
+
+func TestPlainMarkdown_LinkedCodeBlock_noLinks(t *testing.T) {
+	is := is.New(t)
+
+	var f format.PlainMarkdown
+	res, err := f.LinkedCodeBlock("go", []*lang.Span{
+		lang.NewSpan(nil, lang.RawTextSpan, "func New() *Thing", ""),
+	})
+	is.NoErr(err)
+	is.Equal(res, "\tfunc New() *Thing")
+}
+
 func TestPlainMarkdown_Header(t *testing.T) {
 	tests := []struct {
 		text   string
@@ -155,6 +179,37 @@ func TestPlainMarkdown_Link(t *testing.T) {
 	is.Equal(res, "[link text](<https://test.com/a/b/c>)")
 }
 
+func TestPlainMarkdown_Code(t *testing.T) {
+	is := is.New(t)
+
+	var f format.PlainMarkdown
+	res, err := f.Code("map[string]int")
+	is.NoErr(err)
+	is.Equal(res, "`map[string]int`")
+}
+
+func TestPlainMarkdown_Badge(t *testing.T) {
+	is := is.New(t)
+
+	var f format.PlainMarkdown
+	res, err := f.Badge("linux only")
+	is.NoErr(err)
+	is.Equal(res, "**linux only**")
+}
+
+func TestPlainMarkdown_Table(t *testing.T) {
+	is := is.New(t)
+
+	var f format.PlainMarkdown
+	header, err := f.TableHeader("Field", "Description")
+	is.NoErr(err)
+	is.Equal(header, "| Field | Description |\n| --- | --- |")
+
+	row, err := f.TableRow("Name", "Name of the thing.")
+	is.NoErr(err)
+	is.Equal(row, "| Name | Name of the thing. |")
+}
+
 func TestPlainMarkdown_ListEntry(t *testing.T) {
 	is := is.New(t)
 
@@ -2,15 +2,16 @@
 
 mapName=$1
 filename=$2
+dir=${3:-templates}
 
 printf "// Code generated by gentmpl.sh; DO NOT EDIT.\n\npackage ${GOPACKAGE}\n\nvar ${mapName} = map[string]string{\n" > "${filename}.go"
 
-for f in ./templates/*.gotxt
+for f in ./${dir}/*.gotxt
 do
 	f=${f##*/}
 	name=${f%.*}
 	printf "\t\"$name\": \`" >> "${filename}.go"
-	cat ./templates/$f >> "${filename}.go"
+	cat ./${dir}/$f >> "${filename}.go"
 	printf "\`,\n" >> "${filename}.go"
 done
 
@@ -0,0 +1,472 @@
+// Code generated by gentmpl.sh; DO NOT EDIT.
+
+package gomarkdoc
+
+var htmlTemplates = map[string]string{
+	"doc": `{{- range (iter .Blocks) -}}
+	{{- if eq .Entry.Kind "paragraph" -}}
+		{{- if and .Entry.Inline .First .Last -}}
+			{{- template "text" .Entry.Spans -}}
+		{{- else -}}
+			<p>{{- template "text" .Entry.Spans -}}</p>
+		{{- end -}}
+	{{- else if eq .Entry.Kind "code" -}}
+		{{- codeBlock "" (include "text" .Entry.Spans) -}}
+	{{- else if eq .Entry.Kind "header" -}}
+		{{- rawHeader .Entry.Level (include "text" .Entry.Spans) -}}
+	{{- else if eq .Entry.Kind "list" -}}
+		{{- template "list" .Entry.List -}}
+	{{- end -}}
+	{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+{{- end -}}
+`,
+	"fields": `{{- if eq fieldStyle "table" -}}
+
+	{{- tableHeader "Field" "Type" "Description" -}}
+
+	{{- range .Fields -}}
+		{{- inlineSpacer -}}
+
+		{{- $name := escape .Name -}}
+		{{- if .Deprecated -}}
+			{{- $name = printf "%s (deprecated)" $name -}}
+		{{- end -}}
+
+		{{- $type := code .Type -}}
+		{{- if .Tag -}}
+			{{- $type = printf "%s %s" $type (code .Tag) -}}
+		{{- end -}}
+
+		{{- tableRow (printf "%s%s" (anchor .Anchor) $name) $type (include "doc" .Doc) -}}
+	{{- end -}}
+	{{- inlineSpacer -}}
+	</table>
+
+{{- else -}}
+
+	<dl>
+	{{- range .Fields -}}
+		{{- inlineSpacer -}}
+
+		{{- $term := "" -}}
+		{{- if .Embedded -}}
+			{{- $term = code .Type -}}
+		{{- else -}}
+			{{- $term = printf "%s %s" .Name .Type | code -}}
+		{{- end -}}
+		{{- if .Tag -}}
+			{{- $term = printf "%s %s" $term (code .Tag) -}}
+		{{- end -}}
+		{{- if .Deprecated -}}
+			{{- $term = printf "%s (deprecated)" $term -}}
+		{{- end -}}
+
+		<dt>{{- anchor .Anchor -}}{{- $term -}}</dt>
+		{{- if len .Doc.Blocks -}}
+			{{- inlineSpacer -}}
+			<dd>{{- template "doc" .Doc -}}</dd>
+		{{- end -}}
+	{{- end -}}
+	{{- inlineSpacer -}}
+	</dl>
+
+{{- end -}}
+`,
+	"file": `<!-- Code generated by gomarkdoc. DO NOT EDIT -->
+
+{{if .Header -}}
+	{{- .Header -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- range .Packages -}}
+	{{- template "package" . -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if .Footer -}}
+	{{- .Footer -}}
+	{{- spacer -}}
+{{- end -}}
+
+<footer>Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}</footer>
+`,
+	"func": `{{- $title := "" -}}
+{{- if .Receiver -}}
+	{{- $title = codeHref .Location | link .Name | printf "func (%s) %s" (escape .Receiver) -}}
+{{- else -}}
+	{{- $title = codeHref .Location | link .Name | printf "func %s" -}}
+{{- end -}}
+{{- if .Deprecated -}}
+	{{- $title = printf "%s (deprecated)" $title -}}
+{{- end -}}
+{{- rawAnchorHeader .Level $title .Anchor -}}
+{{- spacer -}}
+
+{{- if .Availability -}}
+	{{- badge .Availability -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if .Since -}}
+	{{- printf "Added in %s" .Since | badge -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- $collapse := and .Deprecated collapseDeprecated -}}
+{{- if $collapse -}}
+	{{- accordionHeader "Deprecated" -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if not .ExampleOnly -}}
+	{{- linkedCodeBlock "go" .SignatureSpans -}}
+	{{- spacer -}}
+
+	{{- template "doc" .Doc -}}
+
+	{{- if len .TypeParams -}}
+		{{- spacer -}}
+		{{- template "typeparams" .TypeParams -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Examples -}}
+	{{- if not .ExampleOnly -}}{{- spacer -}}{{- end -}}
+
+	{{- range (iter .Examples) -}}
+		{{- template "example" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if $collapse -}}
+	{{- spacer -}}
+	{{- accordionTerminator -}}
+{{- end -}}
+`,
+	"index": `<ul>
+{{- range (.Groups symbolOrder) -}}
+
+	{{- $nested := false -}}
+	{{- range .Entries -}}
+		{{- if or .Func .Type -}}{{- $nested = true -}}{{- end -}}
+	{{- end -}}
+
+	{{- if .Name -}}
+		{{- inlineSpacer -}}
+		<li>
+		{{- if .Anchor -}}
+			{{- rawLocalHref .Anchor | link .Name -}}
+		{{- else -}}
+			{{- localHref .Name | link .Name -}}
+		{{- end -}}
+		{{- if $nested -}}
+			{{- inlineSpacer -}}
+			<ul>
+		{{- end -}}
+	{{- end -}}
+
+	{{- range .Entries -}}
+
+		{{- if .Func -}}
+			{{- inlineSpacer -}}
+			<li>
+			{{- link .Func.Signature (rawLocalHref .Func.Anchor) -}}
+			{{- if .Func.Deprecated }} (deprecated){{ end -}}
+			</li>
+		{{- end -}}
+
+		{{- if .Type -}}
+			{{- inlineSpacer -}}
+			<li>
+			{{- link .Type.Title (rawLocalHref .Type.Anchor) -}}
+			{{- if .Type.Deprecated }} (deprecated){{ end -}}
+
+			{{- if or (len .Type.Funcs) (len .Type.Methods) -}}
+				{{- inlineSpacer -}}
+				<ul>
+
+				{{- range .Type.Funcs -}}
+					{{- inlineSpacer -}}
+					<li>
+					{{- link .Signature (rawLocalHref .Anchor) -}}
+					{{- if .Deprecated }} (deprecated){{ end -}}
+					</li>
+				{{- end -}}
+
+				{{- range .Type.Methods -}}
+					{{- inlineSpacer -}}
+					<li>
+					{{- link .Signature (rawLocalHref .Anchor) -}}
+					{{- if .Deprecated }} (deprecated){{ end -}}
+					</li>
+				{{- end -}}
+
+				{{- inlineSpacer -}}
+				</ul>
+				{{- inlineSpacer -}}
+			{{- end -}}
+			</li>
+		{{- end -}}
+
+	{{- end -}}
+
+	{{- if .Name -}}
+		{{- if $nested -}}
+			{{- inlineSpacer -}}
+			</ul>
+			{{- inlineSpacer -}}
+		{{- end -}}
+		</li>
+	{{- end -}}
+
+{{- end -}}
+{{- inlineSpacer -}}
+</ul>`,
+	"inherited": `{{- accordionHeader (printf "Inherited from %s" .Origin) -}}
+{{- spacer -}}
+
+{{- if .Href -}}
+	<p>{{- link .Origin .Href | printf "Methods and fields promoted from %s." -}}</p>
+{{- else -}}
+	<p>{{- escape .Origin | printf "Methods and fields promoted from %s." -}}</p>
+{{- end -}}
+{{- spacer -}}
+
+<ul>
+{{- range .Fields -}}
+	{{- inlineSpacer -}}
+	{{- if .Href -}}
+		{{- link .Signature .Href | listEntry 0 -}}
+	{{- else -}}
+		{{- escape .Signature | listEntry 0 -}}
+	{{- end -}}
+{{- end -}}
+
+{{- range .Methods -}}
+	{{- inlineSpacer -}}
+	{{- if .Href -}}
+		{{- link .Signature .Href | listEntry 0 -}}
+	{{- else -}}
+		{{- escape .Signature | listEntry 0 -}}
+	{{- end -}}
+{{- end -}}
+{{- inlineSpacer -}}
+</ul>
+{{- inlineSpacer -}}
+
+{{- accordionTerminator -}}
+`,
+	"list": `{{- $tag := "ul" -}}
+{{- range .Items -}}
+	{{- if eq .Kind "ordered" -}}{{- $tag = "ol" -}}{{- end -}}
+{{- end -}}
+
+<{{ $tag }}>
+{{- range .Items -}}
+	{{- inlineSpacer -}}
+	{{- if eq .Kind "ordered" -}}
+		<li value="{{ .Number }}">{{- include "doc" . -}}</li>
+	{{- else -}}
+		<li>{{- include "doc" . -}}</li>
+	{{- end -}}
+{{- end -}}
+{{- inlineSpacer -}}
+</{{ $tag }}>`,
+	"text": `{{- range . -}}
+	{{- if eq .Kind "text" -}}
+		{{- escape .Text -}}
+	{{- else if eq .Kind "rawText" -}}
+		{{- .Text -}}
+	{{- else if eq .Kind "autolink" -}}
+		{{- link .Text .Text -}}
+	{{- else if eq .Kind "link" -}}
+		{{- link .Text .URL -}}
+	{{- end -}}
+{{- end -}}`,
+	"type": `{{- $title := codeHref .Location | link .Name | printf "type %s" -}}
+{{- if .Deprecated -}}
+	{{- $title = printf "%s (deprecated)" $title -}}
+{{- end -}}
+{{- rawAnchorHeader .Level $title .Anchor -}}
+{{- spacer -}}
+
+{{- if .Availability -}}
+	{{- badge .Availability -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if .Since -}}
+	{{- printf "Added in %s" .Since | badge -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- $collapse := and .Deprecated collapseDeprecated -}}
+{{- if $collapse -}}
+	{{- accordionHeader "Deprecated" -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if .ExampleOnly -}}
+	{{- range (iter .Examples) -}}
+		{{- template "example" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- else -}}
+	{{- template "doc" .Doc -}}
+	{{- spacer -}}
+
+	{{- linkedCodeBlock "go" .DeclSpans -}}
+
+	{{- if len .TypeParams -}}
+		{{- spacer -}}
+		{{- template "typeparams" .TypeParams -}}
+	{{- end -}}
+
+	{{- if len .TypeSet -}}
+		{{- spacer -}}
+
+		<p>{{- bold "Type set" -}}</p>
+		{{- spacer -}}
+
+		<ul>
+		{{- inlineSpacer -}}
+		{{- range (iter .TypeSet) -}}
+			{{- if .Entry.Href -}}
+				{{- link .Entry.Text .Entry.Href | listEntry 0 -}}
+			{{- else -}}
+				{{- code .Entry.Text | listEntry 0 -}}
+			{{- end -}}
+			{{- inlineSpacer -}}
+		{{- end -}}
+		</ul>
+	{{- end -}}
+
+	{{- if len .Fields -}}
+		{{- spacer -}}
+
+		<p>{{- bold "Fields" -}}</p>
+		{{- spacer -}}
+
+		{{- template "fields" . -}}
+	{{- end -}}
+
+	{{- if len .Implements -}}
+		{{- spacer -}}
+
+		<p>{{- bold "Implements" -}}</p>
+		{{- spacer -}}
+
+		<ul>
+		{{- inlineSpacer -}}
+		{{- range (iter .Implements) -}}
+			{{- if .Entry.Pointer -}}
+				{{- link .Entry.Name .Entry.Href | printf "%s (pointer receiver)" | listEntry 0 -}}
+			{{- else -}}
+				{{- link .Entry.Name .Entry.Href | listEntry 0 -}}
+			{{- end -}}
+			{{- inlineSpacer -}}
+		{{- end -}}
+		</ul>
+	{{- end -}}
+
+	{{- if len .Implementors -}}
+		{{- spacer -}}
+
+		<p>{{- bold "Implemented by" -}}</p>
+		{{- spacer -}}
+
+		<ul>
+		{{- inlineSpacer -}}
+		{{- range (iter .Implementors) -}}
+			{{- if .Entry.Pointer -}}
+				{{- link (printf "*%s" .Entry.Name) .Entry.Href | listEntry 0 -}}
+			{{- else -}}
+				{{- link .Entry.Name .Entry.Href | listEntry 0 -}}
+			{{- end -}}
+			{{- inlineSpacer -}}
+		{{- end -}}
+		</ul>
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Consts -}}
+	{{- spacer -}}
+
+	{{- range (iter .Consts) -}}
+		{{- template "value" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Vars -}}
+	{{- spacer -}}
+	
+	{{- range (iter .Vars) -}}
+		{{- template "value" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if and (len .Examples) (not .ExampleOnly) -}}
+	{{- spacer -}}
+	
+	{{- range (iter .Examples) -}}
+		{{- template "example" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Funcs -}}
+	{{- spacer -}}
+	
+	{{- range (iter .Funcs) -}}
+		{{- template "func" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Methods -}}
+	{{- spacer -}}
+	
+	{{- range (iter .Methods) -}}
+		{{- template "func" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+
+{{- if len .Inherited -}}
+	{{- spacer -}}
+
+	{{- range (iter .Inherited) -}}
+		{{- template "inherited" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if $collapse -}}
+	{{- spacer -}}
+	{{- accordionTerminator -}}
+{{- end -}}
+`,
+	"typeparams": `<p>{{- bold "Type parameters" -}}</p>
+{{- spacer -}}
+
+<ul>
+{{- range . -}}
+	{{- inlineSpacer -}}
+
+	{{- $constraint := "" -}}
+	{{- if .ConstraintHref -}}
+		{{- $constraint = link .Constraint .ConstraintHref -}}
+	{{- else -}}
+		{{- $constraint = code .Constraint -}}
+	{{- end -}}
+
+	{{- printf "%s %s" (code .Name) $constraint | listEntry 0 -}}
+{{- end -}}
+{{- inlineSpacer -}}
+</ul>`,
+}
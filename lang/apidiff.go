@@ -0,0 +1,266 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/token"
+	"sort"
+)
+
+type (
+	// APIChange describes a change to a single exported symbol of a package
+	// between two versions of the package.
+	APIChange struct {
+		kind     ChangeKind
+		name     string
+		old      *apiDecl
+		new      *apiDecl
+		breaking string
+	}
+
+	// ChangeKind identifies whether a symbol was added, removed or changed
+	// between two versions of a package.
+	ChangeKind int
+
+	// apiDecl holds the parts of a symbol's declaration that make up the API of
+	// a package.
+	apiDecl struct {
+		kind  SymbolKind
+		title string
+		decl  string
+	}
+)
+
+const (
+	// AddedChange identifies symbols that are only in the new version of the
+	// package.
+	AddedChange ChangeKind = iota + 1
+
+	// RemovedChange identifies symbols that are only in the old version of the
+	// package.
+	RemovedChange
+
+	// ModifiedChange identifies symbols whose declaration differs between the
+	// versions of the package.
+	ModifiedChange
+)
+
+// DiffPackages compares the exported API of two versions of a package and
+// lists the symbols that were added, removed or changed, sorted by name.
+// Funcs and methods are compared by their signatures, types by their
+// declarations and consts and vars by the specs declaring them. Either package
+// may be nil if the package didn't exist in one of the versions. Doc comments
+// are not considered part of the API.
+func DiffPackages(old, new *Package) []*APIChange {
+	oldDecls, newDecls := packageAPI(old), packageAPI(new)
+
+	var changes []*APIChange
+	for name, n := range newDecls {
+		o, ok := oldDecls[name]
+		switch {
+		case !ok:
+			changes = append(changes, &APIChange{kind: AddedChange, name: name, new: n})
+		case o.kind != n.kind || o.decl != n.decl:
+			changes = append(changes, &APIChange{kind: ModifiedChange, name: name, old: o, new: n})
+		}
+	}
+
+	for name, o := range oldDecls {
+		if _, ok := newDecls[name]; !ok {
+			changes = append(changes, &APIChange{kind: RemovedChange, name: name, old: o})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].name < changes[j].name })
+	return changes
+}
+
+// Kind identifies whether the symbol was added, removed or changed.
+func (c *APIChange) Kind() ChangeKind {
+	return c.kind
+}
+
+// Name provides the name of the symbol, qualified by the type of its receiver
+// for methods (e.g. Type.Method).
+func (c *APIChange) Name() string {
+	return c.name
+}
+
+// SymbolKind provides the kind of the symbol in the newest version of the
+// package that has it.
+func (c *APIChange) SymbolKind() SymbolKind {
+	return c.latest().kind
+}
+
+// Title provides a short, single-line description of the symbol, such as the
+// signature of a func, "type Client" for a type or "const Name" for a const.
+func (c *APIChange) Title() string {
+	return c.latest().title
+}
+
+// Old provides the declaration of the symbol in the old version of the
+// package, or an empty string if the symbol was added.
+func (c *APIChange) Old() string {
+	if c.old == nil {
+		return ""
+	}
+
+	return c.old.decl
+}
+
+// New provides the declaration of the symbol in the new version of the
+// package, or an empty string if the symbol was removed.
+func (c *APIChange) New() string {
+	if c.new == nil {
+		return ""
+	}
+
+	return c.new.decl
+}
+
+func (c *APIChange) latest() *apiDecl {
+	if c.new != nil {
+		return c.new
+	}
+
+	return c.old
+}
+
+// String provides the name of the change kind, such as "added".
+func (k ChangeKind) String() string {
+	switch k {
+	case AddedChange:
+		return "added"
+	case RemovedChange:
+		return "removed"
+	case ModifiedChange:
+		return "changed"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// packageAPI collects the declarations of the exported symbols of the package,
+// keyed by their names qualified by their receivers.
+func packageAPI(pkg *Package) map[string]*apiDecl {
+	decls := make(map[string]*apiDecl)
+	if pkg == nil {
+		return decls
+	}
+
+	addValues := func(values []*doc.Value) {
+		for _, v := range values {
+			kind := VarSymbolKind
+			if v.Decl.Tok == token.CONST {
+				kind = ConstSymbolKind
+			}
+
+			for _, s := range v.Decl.Specs {
+				spec := s.(*ast.ValueSpec)
+				decl := apiText(&ast.GenDecl{Tok: v.Decl.Tok, Specs: []ast.Spec{withoutSpecComments(spec)}})
+				for _, n := range spec.Names {
+					if ast.IsExported(n.Name) {
+						decls[n.Name] = &apiDecl{kind, fmt.Sprintf("%s %s", v.Decl.Tok, n.Name), decl}
+					}
+				}
+			}
+		}
+	}
+
+	addFuncs := func(funcs []*doc.Func) {
+		for _, fn := range funcs {
+			f := NewFunc(pkg.cfg, fn, nil)
+			kind := FuncSymbolKind
+			if fn.Recv != "" {
+				kind = MethodSymbolKind
+			}
+
+			sig := apiText(&ast.FuncDecl{Recv: fn.Decl.Recv, Name: fn.Decl.Name, Type: fn.Decl.Type})
+			decls[f.symbolName()] = &apiDecl{kind, sig, sig}
+		}
+	}
+
+	addValues(pkg.doc.Consts)
+	addValues(pkg.doc.Vars)
+	addFuncs(pkg.doc.Funcs)
+	for _, t := range pkg.doc.Types {
+		var decl string
+		for _, s := range t.Decl.Specs {
+			if spec, ok := s.(*ast.TypeSpec); ok && spec.Name.Name == t.Name {
+				decl = apiText(&ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{withoutSpecComments(spec)}})
+			}
+		}
+
+		decls[t.Name] = &apiDecl{TypeSymbolKind, fmt.Sprintf("type %s", t.Name), decl}
+		addValues(t.Consts)
+		addValues(t.Vars)
+		addFuncs(t.Funcs)
+		addFuncs(t.Methods)
+	}
+
+	return decls
+}
+
+// apiText prints the provided declaration in the canonical formatting used for
+// comparisons.
+func apiText(node ast.Node) string {
+	text, err := printNode(node, token.NewFileSet())
+	if err != nil {
+		return ""
+	}
+
+	return text
+}
+
+// withoutSpecComments provides a copy of the spec without any of the comments
+// within it, including the ones on the fields and methods of struct and
+// interface types, so that changes to documentation aren't reported as API
+// changes.
+func withoutSpecComments(spec ast.Spec) ast.Spec {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		c := *s
+		c.Doc, c.Comment = nil, nil
+		c.Type = withoutFieldComments(c.Type)
+		return &c
+	case *ast.ValueSpec:
+		c := *s
+		c.Doc, c.Comment = nil, nil
+		return &c
+	default:
+		return spec
+	}
+}
+
+func withoutFieldComments(expr ast.Expr) ast.Expr {
+	copyFields := func(list *ast.FieldList) *ast.FieldList {
+		if list == nil {
+			return nil
+		}
+
+		c := *list
+		c.List = make([]*ast.Field, len(list.List))
+		for i, f := range list.List {
+			field := *f
+			field.Doc, field.Comment = nil, nil
+			field.Type = withoutFieldComments(field.Type)
+			c.List[i] = &field
+		}
+
+		return &c
+	}
+
+	switch t := expr.(type) {
+	case *ast.StructType:
+		c := *t
+		c.Fields = copyFields(t.Fields)
+		return &c
+	case *ast.InterfaceType:
+		c := *t
+		c.Methods = copyFields(t.Methods)
+		return &c
+	default:
+		return expr
+	}
+}
@@ -0,0 +1,120 @@
+package lang_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/lang"
+	"github.com/princjef/gomarkdoc/logger"
+)
+
+func TestDiffPackages(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	is.NoErr(err)
+
+	release(t, repo, dir, "v1.0.0", false, map[string]string{
+		"go.mod": "module example.com/api\n",
+		"api.go": `package api
+
+// Limit is documented.
+const Limit = 10
+
+// Client is a client.
+type Client struct {
+	// Name is the name.
+	Name string
+}
+
+// Do does something.
+func (c *Client) Do(req string) error { return nil }
+
+// Close closes the client.
+func (c *Client) Close() {}
+`,
+	})
+	release(t, repo, dir, "v1.1.0", false, map[string]string{
+		"api.go": `package api
+
+// Limit is documented differently.
+const Limit = 10
+
+// Client is a client.
+type Client struct {
+	// Name is the name of the client.
+	Name string
+	Timeout int
+}
+
+// Do does something.
+func (c *Client) Do(req string, retries int) error { return nil }
+
+// NewClient creates a client.
+func NewClient() *Client { return nil }
+`,
+	})
+
+	// The working tree is not used for revisions
+	is.NoErr(os.WriteFile(filepath.Join(dir, "api.go"), []byte("package api\n\nfunc Uncommitted() {}\n"), 0644))
+
+	log := logger.New(logger.ErrorLevel)
+	from, err := lang.NewRevision(log, dir, "v1.0.0")
+	is.NoErr(err)
+
+	to, err := lang.NewRevision(log, dir, "HEAD")
+	is.NoErr(err)
+
+	dirs, err := to.PackageDirs(dir, true)
+	is.NoErr(err)
+	is.Equal(len(dirs), 1)
+
+	oldPkg, err := from.Package(dir)
+	is.NoErr(err)
+	is.Equal(oldPkg.ImportPath(), "example.com/api")
+
+	newPkg, err := to.Package(dir)
+	is.NoErr(err)
+
+	changes := lang.DiffPackages(oldPkg, newPkg)
+	is.Equal(len(changes), 4)
+
+	is.Equal(changes[0].Name(), "Client")
+	is.Equal(changes[0].Kind(), lang.ModifiedChange)
+	is.Equal(changes[0].Title(), "type Client")
+	is.Equal(changes[0].New(), "type Client struct {\n    Name    string\n    Timeout int\n}")
+
+	is.Equal(changes[1].Name(), "Client.Close")
+	is.Equal(changes[1].Kind(), lang.RemovedChange)
+	is.Equal(changes[1].SymbolKind(), lang.MethodSymbolKind)
+	is.Equal(changes[1].New(), "")
+
+	is.Equal(changes[2].Name(), "Client.Do")
+	is.Equal(changes[2].Kind(), lang.ModifiedChange)
+	is.Equal(changes[2].Old(), "func (c *Client) Do(req string) error")
+	is.Equal(changes[2].New(), "func (c *Client) Do(req string, retries int) error")
+
+	is.Equal(changes[3].Name(), "NewClient")
+	is.Equal(changes[3].Kind(), lang.AddedChange)
+	is.Equal(changes[3].Title(), "func NewClient() *Client")
+}
+
+func TestDiffPackages_newPackage(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/simple")
+	is.NoErr(err)
+
+	changes := lang.DiffPackages(nil, pkg)
+	is.True(len(changes) > 0)
+	for _, c := range changes {
+		is.Equal(c.Kind(), lang.AddedChange)
+		is.Equal(c.Old(), "")
+	}
+
+	is.Equal(len(lang.DiffPackages(pkg, pkg)), 0)
+}
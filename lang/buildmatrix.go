@@ -0,0 +1,343 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"path/filepath"
+	"strings"
+)
+
+type (
+	// BuildContext identifies a target platform and set of build tags under
+	// which a package can be built.
+	BuildContext struct {
+		GOOS   string
+		GOARCH string
+		Tags   []string
+	}
+
+	// BuildMatrix holds a set of build contexts under which packages are
+	// documented together. The documentation for a package includes the
+	// symbols available under any of the contexts, and symbols that are only
+	// available under some of them are annotated accordingly.
+	BuildMatrix struct {
+		contexts []BuildContext
+	}
+
+	// availability records which of the contexts of a build matrix include
+	// each of the files and symbols of a package.
+	availability struct {
+		matrix  *BuildMatrix
+		files   map[string][]bool
+		symbols map[string][]bool
+	}
+)
+
+// NewBuildMatrix creates a build matrix from the provided build contexts.
+func NewBuildMatrix(contexts ...BuildContext) *BuildMatrix {
+	return &BuildMatrix{contexts}
+}
+
+// ParseBuildMatrix creates a build matrix from a list of entries of the form
+// GOOS/GOARCH, optionally followed by a comma-separated list of build tags
+// (e.g. linux/amd64,enterprise), as used in gomarkdoc's configuration.
+func ParseBuildMatrix(entries []string) (*BuildMatrix, error) {
+	contexts := make([]BuildContext, len(entries))
+	for i, entry := range entries {
+		parts := strings.Split(entry, ",")
+		goos, goarch, ok := strings.Cut(parts[0], "/")
+		if !ok || goos == "" || goarch == "" {
+			return nil, fmt.Errorf("gomarkdoc: invalid build context %q, expected GOOS/GOARCH[,tag...]", entry)
+		}
+
+		var tags []string
+		for _, tag := range parts[1:] {
+			if tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+
+		contexts[i] = BuildContext{goos, goarch, tags}
+	}
+
+	return NewBuildMatrix(contexts...), nil
+}
+
+// Contexts lists the build contexts of the matrix.
+func (m *BuildMatrix) Contexts() []BuildContext {
+	return m.contexts
+}
+
+// String provides the representation of the build context used in gomarkdoc's
+// configuration, such as linux/amd64,enterprise.
+func (c BuildContext) String() string {
+	return strings.Join(append([]string{c.platform()}, c.Tags...), ",")
+}
+
+func (c BuildContext) platform() string {
+	return fmt.Sprintf("%s/%s", c.GOOS, c.GOARCH)
+}
+
+func (c BuildContext) hasTag(tag string) bool {
+	for _, t := range c.Tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchFile reports whether the file with the provided name in the provided
+// directory is included in the build under the context, taking both its name
+// and its build constraints into account.
+func (c BuildContext) matchFile(dir, name string) (bool, error) {
+	ctx := build.Default
+	ctx.GOOS = c.GOOS
+	ctx.GOARCH = c.GOARCH
+	ctx.BuildTags = c.Tags
+
+	return ctx.MatchFile(dir, name)
+}
+
+// newAvailability determines which of the non-test files of the package are
+// included under each of the contexts of the matrix, and which symbols are
+// declared in them.
+func newAvailability(cfg *Config, matrix *BuildMatrix) (*availability, error) {
+	a := &availability{
+		matrix:  matrix,
+		files:   make(map[string][]bool),
+		symbols: make(map[string][]bool),
+	}
+
+	for _, f := range cfg.Files {
+		filename := cfg.FileSet.Position(f.Package).Filename
+		if strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+
+		included := make([]bool, len(matrix.contexts))
+		var anyIncluded bool
+		for i, c := range matrix.contexts {
+			ok, err := c.matchFile(filepath.Dir(filename), filepath.Base(filename))
+			if err != nil {
+				return nil, fmt.Errorf("gomarkdoc: failed to match file %s: %w", filename, err)
+			}
+
+			included[i] = ok
+			anyIncluded = anyIncluded || ok
+		}
+
+		if !anyIncluded {
+			continue
+		}
+
+		a.files[absFilename(filename)] = included
+		for _, name := range declaredSymbols(f) {
+			a.add(name, included)
+		}
+	}
+
+	return a, nil
+}
+
+// fileNames lists the base names of the files included under any of the
+// contexts of the matrix.
+func (a *availability) fileNames() []string {
+	files := make([]string, 0, len(a.files))
+	for f := range a.files {
+		files = append(files, filepath.Base(f))
+	}
+
+	return files
+}
+
+func (a *availability) add(name string, included []bool) {
+	existing, ok := a.symbols[name]
+	if !ok {
+		existing = make([]bool, len(included))
+		a.symbols[name] = existing
+	}
+
+	for i, inc := range included {
+		existing[i] = existing[i] || inc
+	}
+}
+
+// symbol describes the contexts under which the symbol with the provided name
+// is available. See describe for details.
+func (a *availability) symbol(name string) string {
+	if a == nil {
+		return ""
+	}
+
+	return a.describe(a.symbols[name])
+}
+
+// node describes the contexts under which the file containing the provided
+// node is included. See describe for details.
+func (a *availability) node(cfg *Config, node ast.Node) string {
+	if a == nil {
+		return ""
+	}
+
+	return a.describe(a.files[absFilename(cfg.FileSet.Position(node.Pos()).Filename)])
+}
+
+// describe produces a short description of the subset of the matrix's contexts
+// identified by included, such as "linux, darwin only" or "requires tag:
+// enterprise". The description is based on the operating systems,
+// architectures, platforms or build tags that set the subset apart from the
+// rest of the contexts, in that order of preference. An empty string is
+// returned if the subset includes all of the contexts.
+func (a *availability) describe(included []bool) string {
+	if len(included) == 0 {
+		return ""
+	}
+
+	all := true
+	for _, inc := range included {
+		all = all && inc
+	}
+
+	if all {
+		return ""
+	}
+
+	contexts := a.matrix.contexts
+	for _, key := range []func(c BuildContext) string{
+		func(c BuildContext) string { return c.GOOS },
+		func(c BuildContext) string { return c.GOARCH },
+		BuildContext.platform,
+	} {
+		var values []string
+		seen := make(map[string]bool)
+		for i, c := range contexts {
+			if included[i] && !seen[key(c)] {
+				seen[key(c)] = true
+				values = append(values, key(c))
+			}
+		}
+
+		matches := true
+		for i, c := range contexts {
+			matches = matches && included[i] == seen[key(c)]
+		}
+
+		if matches {
+			return fmt.Sprintf("%s only", strings.Join(values, ", "))
+		}
+	}
+
+	if tags := a.requiredTags(included); len(tags) == 1 {
+		return fmt.Sprintf("requires tag: %s", tags[0])
+	} else if len(tags) > 1 {
+		return fmt.Sprintf("requires tags: %s", strings.Join(tags, ", "))
+	}
+
+	var names []string
+	for i, c := range contexts {
+		if included[i] {
+			names = append(names, c.String())
+		}
+	}
+
+	return fmt.Sprintf("%s only", strings.Join(names, "; "))
+}
+
+// requiredTags finds the build tags shared by all of the included contexts
+// that set them apart from the rest of the contexts. Nothing is returned if
+// the included contexts cannot be identified by their tags alone.
+func (a *availability) requiredTags(included []bool) []string {
+	contexts := a.matrix.contexts
+
+	var tags []string
+	for i, c := range contexts {
+		if !included[i] {
+			continue
+		}
+
+		for _, tag := range c.Tags {
+			// Tags used by every context don't set any of them apart
+			shared, everywhere := true, true
+			for j, other := range contexts {
+				shared = shared && (!included[j] || other.hasTag(tag))
+				everywhere = everywhere && other.hasTag(tag)
+			}
+
+			if shared && !everywhere && !containsString(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+
+		break
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	for i, c := range contexts {
+		hasAll := true
+		for _, tag := range tags {
+			hasAll = hasAll && c.hasTag(tag)
+		}
+
+		if hasAll != included[i] {
+			return nil
+		}
+	}
+
+	return tags
+}
+
+// declaredSymbols lists the names of the symbols declared at the top level of
+// the provided file. Methods are qualified by the name of their receiver's
+// type (e.g. Type.Method).
+func declaredSymbols(f *ast.File) []string {
+	var names []string
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				names = append(names, fmt.Sprintf("%s.%s", embeddedTypeName(d.Recv.List[0].Type), d.Name.Name))
+			} else {
+				names = append(names, d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, s := range d.Specs {
+				switch spec := s.(type) {
+				case *ast.TypeSpec:
+					names = append(names, spec.Name.Name)
+				case *ast.ValueSpec:
+					for _, n := range spec.Names {
+						names = append(names, n.Name)
+					}
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// absFilename provides the absolute form of the provided filename so that the
+// same file can be identified from different parses of the package.
+func absFilename(filename string) string {
+	if abs, err := filepath.Abs(filename); err == nil {
+		return abs
+	}
+
+	return filename
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
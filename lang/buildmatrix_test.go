@@ -0,0 +1,115 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/lang"
+	"github.com/princjef/gomarkdoc/logger"
+)
+
+func TestParseBuildMatrix(t *testing.T) {
+	is := is.New(t)
+
+	matrix, err := lang.ParseBuildMatrix([]string{"linux/amd64", "windows/arm64,enterprise,beta"})
+	is.NoErr(err)
+
+	contexts := matrix.Contexts()
+	is.Equal(len(contexts), 2)
+	is.Equal(contexts[0], lang.BuildContext{GOOS: "linux", GOARCH: "amd64"})
+	is.Equal(contexts[1], lang.BuildContext{GOOS: "windows", GOARCH: "arm64", Tags: []string{"enterprise", "beta"}})
+	is.Equal(contexts[1].String(), "windows/arm64,enterprise,beta")
+}
+
+func TestParseBuildMatrix_invalid(t *testing.T) {
+	is := is.New(t)
+
+	for _, entry := range []string{"linux", "/amd64", "linux/", ",enterprise"} {
+		_, err := lang.ParseBuildMatrix([]string{entry})
+		is.True(err != nil) // invalid entry accepted
+	}
+}
+
+func TestPackage_buildMatrix(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadMatrixPackage(
+		lang.BuildContext{GOOS: "linux", GOARCH: "amd64"},
+		lang.BuildContext{GOOS: "darwin", GOARCH: "arm64"},
+		lang.BuildContext{GOOS: "windows", GOARCH: "amd64"},
+		lang.BuildContext{GOOS: "linux", GOARCH: "amd64", Tags: []string{"enterprise"}},
+	)
+	is.NoErr(err)
+
+	types := make(map[string]string)
+	methods := make(map[string]string)
+	for _, typ := range pkg.Types() {
+		types[typ.Name()] = typ.Availability()
+		for _, m := range typ.Methods() {
+			methods[m.Name()] = m.Availability()
+		}
+	}
+
+	is.Equal(types, map[string]string{
+		"Conn":    "",
+		"License": "requires tag: enterprise",
+		"Socket":  "linux, darwin only",
+	})
+	is.Equal(methods, map[string]string{
+		"Close":  "",
+		"Fd":     "linux, darwin only",
+		"Handle": "windows only",
+	})
+
+	funcs := pkg.Funcs()
+	is.Equal(len(funcs), 1)
+	is.Equal(funcs[0].Name(), "Audit")
+	is.Equal(funcs[0].Availability(), "requires tag: enterprise")
+
+	// Each platform's declaration of the constant is documented
+	consts := pkg.Consts()
+	is.Equal(len(consts), 2)
+	is.Equal(consts[0].Availability(), "linux, darwin only")
+	is.Equal(consts[1].Availability(), "windows only")
+}
+
+func TestPackage_buildMatrixPlatforms(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadMatrixPackage(
+		lang.BuildContext{GOOS: "linux", GOARCH: "amd64"},
+		lang.BuildContext{GOOS: "linux", GOARCH: "arm64"},
+		lang.BuildContext{GOOS: "windows", GOARCH: "amd64"},
+		lang.BuildContext{GOOS: "windows", GOARCH: "arm64"},
+		lang.BuildContext{GOOS: "darwin", GOARCH: "arm64"},
+	)
+	is.NoErr(err)
+
+	typ, err := findType(pkg, "Socket")
+	is.NoErr(err)
+	is.Equal(typ.Availability(), "linux, darwin only")
+
+	_, err = findType(pkg, "License")
+	is.True(err != nil) // not available under any of the contexts
+}
+
+func TestPackage_noBuildMatrix(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/matrix")
+	is.NoErr(err)
+
+	for _, typ := range pkg.Types() {
+		is.Equal(typ.Availability(), "")
+	}
+}
+
+func loadMatrixPackage(contexts ...lang.BuildContext) (*lang.Package, error) {
+	buildPkg, err := getBuildPackage("../testData/matrix")
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.New(logger.ErrorLevel)
+	return lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithBuildMatrix(lang.NewBuildMatrix(contexts...)))
+}
@@ -0,0 +1,354 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+)
+
+// CheckCompatibility compares the exported API of two versions of a package
+// like DiffPackages and classifies each of the changes as compatible or
+// breaking for users of the package. Both versions are type checked so that
+// changes that don't affect the types of the package's symbols, such as
+// renamed parameters, are considered compatible. Removing a symbol, changing
+// the signature of a func or method, removing or changing an exported field
+// and adding a method to an interface are among the breaking changes. Either
+// package may be nil if the package didn't exist in one of the versions.
+//
+// Imports of the packages are type checked from source. For packages read
+// from a Revision, imports from the same module are read from the same
+// revision. Symbols whose types can't be fully resolved are compared by their
+// declarations instead.
+func CheckCompatibility(old, new *Package) []*APIChange {
+	changes := DiffPackages(old, new)
+	if len(changes) == 0 {
+		return changes
+	}
+
+	// Sharing the importer between the versions ensures that types from other
+	// modules are identical in both versions
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil)
+	oldTypes, newTypes := checkTypes(old, imp), checkTypes(new, imp)
+
+	for _, c := range changes {
+		switch c.kind {
+		case AddedChange:
+			// Adding a symbol never breaks existing users
+		case RemovedChange:
+			c.breaking = fmt.Sprintf("%s %s was removed", c.old.kind, c.name)
+		case ModifiedChange:
+			c.breaking = modifiedBreakage(c, lookupObject(oldTypes, c.name), lookupObject(newTypes, c.name))
+		}
+	}
+
+	return changes
+}
+
+// Breaking identifies whether the change breaks existing users of the package.
+// This is only known for changes provided by CheckCompatibility.
+func (c *APIChange) Breaking() bool {
+	return c.breaking != ""
+}
+
+// BreakingReason provides a short description of why the change breaks
+// existing users of the package, or an empty string if it doesn't.
+func (c *APIChange) BreakingReason() string {
+	return c.breaking
+}
+
+// checkTypes type checks the source files of the package. Errors are ignored,
+// as the type checker still resolves everything it can.
+func checkTypes(pkg *Package, imp types.Importer) *types.Package {
+	if pkg == nil {
+		return nil
+	}
+
+	include := make(map[string]bool)
+	for _, f := range pkg.cfg.sourceFiles {
+		include[f] = true
+	}
+
+	var files []*ast.File
+	for _, f := range pkg.cfg.Files {
+		if include[filepath.Base(pkg.cfg.FileSet.Position(f.Pos()).Filename)] && f.Name.Name == pkg.Name() {
+			files = append(files, f)
+		}
+	}
+
+	if r := pkg.cfg.revision; r != nil {
+		imp = r.importer(pkg.cfg.PkgDir, imp)
+	}
+
+	conf := types.Config{
+		Importer: imp,
+		Error: func(err error) {
+			pkg.cfg.Log.Debugf("type checking %s: %s", pkg.ImportPath(), err)
+		},
+	}
+
+	typesPkg, _ := conf.Check(pkg.ImportPath(), pkg.cfg.FileSet, files, nil)
+	return typesPkg
+}
+
+// lookupObject finds the object for the symbol with the provided name, which
+// is qualified by the type of its receiver for methods (e.g. Type.Method).
+func lookupObject(pkg *types.Package, name string) types.Object {
+	if pkg == nil {
+		return nil
+	}
+
+	recv, method, ok := strings.Cut(name, ".")
+	if !ok {
+		return pkg.Scope().Lookup(name)
+	}
+
+	tn, ok := pkg.Scope().Lookup(recv).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+
+	named, ok := tn.Type().(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	for i := 0; i < named.NumMethods(); i++ {
+		if m := named.Method(i); m.Name() == method {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// modifiedBreakage describes why the change to a symbol's declaration breaks
+// existing users, or provides an empty string if it doesn't. If the symbol's
+// type couldn't be resolved in either version, the change is considered
+// breaking because the declarations differ.
+func modifiedBreakage(c *APIChange, oldObj, newObj types.Object) string {
+	if c.old.kind != c.new.kind {
+		return fmt.Sprintf("%s was changed from a %s to a %s", c.name, c.old.kind, c.new.kind)
+	}
+
+	if !resolved(oldObj) || !resolved(newObj) {
+		return fmt.Sprintf("declaration of %s %s changed", c.new.kind, c.name)
+	}
+
+	oldKey, newKey := typeKey{oldObj.Pkg()}, typeKey{newObj.Pkg()}
+	switch o := oldObj.(type) {
+	case *types.Func:
+		if oldKey.of(o.Type()) != newKey.of(newObj.Type()) {
+			return fmt.Sprintf("signature of %s %s changed", c.new.kind, c.name)
+		}
+
+		// Methods with pointer receivers aren't in the method set of values
+		if !pointerRecv(o) && pointerRecv(newObj.(*types.Func)) {
+			return fmt.Sprintf("receiver of method %s changed to a pointer", c.name)
+		}
+	case *types.Const:
+		n := newObj.(*types.Const)
+		if oldKey.of(o.Type()) != newKey.of(n.Type()) {
+			return fmt.Sprintf("type of const %s changed", c.name)
+		}
+
+		if o.Val().ExactString() != n.Val().ExactString() {
+			return fmt.Sprintf("value of const %s changed", c.name)
+		}
+	case *types.Var:
+		if oldKey.of(o.Type()) != newKey.of(newObj.Type()) {
+			return fmt.Sprintf("type of var %s changed", c.name)
+		}
+	case *types.TypeName:
+		return typeBreakage(c.name, o, newObj.(*types.TypeName), oldKey, newKey)
+	}
+
+	return ""
+}
+
+// typeBreakage describes why the change to a type declaration breaks existing
+// users, or provides an empty string if it doesn't. Fields may be added to
+// structs, but exported fields may not be removed or changed. Interfaces that
+// can be implemented outside of the package can't gain or lose methods.
+func typeBreakage(name string, oldTn, newTn *types.TypeName, oldKey, newKey typeKey) string {
+	if oldTn.IsAlias() != newTn.IsAlias() {
+		return fmt.Sprintf("type %s was changed between an alias and a defined type", name)
+	}
+
+	if oldTn.IsAlias() {
+		if oldKey.of(oldTn.Type()) != newKey.of(newTn.Type()) {
+			return fmt.Sprintf("type alias %s refers to a different type", name)
+		}
+
+		return ""
+	}
+
+	oldNamed, ok1 := oldTn.Type().(*types.Named)
+	newNamed, ok2 := newTn.Type().(*types.Named)
+	if !ok1 || !ok2 {
+		return ""
+	}
+
+	if oldKey.typeParams(oldNamed.TypeParams()) != newKey.typeParams(newNamed.TypeParams()) {
+		return fmt.Sprintf("type parameters of type %s changed", name)
+	}
+
+	switch o := oldNamed.Underlying().(type) {
+	case *types.Struct:
+		n, ok := newNamed.Underlying().(*types.Struct)
+		if !ok {
+			return fmt.Sprintf("type %s is no longer a struct", name)
+		}
+
+		fields := make(map[string]*types.Var)
+		for i := 0; i < n.NumFields(); i++ {
+			fields[n.Field(i).Name()] = n.Field(i)
+		}
+
+		for i := 0; i < o.NumFields(); i++ {
+			f := o.Field(i)
+			if !f.Exported() {
+				continue
+			}
+
+			nf, ok := fields[f.Name()]
+			switch {
+			case !ok || !nf.Exported():
+				return fmt.Sprintf("field %s.%s was removed", name, f.Name())
+			case oldKey.of(f.Type()) != newKey.of(nf.Type()) || f.Embedded() != nf.Embedded():
+				return fmt.Sprintf("type of field %s.%s changed", name, f.Name())
+			}
+		}
+	case *types.Interface:
+		n, ok := newNamed.Underlying().(*types.Interface)
+		if !ok {
+			return fmt.Sprintf("type %s is no longer an interface", name)
+		}
+
+		methods := make(map[string]*types.Func)
+		for i := 0; i < o.NumMethods(); i++ {
+			methods[o.Method(i).Name()] = o.Method(i)
+		}
+
+		sealed := false
+		for i := 0; i < o.NumMethods(); i++ {
+			m := o.Method(i)
+			if !m.Exported() {
+				sealed = true
+				continue
+			}
+
+			nm := lookupMethod(n, m.Name())
+			switch {
+			case nm == nil:
+				return fmt.Sprintf("method %s.%s was removed", name, m.Name())
+			case oldKey.of(m.Type()) != newKey.of(nm.Type()):
+				return fmt.Sprintf("signature of method %s.%s changed", name, m.Name())
+			}
+		}
+
+		// Interfaces with unexported methods can only be implemented within
+		// the package, so they can safely gain methods
+		for i := 0; i < n.NumMethods() && !sealed; i++ {
+			if m := n.Method(i); m.Exported() && methods[m.Name()] == nil {
+				return fmt.Sprintf("method %s.%s was added to an interface", name, m.Name())
+			}
+		}
+	default:
+		if oldKey.of(o) != newKey.of(newNamed.Underlying()) {
+			return fmt.Sprintf("underlying type of type %s changed", name)
+		}
+	}
+
+	return ""
+}
+
+func pointerRecv(fn *types.Func) bool {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+
+	_, ok := recv.Type().(*types.Pointer)
+	return ok
+}
+
+func lookupMethod(iface *types.Interface, name string) *types.Func {
+	for i := 0; i < iface.NumMethods(); i++ {
+		if m := iface.Method(i); m.Name() == name {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// resolved identifies whether the type of the object, including the underlying
+// type for type names, was fully resolved by the type checker.
+func resolved(obj types.Object) bool {
+	if obj == nil {
+		return false
+	}
+
+	text := types.TypeString(obj.Type(), nil)
+	if _, ok := obj.(*types.TypeName); ok {
+		text = types.TypeString(obj.Type().Underlying(), nil)
+	}
+
+	return !strings.Contains(text, "invalid type")
+}
+
+// typeKey produces text representations of types that can be compared across
+// versions of a package. Types from the package itself are unqualified, since
+// the package's import path changes with its major version, and the names of
+// parameters and results are left out.
+type typeKey struct {
+	pkg *types.Package
+}
+
+func (k typeKey) of(t types.Type) string {
+	if sig, ok := t.(*types.Signature); ok {
+		return k.signature(sig)
+	}
+
+	return types.TypeString(t, types.RelativeTo(k.pkg))
+}
+
+func (k typeKey) signature(sig *types.Signature) string {
+	tuple := func(t *types.Tuple, variadic bool) string {
+		parts := make([]string, t.Len())
+		for i := range parts {
+			typ := t.At(i).Type()
+			if variadic && i == t.Len()-1 {
+				parts[i] = "..." + k.of(typ.(*types.Slice).Elem())
+			} else {
+				parts[i] = k.of(typ)
+			}
+		}
+
+		return strings.Join(parts, ", ")
+	}
+
+	return fmt.Sprintf(
+		"func%s(%s) (%s)",
+		k.typeParams(sig.TypeParams()),
+		tuple(sig.Params(), sig.Variadic()),
+		tuple(sig.Results(), false),
+	)
+}
+
+func (k typeKey) typeParams(params *types.TypeParamList) string {
+	if params.Len() == 0 {
+		return ""
+	}
+
+	parts := make([]string, params.Len())
+	for i := range parts {
+		parts[i] = k.of(params.At(i).Constraint())
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(parts, ", "))
+}
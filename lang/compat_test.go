@@ -0,0 +1,192 @@
+package lang_test
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/lang"
+	"github.com/princjef/gomarkdoc/logger"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		breaking string
+	}{
+		{
+			name: "renamed parameter",
+			old:  "func Run(name string) error { return nil }",
+			new:  "func Run(n string) error { return nil }",
+		},
+		{
+			name:     "changed parameter type",
+			old:      "func Run(name string) error { return nil }",
+			new:      "func Run(name []byte) error { return nil }",
+			breaking: "signature of func Run changed",
+		},
+		{
+			name:     "changed imported parameter type",
+			old:      "import \"context\"\n\nfunc Run(ctx context.Context) {}",
+			new:      "import \"io\"\n\nfunc Run(r io.Reader) {}",
+			breaking: "signature of func Run changed",
+		},
+		{
+			name:     "removed func",
+			old:      "func Run() {}\n\nfunc Stop() {}",
+			new:      "func Run() {}",
+			breaking: "func Stop was removed",
+		},
+		{
+			name: "added func",
+			old:  "func Run() {}",
+			new:  "func Run() {}\n\nfunc Stop() {}",
+		},
+		{
+			name:     "added interface method",
+			old:      "type Runner interface {\n\tRun()\n}",
+			new:      "type Runner interface {\n\tRun()\n\tStop()\n}",
+			breaking: "method Runner.Stop was added to an interface",
+		},
+		{
+			name: "added sealed interface method",
+			old:  "type Runner interface {\n\tRun()\n\tsealed()\n}",
+			new:  "type Runner interface {\n\tRun()\n\tStop()\n\tsealed()\n}",
+		},
+		{
+			name: "added field",
+			old:  "type Config struct {\n\tName string\n}",
+			new:  "type Config struct {\n\tName string\n\tTimeout int\n}",
+		},
+		{
+			name:     "removed field",
+			old:      "type Config struct {\n\tName string\n\tTimeout int\n}",
+			new:      "type Config struct {\n\tName string\n}",
+			breaking: "field Config.Timeout was removed",
+		},
+		{
+			name:     "changed const value",
+			old:      "const Limit = 10",
+			new:      "const Limit = 20",
+			breaking: "value of const Limit changed",
+		},
+		{
+			name: "changed var value",
+			old:  "var Limit = 10",
+			new:  "var Limit = 10 * 2",
+		},
+		{
+			name:     "pointer receiver",
+			old:      "type Client struct{}\n\nfunc (c Client) Run() {}",
+			new:      "type Client struct{}\n\nfunc (c *Client) Run() {}",
+			breaking: "receiver of method Client.Run changed to a pointer",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			changes := lang.CheckCompatibility(compatPackage(t, test.old), compatPackage(t, test.new))
+			is.True(len(changes) > 0)
+
+			var breaking string
+			for _, c := range changes {
+				if c.Breaking() {
+					is.Equal(breaking, "")
+					breaking = c.BreakingReason()
+				}
+			}
+
+			is.Equal(breaking, test.breaking)
+		})
+	}
+}
+
+func TestCheckCompatibility_newPackage(t *testing.T) {
+	is := is.New(t)
+
+	pkg := compatPackage(t, "func Run() {}")
+
+	changes := lang.CheckCompatibility(nil, pkg)
+	is.Equal(len(changes), 1)
+	is.True(!changes[0].Breaking())
+
+	changes = lang.CheckCompatibility(pkg, nil)
+	is.Equal(len(changes), 1)
+	is.Equal(changes[0].BreakingReason(), "func Run was removed")
+}
+
+func TestCheckCompatibility_revisionImports(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	is.NoErr(err)
+
+	release(t, repo, dir, "v1.0.0", false, map[string]string{
+		"go.mod":         "module example.com/api\n",
+		"model/model.go": "package model\n\n// ID identifies a record.\ntype ID int\n",
+		"api.go": `package api
+
+import "example.com/api/model"
+
+// Get gets a record.
+func Get(id model.ID) error { return nil }
+`,
+	})
+	release(t, repo, dir, "v1.1.0", false, map[string]string{
+		"api.go": `package api
+
+import "example.com/api/model"
+
+// Get gets a record.
+func Get(key model.ID) error { return nil }
+`,
+	})
+
+	// Imports from the module are read from the revisions rather than the
+	// working tree
+	is.NoErr(os.RemoveAll(filepath.Join(dir, "model")))
+
+	log := logger.New(logger.ErrorLevel)
+	from, err := lang.NewRevision(log, dir, "v1.0.0")
+	is.NoErr(err)
+
+	to, err := lang.NewRevision(log, dir, "v1.1.0")
+	is.NoErr(err)
+
+	oldPkg, err := from.Package(dir)
+	is.NoErr(err)
+
+	newPkg, err := to.Package(dir)
+	is.NoErr(err)
+
+	changes := lang.CheckCompatibility(oldPkg, newPkg)
+	is.Equal(len(changes), 1)
+	is.Equal(changes[0].Name(), "Get")
+	is.Equal(changes[0].BreakingReason(), "")
+}
+
+// compatPackage loads a package with the provided declarations from a
+// temporary module.
+func compatPackage(t *testing.T, decls string) *lang.Package {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/compat\n"), 0644))
+	is.NoErr(os.WriteFile(filepath.Join(dir, "compat.go"), []byte("package compat\n\n"+decls+"\n"), 0644))
+
+	buildPkg, err := build.ImportDir(dir, build.ImportComment)
+	is.NoErr(err)
+
+	pkg, err := lang.NewPackageFromBuild(logger.New(logger.ErrorLevel), buildPkg)
+	is.NoErr(err)
+
+	return pkg
+}
@@ -23,15 +23,30 @@ type (
 	// Config defines contextual information used to resolve documentation for
 	// a construct.
 	Config struct {
-		FileSet *token.FileSet
-		Files   []*ast.File
-		Level   int
-		Repo    *Repo
-		PkgDir  string
-		WorkDir string
-		Symbols map[string]Symbol
-		Pkg     *doc.Package
-		Log     logger.Logger
+		FileSet     *token.FileSet
+		Files       []*ast.File
+		Level       int
+		Repo        *Repo
+		PkgDir      string
+		WorkDir     string
+		Symbols     map[string]Symbol
+		Pkg         *doc.Package
+		TypeInfo    *TypeInfo
+		SymbolTable *SymbolTable
+		OutputFile  string
+		DocLinks    *DocLinks
+		Log         logger.Logger
+
+		availability *availability
+		directives   map[string]directives
+		order        SymbolOrder
+		since        map[string]string
+		sourceFiles  []string
+		linkReport   *LinkReport
+		docLocation  *Location
+		docComment   *ast.CommentGroup
+		revision     *Revision
+		declared     map[string]bool
 	}
 
 	// Repo represents information about a repository relevant to documentation
@@ -40,6 +55,7 @@ type (
 		Remote        string
 		DefaultBranch string
 		PathFromRoot  string
+		Host          RepoHost
 	}
 
 	// Location holds information for identifying a position within a file and
@@ -117,21 +133,49 @@ func NewConfig(log logger.Logger, workDir string, pkgDir string, opts ...ConfigO
 		log.Debugf("skipping repository resolution because all values have manual overrides")
 	}
 
+	if cfg.Repo.Host == nil {
+		cfg.Repo.Host = detectRepoHost(cfg.Repo.Remote)
+		if cfg.Repo.Host == nil {
+			// Self-managed servers can't be told apart by their hostname, so
+			// the fallback to the format's scheme may well be wrong for them
+			log.Infof(
+				"unable to detect the host of repository %s. Links to the source code use the scheme of the "+
+					"selected format unless the host is set explicitly (e.g. with --repository.host)",
+				cfg.Repo.Remote,
+			)
+		}
+	}
+
 	return cfg, nil
 }
 
 // Inc copies the Config and increments the level by the provided step.
 func (c *Config) Inc(step int) *Config {
 	return &Config{
-		FileSet: c.FileSet,
-		Files:   c.Files,
-		Level:   c.Level + step,
-		PkgDir:  c.PkgDir,
-		WorkDir: c.WorkDir,
-		Repo:    c.Repo,
-		Symbols: c.Symbols,
-		Pkg:     c.Pkg,
-		Log:     c.Log,
+		FileSet:     c.FileSet,
+		Files:       c.Files,
+		Level:       c.Level + step,
+		PkgDir:      c.PkgDir,
+		WorkDir:     c.WorkDir,
+		Repo:        c.Repo,
+		Symbols:     c.Symbols,
+		Pkg:         c.Pkg,
+		TypeInfo:    c.TypeInfo,
+		SymbolTable: c.SymbolTable,
+		OutputFile:  c.OutputFile,
+		DocLinks:    c.DocLinks,
+		Log:         c.Log,
+
+		availability: c.availability,
+		directives:   c.directives,
+		order:        c.order,
+		since:        c.since,
+		sourceFiles:  c.sourceFiles,
+		linkReport:   c.linkReport,
+		docLocation:  c.docLocation,
+		docComment:   c.docComment,
+		revision:     c.revision,
+		declared:     c.declared,
 	}
 }
 
@@ -290,12 +334,29 @@ func processRemote(log logger.Logger, repository *git.Repository, remote *git.Re
 
 var (
 	sshRemoteRegex       = regexp.MustCompile(`^[\w-]+@([^:]+):(.+?)(?:\.git)?$`)
-	httpsRemoteRegex     = regexp.MustCompile(`^(https?://)(?:[^@/]+@)?([\w-.]+)(/.+?)?(?:\.git)?$`)
+	sshURLRemoteRegex    = regexp.MustCompile(`^ssh://(?:[^@/]+@)?([\w-.]+)(?::(\d+))?/(.+?)(?:\.git)?/?$`)
+	httpsRemoteRegex     = regexp.MustCompile(`^(https?://)(?:[^@/]+@)?([\w-.]+(?::\d+)?)(/.+?)?(?:\.git)?/?$`)
 	devOpsSSHV3PathRegex = regexp.MustCompile(`^v3/([^/]+)/([^/]+)/([^/]+)$`)
 	devOpsHTTPSPathRegex = regexp.MustCompile(`^/([^/]+)/([^/]+)/_git/([^/]+)$`)
+	bitbucketSCMRegex    = regexp.MustCompile(`^(.*)/scm/([^/]+)/([^/]+)$`)
 )
 
+// bitbucketServerSSHPort is the port Bitbucket Server listens on for SSH by
+// default, which identifies its remotes.
+const bitbucketServerSSHPort = "7999"
+
 func normalizeRemote(remote string) (string, bool) {
+	// SSH remotes in URL form (e.g. ssh://git@gitlab.example.com:2222/repo.git),
+	// which self-hosted servers commonly use to listen on a non-standard port.
+	// The port is only used for SSH, so it doesn't carry over to the web URL.
+	if match := sshURLRemoteRegex.FindStringSubmatch(remote); match != nil {
+		if match[2] == bitbucketServerSSHPort {
+			return bitbucketServerRemote(fmt.Sprintf("https://%s", match[1]), match[3])
+		}
+
+		remote = fmt.Sprintf("git@%s:%s", match[1], match[3])
+	}
+
 	if match := sshRemoteRegex.FindStringSubmatch(remote); match != nil {
 		switch match[1] {
 		case "ssh.dev.azure.com", "vs-ssh.visualstudio.com":
@@ -345,6 +406,13 @@ func normalizeRemote(remote string) (string, bool) {
 			}
 
 			return "", false
+		case bitbucketSCMRegex.MatchString(match[3]):
+			// Bitbucket Server, which serves its repositories under /scm
+			pathMatch := bitbucketSCMRegex.FindStringSubmatch(match[3])
+			return bitbucketServerRemote(
+				fmt.Sprintf("%s%s%s", match[1], match[2], pathMatch[1]),
+				fmt.Sprintf("%s/%s", pathMatch[2], pathMatch[3]),
+			)
 		default:
 			// GitHub and friends
 			return fmt.Sprintf("%s%s%s", match[1], match[2], match[3]), true
@@ -355,6 +423,22 @@ func normalizeRemote(remote string) (string, bool) {
 	return "", false
 }
 
+// bitbucketServerRemote converts the provided path of a repository on a
+// Bitbucket Server, which is either project/repo or ~user/repo, into the URL
+// for browsing the repository under the provided base URL.
+func bitbucketServerRemote(base, p string) (string, bool) {
+	parts := strings.Split(p, "/")
+	if len(parts) != 2 {
+		return "", false
+	}
+
+	if user, ok := strings.CutPrefix(parts[0], "~"); ok {
+		return fmt.Sprintf("%s/users/%s/repos/%s", base, user, parts[1]), true
+	}
+
+	return fmt.Sprintf("%s/projects/%s/repos/%s", base, parts[0], parts[1]), true
+}
+
 // NewLocation returns a location for the provided Config and ast.Node
 // combination. This is typically not called directly, but is made available via
 // the Location() methods of various lang constructs.
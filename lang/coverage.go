@@ -0,0 +1,183 @@
+package lang
+
+import (
+	"go/ast"
+	"go/doc"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Coverage counts the exported symbols of a single kind in a package and how
+// thoroughly they are documented.
+type Coverage struct {
+	// Kind is the kind of the symbols. Methods include the methods of
+	// interface types.
+	Kind SymbolKind
+
+	// Symbols is the number of exported symbols of the kind.
+	Symbols int
+
+	// Undocumented is the number of symbols without a doc comment.
+	Undocumented int
+
+	// MissingExamples is the number of funcs, types and methods without any
+	// examples. It is always zero for the other kinds of symbols, which can't
+	// have examples.
+	MissingExamples int
+
+	// Misnamed is the number of documented symbols whose doc comment doesn't
+	// start with the name of the symbol, optionally preceded by "A", "An" or
+	// "The". Comments shared by a group of consts or vars are not counted.
+	Misnamed int
+}
+
+// Coverage counts the exported symbols of the package, including the fields
+// and methods of exported types, and how thoroughly they are documented. The
+// counts are split by the kind of symbol and ordered by kind. Kinds without any
+// symbols are left out.
+func (pkg *Package) Coverage() []Coverage {
+	counts := make(map[SymbolKind]*Coverage)
+	add := func(kind SymbolKind, name, text string, examples int, canHaveExamples bool) {
+		c, ok := counts[kind]
+		if !ok {
+			c = &Coverage{Kind: kind}
+			counts[kind] = c
+		}
+
+		c.Symbols++
+		if canHaveExamples && examples == 0 {
+			c.MissingExamples++
+		}
+
+		switch {
+		case strings.TrimSpace(text) == "":
+			c.Undocumented++
+		case name != "" && !docStartsWithName(text, name):
+			c.Misnamed++
+		}
+	}
+
+	addValues := func(kind SymbolKind, values []*doc.Value) {
+		for _, v := range values {
+			for _, s := range v.Decl.Specs {
+				spec := s.(*ast.ValueSpec)
+				for _, n := range spec.Names {
+					if !ast.IsExported(n.Name) {
+						continue
+					}
+
+					switch {
+					case spec.Doc != nil || spec.Comment != nil:
+						add(kind, n.Name, fieldOrSpecText(spec.Doc, spec.Comment), 0, false)
+					case len(v.Decl.Specs) == 1 && len(spec.Names) == 1:
+						add(kind, n.Name, v.Doc, 0, false)
+					default:
+						// The doc comment describes the whole group
+						add(kind, "", v.Doc, 0, false)
+					}
+				}
+			}
+		}
+	}
+
+	addFuncs := func(kind SymbolKind, funcs []*Func) {
+		for _, fn := range funcs {
+			if ast.IsExported(fn.doc.Name) {
+				add(kind, fn.doc.Name, fn.doc.Doc, len(fn.Examples()), true)
+			}
+		}
+	}
+
+	addValues(ConstSymbolKind, pkg.doc.Consts)
+	addValues(VarSymbolKind, pkg.doc.Vars)
+	addFuncs(FuncSymbolKind, pkg.Funcs())
+
+	for _, typ := range pkg.Types() {
+		addValues(ConstSymbolKind, typ.doc.Consts)
+		addValues(VarSymbolKind, typ.doc.Vars)
+		addFuncs(FuncSymbolKind, typ.Funcs())
+
+		if !ast.IsExported(typ.doc.Name) {
+			continue
+		}
+
+		add(TypeSymbolKind, typ.doc.Name, typ.doc.Doc, len(typ.Examples()), true)
+		addFuncs(MethodSymbolKind, typ.Methods())
+
+		for _, f := range structFields(typ.doc) {
+			for _, name := range fieldNames(f) {
+				if ast.IsExported(name) {
+					add(FieldSymbolKind, name, fieldText(f), 0, false)
+				}
+			}
+		}
+
+		if spec := typ.spec(); spec != nil {
+			if iface, ok := spec.Type.(*ast.InterfaceType); ok && iface.Methods != nil {
+				for _, m := range iface.Methods.List {
+					// Embedded interfaces and type constraints have no names
+					for _, n := range m.Names {
+						if ast.IsExported(n.Name) {
+							add(MethodSymbolKind, n.Name, fieldText(m), 0, false)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	var res []Coverage
+	for kind := TypeSymbolKind; kind <= FieldSymbolKind; kind++ {
+		if c, ok := counts[kind]; ok {
+			res = append(res, *c)
+		}
+	}
+
+	return res
+}
+
+// Documented provides the number of symbols with a doc comment.
+func (c Coverage) Documented() int {
+	return c.Symbols - c.Undocumented
+}
+
+// Percent provides the percentage of the symbols that have a doc comment. If
+// there are no symbols, they are all considered documented.
+func (c Coverage) Percent() float64 {
+	if c.Symbols == 0 {
+		return 100
+	}
+
+	return float64(c.Documented()) * 100 / float64(c.Symbols)
+}
+
+// fieldOrSpecText provides the text of the doc comment above a field or spec,
+// falling back to the comment on the same line.
+func fieldOrSpecText(doc, comment *ast.CommentGroup) string {
+	if doc != nil {
+		return doc.Text()
+	}
+
+	return comment.Text()
+}
+
+// docStartsWithName identifies whether the documentation text starts with the
+// provided name as a whole word, optionally preceded by an article.
+func docStartsWithName(text, name string) bool {
+	text = strings.TrimSpace(text)
+	for _, article := range []string{"A ", "An ", "The "} {
+		if rest := strings.TrimPrefix(text, article); rest != text && strings.HasPrefix(rest, name) {
+			text = rest
+			break
+		}
+	}
+
+	rest := strings.TrimPrefix(text, name)
+	if rest == text {
+		return false
+	}
+
+	r, _ := utf8.DecodeRuneInString(rest)
+	return rest == "" || !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
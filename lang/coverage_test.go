@@ -0,0 +1,32 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+func TestPackage_Coverage(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/coverage")
+	is.NoErr(err)
+
+	is.Equal(pkg.Coverage(), []lang.Coverage{
+		{Kind: lang.TypeSymbolKind, Symbols: 2, MissingExamples: 2},
+		{Kind: lang.FuncSymbolKind, Symbols: 2, Undocumented: 1, MissingExamples: 1},
+		{Kind: lang.ConstSymbolKind, Symbols: 4, Undocumented: 1},
+		{Kind: lang.VarSymbolKind, Symbols: 1, Misnamed: 1},
+		{Kind: lang.MethodSymbolKind, Symbols: 4, Undocumented: 2, MissingExamples: 1},
+		{Kind: lang.FieldSymbolKind, Symbols: 2, Undocumented: 1},
+	})
+}
+
+func TestCoverage_Percent(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(lang.Coverage{Symbols: 4, Undocumented: 1}.Percent(), 75.0)
+	is.Equal(lang.Coverage{Symbols: 4, Undocumented: 1}.Documented(), 3)
+	is.Equal(lang.Coverage{}.Percent(), 100.0)
+}
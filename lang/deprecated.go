@@ -0,0 +1,179 @@
+package lang
+
+import (
+	"go/ast"
+	"go/doc"
+	"go/token"
+	"strings"
+)
+
+const deprecatedPrefix = "Deprecated: "
+
+// deprecationNotice finds the deprecation notice in the provided documentation
+// text. Following the Go convention, a symbol is deprecated if a paragraph of
+// its documentation begins with "Deprecated: ". The notice is the remainder of
+// that paragraph, joined into a single line. The second return value reports
+// whether a notice was found.
+func deprecationNotice(text string) (string, bool) {
+	for _, paragraph := range strings.Split(normalizeDoc(text), "\n\n") {
+		paragraph = formatDocParagraph(paragraph)
+		if strings.HasPrefix(paragraph, deprecatedPrefix) {
+			return strings.TrimSpace(paragraph[len(deprecatedPrefix):]), true
+		}
+	}
+
+	return "", false
+}
+
+func isDeprecated(text string) bool {
+	_, ok := deprecationNotice(text)
+	return ok
+}
+
+// removeDeprecated removes the deprecated symbols from the documentation for
+// the package. Removing a deprecated type also removes its methods, while the
+// funcs and values associated with it are moved to the package level and kept
+// unless they're deprecated themselves. Deprecated fields are removed from the
+// declarations of struct types.
+func removeDeprecated(pkg *doc.Package) {
+	var moved bool
+	types := pkg.Types[:0]
+	for _, t := range pkg.Types {
+		if isDeprecated(t.Doc) {
+			pkg.Funcs = append(pkg.Funcs, t.Funcs...)
+			pkg.Consts = append(pkg.Consts, t.Consts...)
+			pkg.Vars = append(pkg.Vars, t.Vars...)
+			moved = true
+			continue
+		}
+
+		t.Consts = removeDeprecatedValues(t.Consts)
+		t.Vars = removeDeprecatedValues(t.Vars)
+		t.Funcs = removeDeprecatedFuncs(t.Funcs)
+		t.Methods = removeDeprecatedFuncs(t.Methods)
+		removeDeprecatedFields(t)
+		types = append(types, t)
+	}
+
+	pkg.Types = types
+	pkg.Consts = removeDeprecatedValues(pkg.Consts)
+	pkg.Vars = removeDeprecatedValues(pkg.Vars)
+	pkg.Funcs = removeDeprecatedFuncs(pkg.Funcs)
+
+	if moved {
+		sortPackageSymbols(pkg)
+	}
+}
+
+func removeDeprecatedFields(t *doc.Type) {
+	for _, s := range t.Decl.Specs {
+		spec, ok := s.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok || st.Fields == nil {
+			continue
+		}
+
+		fields := st.Fields.List[:0]
+		for _, f := range st.Fields.List {
+			if isDeprecated(fieldText(f)) {
+				// Let the printer note that fields were left out
+				st.Incomplete = true
+				continue
+			}
+
+			fields = append(fields, f)
+		}
+
+		st.Fields.List = fields
+	}
+}
+
+// removeDeprecatedValues removes the deprecated const or var declarations. A
+// declaration is removed if its documentation marks it as deprecated, while
+// the specs of a grouped declaration are removed if their own documentation
+// does.
+func removeDeprecatedValues(values []*doc.Value) []*doc.Value {
+	res := values[:0]
+	for _, v := range values {
+		if !isDeprecated(v.Doc) && removeDeprecatedSpecs(v) {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+// removeDeprecatedSpecs removes the specs of the declaration whose own
+// documentation marks them as deprecated, and reports whether any specs are
+// left.
+func removeDeprecatedSpecs(v *doc.Value) bool {
+	var (
+		prevType ast.Expr
+		removed  bool
+	)
+	specs := v.Decl.Specs[:0]
+	for _, s := range v.Decl.Specs {
+		spec, ok := s.(*ast.ValueSpec)
+		if !ok {
+			specs = append(specs, s)
+			continue
+		}
+
+		// A const spec without a type or values repeats the one before it, so
+		// it needs the type of that spec if the spec is removed
+		if v.Decl.Tok == token.CONST && spec.Type == nil && len(spec.Values) == 0 && prevType != nil {
+			spec.Type = prevType
+		}
+
+		if spec.Doc != nil && isDeprecated(spec.Doc.Text()) {
+			prevType = spec.Type
+			removed = true
+			continue
+		}
+
+		prevType = nil
+		specs = append(specs, spec)
+	}
+
+	v.Decl.Specs = specs
+	if removed {
+		v.Names = valueSpecNames(specs)
+	}
+
+	return len(specs) > 0
+}
+
+// valueSpecNames provides the names declared by the provided specs, leaving out
+// the blank identifier.
+func valueSpecNames(specs []ast.Spec) []string {
+	var names []string
+	for _, s := range specs {
+		spec, ok := s.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+
+		for _, n := range spec.Names {
+			if n.Name != "_" {
+				names = append(names, n.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+func removeDeprecatedFuncs(funcs []*doc.Func) []*doc.Func {
+	res := funcs[:0]
+	for _, fn := range funcs {
+		if !isDeprecated(fn.Doc) {
+			res = append(res, fn)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,229 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// directivePrefix is the prefix of the comment directives that control how
+// gomarkdoc documents the declarations they are attached to. Like other Go
+// directives (e.g. //go:generate), they are written without a space after the
+// slashes, which leaves them out of the text of the doc comment.
+const directivePrefix = "//gomarkdoc:"
+
+// directives holds the settings from the gomarkdoc comment directives on a
+// single declaration.
+type directives struct {
+	hide        bool
+	group       string
+	order       int
+	exampleOnly bool
+}
+
+// newDirectives reads the gomarkdoc comment directives from the declarations
+// in the files of the package with the provided name. Directives are keyed by
+// the name of the symbol they apply to, qualified by the receiver's type for
+// methods and fields (e.g. Type.Method). The directives on a const or var
+// declaration apply to all of the names it declares.
+func newDirectives(cfg *Config, name string, files []string) map[string]directives {
+	res := make(map[string]directives)
+	add := func(symbol string, groups ...*ast.CommentGroup) {
+		d := res[symbol]
+		for _, group := range groups {
+			parseDirectives(cfg, group, &d)
+		}
+
+		if d != (directives{}) {
+			res[symbol] = d
+		}
+	}
+
+	for _, f := range cfg.Files {
+		filename := cfg.FileSet.Position(f.Package).Filename
+		if f.Name.Name != name || !containsString(files, filepath.Base(filename)) {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv != nil && len(d.Recv.List) > 0 {
+					add(fmt.Sprintf("%s.%s", embeddedTypeName(d.Recv.List[0].Type), d.Name.Name), d.Doc)
+				} else {
+					add(d.Name.Name, d.Doc)
+				}
+			case *ast.GenDecl:
+				for _, s := range d.Specs {
+					switch spec := s.(type) {
+					case *ast.TypeSpec:
+						// The doc package uses the comment on the declaration
+						// for types that aren't part of a group
+						if d.Lparen.IsValid() {
+							add(spec.Name.Name, spec.Doc)
+						} else {
+							add(spec.Name.Name, d.Doc, spec.Doc)
+						}
+
+						addFieldDirectives(spec, add)
+					case *ast.ValueSpec:
+						for _, n := range spec.Names {
+							add(n.Name, d.Doc, spec.Doc)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+func addFieldDirectives(spec *ast.TypeSpec, add func(string, ...*ast.CommentGroup)) {
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok || st.Fields == nil {
+		return
+	}
+
+	for _, f := range st.Fields.List {
+		for _, name := range fieldNames(f) {
+			add(fmt.Sprintf("%s.%s", spec.Name.Name, name), f.Doc)
+		}
+	}
+}
+
+// parseDirectives applies the gomarkdoc directives found in the provided
+// comment group to d. Unrecognized or malformed directives are reported as
+// warnings and otherwise ignored.
+func parseDirectives(cfg *Config, group *ast.CommentGroup, d *directives) {
+	if group == nil {
+		return
+	}
+
+	for _, c := range group.List {
+		if !strings.HasPrefix(c.Text, directivePrefix) {
+			continue
+		}
+
+		name, arg, _ := strings.Cut(strings.TrimPrefix(c.Text, directivePrefix), " ")
+		arg = strings.TrimSpace(arg)
+		pos := cfg.FileSet.Position(c.Pos())
+
+		switch name {
+		case "hide":
+			d.hide = true
+		case "example-only":
+			d.exampleOnly = true
+		case "group":
+			if arg == "" {
+				cfg.Log.Warnf("%s: missing name for gomarkdoc:group directive", pos)
+				continue
+			}
+
+			d.group = arg
+		case "order":
+			order, err := strconv.Atoi(arg)
+			if err != nil {
+				cfg.Log.Warnf("%s: invalid position %q for gomarkdoc:order directive", pos, arg)
+				continue
+			}
+
+			d.order = order
+		default:
+			cfg.Log.Warnf("%s: unknown directive gomarkdoc:%s", pos, name)
+		}
+	}
+}
+
+// isVisible reports whether the symbol is left in the documentation by the
+// gomarkdoc:hide directives. It is used as a filter with filterSymbols.
+func (c *Config) isVisible(sym Symbol) bool {
+	return !c.directives[symbolName(sym.Receiver, sym.Name)].hide
+}
+
+// hasHidden reports whether any symbol has a gomarkdoc:hide directive.
+func (c *Config) hasHidden() bool {
+	for _, d := range c.directives {
+		if d.hide {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stripDirectives removes the gomarkdoc directives from the comments within
+// the provided declaration so that they aren't included when it is printed.
+// The doc package already leaves directives out of the text of doc comments.
+func stripDirectives(node ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Field:
+			n.Doc = withoutDirectives(n.Doc)
+			n.Comment = withoutDirectives(n.Comment)
+		case *ast.ValueSpec:
+			n.Doc = withoutDirectives(n.Doc)
+			n.Comment = withoutDirectives(n.Comment)
+		case *ast.TypeSpec:
+			n.Doc = withoutDirectives(n.Doc)
+			n.Comment = withoutDirectives(n.Comment)
+		}
+
+		return true
+	})
+}
+
+func withoutDirectives(group *ast.CommentGroup) *ast.CommentGroup {
+	if group == nil {
+		return nil
+	}
+
+	list := make([]*ast.Comment, 0, len(group.List))
+	for _, c := range group.List {
+		if !strings.HasPrefix(c.Text, directivePrefix) {
+			list = append(list, c)
+		}
+	}
+
+	if len(list) == 0 {
+		return nil
+	}
+
+	return &ast.CommentGroup{List: list}
+}
+
+// stripPackageDirectives removes the gomarkdoc directives from the comments
+// within all of the declarations documented for the package.
+func stripPackageDirectives(pkg *doc.Package) {
+	stripValues := func(values []*doc.Value) {
+		for _, v := range values {
+			stripDirectives(v.Decl)
+		}
+	}
+
+	stripValues(pkg.Consts)
+	stripValues(pkg.Vars)
+	for _, t := range pkg.Types {
+		stripDirectives(t.Decl)
+		stripValues(t.Consts)
+		stripValues(t.Vars)
+	}
+}
+
+// linkExampleOnlyFields points the fields of the types that are documented by
+// their examples alone to the type itself, since the fields aren't rendered and
+// have no anchors of their own.
+func linkExampleOnlyFields(cfg *Config, symbols map[string]Symbol) {
+	for key, sym := range symbols {
+		if sym.Kind != FieldSymbolKind || !cfg.directives[sym.Receiver].exampleOnly {
+			continue
+		}
+
+		typeSym := symbols[sym.Receiver]
+		sym.Parent = &typeSym
+		symbols[key] = sym
+	}
+}
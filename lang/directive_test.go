@@ -0,0 +1,133 @@
+package lang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+func TestPackage_hideDirective(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/directives")
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Vars()), 0)
+
+	_, err = findType(pkg, "Tokenizer")
+	is.True(err != nil) // hidden type documented
+
+	// The constructor of the hidden type is documented on its own
+	_, err = findFunc(pkg, "NewTokenizer")
+	is.NoErr(err)
+
+	typ, err := findType(pkg, "Parser")
+	is.NoErr(err)
+
+	methods := typ.Methods()
+	is.Equal(len(methods), 1)
+	is.Equal(methods[0].Name(), "Parse")
+
+	// Links to hidden symbols are plain text
+	for _, span := range typ.Doc().Blocks()[0].Spans() {
+		is.True(span.Kind() != lang.LinkSpan || span.Text() != "Tokenizer")
+	}
+}
+
+func TestPackage_hideDirectiveFields(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/directives")
+	is.NoErr(err)
+
+	typ, err := findType(pkg, "Options")
+	is.NoErr(err)
+
+	fields := typ.Fields()
+	is.Equal(len(fields), 1)
+	is.Equal(fields[0].Name(), "Strict")
+
+	decl, err := typ.Decl()
+	is.NoErr(err)
+	is.Equal(decl, "type Options struct {\n    // Strict rejects unknown input.\n    Strict bool\n    // contains filtered or unexported fields\n}")
+}
+
+func TestPackage_groupAndOrderDirectives(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/directives")
+	is.NoErr(err)
+
+	consts := pkg.Consts()
+	is.Equal(len(consts), 1)
+	is.Equal(consts[0].Group(), "Configuration")
+	is.Equal(consts[0].Order(), 2)
+
+	options, err := findType(pkg, "Options")
+	is.NoErr(err)
+	is.Equal(options.Group(), "Configuration")
+	is.Equal(options.Order(), 1)
+
+	parser, err := findType(pkg, "Parser")
+	is.NoErr(err)
+	is.Equal(parser.Group(), "Parsing")
+	is.Equal(parser.Order(), 0)
+
+	parse := parser.Methods()[0]
+	is.Equal(parse.Group(), "")
+	is.Equal(parse.Order(), 1)
+
+	quote, err := findFunc(pkg, "Quote")
+	is.NoErr(err)
+	is.Equal(quote.Group(), "Parsing")
+	is.True(quote.ExampleOnly())
+	is.True(!parser.ExampleOnly())
+}
+
+func TestPackage_exampleOnlyFieldLinks(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/directives")
+	is.NoErr(err)
+
+	parser, err := findType(pkg, "Parser")
+	is.NoErr(err)
+
+	// The fields of an example-only type aren't rendered, so links to them
+	// point to the type
+	var found bool
+	for _, span := range parser.Methods()[0].Doc().Blocks()[0].Spans() {
+		if span.Kind() == lang.LinkSpan && span.Text() == "Token.Text" {
+			found = true
+			is.Equal(span.URL(), "#Token")
+		}
+	}
+
+	is.True(found) // link to example-only field not found
+}
+
+func TestPackage_directivesRemovedFromDoc(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/directives")
+	is.NoErr(err)
+
+	consts := pkg.Consts()
+	is.Equal(len(consts), 1)
+
+	decl, err := consts[0].Decl()
+	is.NoErr(err)
+	is.True(!strings.Contains(decl, "gomarkdoc:"))
+
+	quote, err := findFunc(pkg, "Quote")
+	is.NoErr(err)
+	is.Equal(quote.Summary(), "Quote quotes the input.")
+
+	for _, block := range quote.Doc().Blocks() {
+		for _, span := range block.Spans() {
+			is.True(!strings.Contains(span.Text(), "gomarkdoc:"))
+		}
+	}
+}
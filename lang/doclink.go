@@ -0,0 +1,111 @@
+package lang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+type (
+	// DocLinks maps prefixes of import paths to templates that produce the URL
+	// for documentation of packages with a matching import path. It is used to
+	// resolve links to packages that are not documented on pkg.go.dev, such as
+	// private modules.
+	DocLinks struct {
+		rules []docLinkRule
+	}
+
+	// DocLinkData holds the data available to the templates for doc links.
+	DocLinkData struct {
+		// ImportPath holds the import path of the linked package.
+		ImportPath string
+		// Symbol holds the name of the linked symbol, qualified by its
+		// receiver if it has one (e.g. Type.Method). It is empty for links to
+		// the package itself.
+		Symbol string
+		// Recv holds the receiver of the linked symbol, if any.
+		Recv string
+		// Name holds the unqualified name of the linked symbol, if any.
+		Name string
+	}
+
+	docLinkRule struct {
+		prefix string
+		tmpl   *template.Template
+	}
+)
+
+// NewDocLinks creates a set of doc link rules from a map of import path
+// prefixes to URL templates. Templates use the text/template syntax and are
+// provided with a DocLinkData. When multiple prefixes match an import path, the
+// longest one is used.
+func NewDocLinks(templates map[string]string) (*DocLinks, error) {
+	rules := make([]docLinkRule, 0, len(templates))
+	for prefix, text := range templates {
+		tmpl, err := template.New(prefix).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: invalid doc link template for %s: %w", prefix, err)
+		}
+
+		rules = append(rules, docLinkRule{prefix, tmpl})
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if len(rules[i].prefix) != len(rules[j].prefix) {
+			return len(rules[i].prefix) > len(rules[j].prefix)
+		}
+
+		return rules[i].prefix < rules[j].prefix
+	})
+
+	return &DocLinks{rules}, nil
+}
+
+// ParseDocLinks creates a set of doc link rules from a list of entries of the
+// form prefix=template, as used in gomarkdoc's configuration.
+func ParseDocLinks(entries []string) (*DocLinks, error) {
+	templates := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		prefix, text, ok := strings.Cut(entry, "=")
+		if !ok || prefix == "" {
+			return nil, fmt.Errorf("gomarkdoc: invalid doc link %q, expected prefix=template", entry)
+		}
+
+		templates[prefix] = text
+	}
+
+	return NewDocLinks(templates)
+}
+
+// Href produces the href for the documentation of the symbol with the
+// provided receiver and name in the package with the provided import path. If
+// no name is provided, the href points to the package's documentation itself.
+// The second return value is false if no prefix matches the import path. An
+// error is returned if the template for the matching prefix fails to execute.
+func (d *DocLinks) Href(importPath, recv, name string) (string, bool, error) {
+	for _, rule := range d.rules {
+		if !strings.HasPrefix(importPath, rule.prefix) {
+			continue
+		}
+
+		var symbol string
+		if name != "" {
+			symbol = symbolName(recv, name)
+		}
+
+		var b strings.Builder
+		if err := rule.tmpl.Execute(&b, DocLinkData{
+			ImportPath: importPath,
+			Symbol:     symbol,
+			Recv:       recv,
+			Name:       name,
+		}); err != nil {
+			return "", false, fmt.Errorf("gomarkdoc: failed to resolve doc link for %s: %w", importPath, err)
+		}
+
+		return b.String(), true, nil
+	}
+
+	return "", false, nil
+}
@@ -0,0 +1,81 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+func TestDocLinks_Href(t *testing.T) {
+	links, err := lang.NewDocLinks(map[string]string{
+		"corp.example.com/":      "https://docs.corp/{{.ImportPath}}{{if .Symbol}}#{{.Symbol}}{{end}}",
+		"corp.example.com/pages": "https://pages.corp/{{.ImportPath}}?recv={{.Recv}}&name={{.Name}}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                     string
+		importPath, recv, symbol string
+		href                     string
+		ok                       bool
+	}{
+		{"symbol", "corp.example.com/mod/pkg", "", "Thing", "https://docs.corp/corp.example.com/mod/pkg#Thing", true},
+		{
+			"method",
+			"corp.example.com/mod/pkg", "Thing", "Do",
+			"https://docs.corp/corp.example.com/mod/pkg#Thing.Do",
+			true,
+		},
+		{"package", "corp.example.com/mod/pkg", "", "", "https://docs.corp/corp.example.com/mod/pkg", true},
+		{
+			"longest prefix",
+			"corp.example.com/pages/pkg", "Thing", "Do",
+			"https://pages.corp/corp.example.com/pages/pkg?recv=Thing&name=Do",
+			true,
+		},
+		{"no match", "example.com/mod/pkg", "", "Thing", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			href, ok, err := links.Href(test.importPath, test.recv, test.symbol)
+			is.NoErr(err)
+			is.Equal(ok, test.ok)
+			is.Equal(href, test.href)
+		})
+	}
+}
+
+func TestNewDocLinks_invalidTemplate(t *testing.T) {
+	is := is.New(t)
+
+	_, err := lang.NewDocLinks(map[string]string{"example.com/": "https://docs/{{.ImportPath"})
+	is.True(err != nil) // template is not closed
+}
+
+func TestParseDocLinks(t *testing.T) {
+	is := is.New(t)
+
+	links, err := lang.ParseDocLinks([]string{"example.com/=https://docs/{{.ImportPath}}?a=b"})
+	is.NoErr(err)
+
+	href, ok, err := links.Href("example.com/pkg", "", "")
+	is.NoErr(err)
+	is.True(ok)
+	is.Equal(href, "https://docs/example.com/pkg?a=b")
+}
+
+func TestParseDocLinks_invalid(t *testing.T) {
+	is := is.New(t)
+
+	_, err := lang.ParseDocLinks([]string{"=https://docs/"})
+	is.True(err != nil) // missing prefix
+
+	_, err = lang.ParseDocLinks([]string{"example.com/"})
+	is.True(err != nil) // missing template
+}
@@ -3,6 +3,7 @@ package lang
 import (
 	"fmt"
 	"go/doc"
+	"go/format"
 	"go/printer"
 	"strings"
 )
@@ -32,6 +33,12 @@ func (ex *Example) Name() string {
 	return splitCamel(ex.name)
 }
 
+// FuncName provides the name of the function declaring the example, such as
+// ExampleType_Method.
+func (ex *Example) FuncName() string {
+	return "Example" + ex.doc.Name
+}
+
 // Title provides a formatted string to print as the title of the example. It
 // incorporates the example's name, if present.
 func (ex *Example) Title() string {
@@ -58,7 +65,7 @@ func (ex *Example) Summary() string {
 // Doc provides the structured contents of the documentation comment for the
 // example.
 func (ex *Example) Doc() *Doc {
-	return NewDoc(ex.cfg.Inc(1), ex.doc.Doc)
+	return NewDoc(ex.cfg.docConfig(1, ex.Location(), ex.cfg.precedingComment(ex.doc.Code)), ex.doc.Doc)
 }
 
 // Code provides the raw text code representation of the example's contents.
@@ -99,3 +106,32 @@ func (ex *Example) Output() string {
 func (ex *Example) HasOutput() bool {
 	return ex.doc.Output != "" || ex.doc.EmptyOutput
 }
+
+// Unordered indicates whether the example's output is introduced by an
+// "Unordered output:" comment, in which case the lines of output may appear in
+// any order.
+func (ex *Example) Unordered() bool {
+	return ex.doc.Unordered
+}
+
+// Runnable indicates whether the example can be run as a standalone program.
+// This is only the case for examples declared in an external test package
+// (i.e. one whose name ends in _test).
+func (ex *Example) Runnable() bool {
+	return ex.doc.Play != nil
+}
+
+// Program provides the source code of a standalone main package that runs the
+// example. It produces an error if the example is not runnable.
+func (ex *Example) Program() (string, error) {
+	if ex.doc.Play == nil {
+		return "", fmt.Errorf("gomarkdoc: example %s is not runnable", ex.doc.Name)
+	}
+
+	var code strings.Builder
+	if err := format.Node(&code, ex.cfg.FileSet, ex.doc.Play); err != nil {
+		return "", err
+	}
+
+	return code.String(), nil
+}
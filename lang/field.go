@@ -0,0 +1,153 @@
+package lang
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// Field holds documentation information for a single field of a struct type.
+type Field struct {
+	cfg   *Config
+	recv  string
+	name  string
+	field *ast.Field
+}
+
+// NewField creates a Field from the name of the struct type containing it, the
+// name of the field and the AST node of the field declaring it. A single field
+// node may declare several names, each of which is documented as its own
+// Field.
+func NewField(cfg *Config, recv, name string, field *ast.Field) *Field {
+	return &Field{cfg, recv, name, field}
+}
+
+// Level provides the default level that headers within the field's
+// documentation should be rendered.
+func (f *Field) Level() int {
+	return f.cfg.Level
+}
+
+// Name provides the name of the field. For embedded fields, this is the name of
+// the embedded type.
+func (f *Field) Name() string {
+	return f.name
+}
+
+// Type provides the raw text representation of the field's type. For embedded
+// fields, this is the embedded type, including the pointer if there is one.
+func (f *Field) Type() (string, error) {
+	// We use a custom FileSet so that we don't inherit multiline formatting
+	return printNode(f.field.Type, token.NewFileSet())
+}
+
+// Tag provides the struct tag of the field without the surrounding quotes, or
+// an empty string if the field has no tag.
+func (f *Field) Tag() string {
+	if f.field.Tag == nil {
+		return ""
+	}
+
+	tag, err := strconv.Unquote(f.field.Tag.Value)
+	if err != nil {
+		return f.field.Tag.Value
+	}
+
+	return tag
+}
+
+// Embedded reports whether the field is an embedded field.
+func (f *Field) Embedded() bool {
+	return len(f.field.Names) == 0
+}
+
+// Summary provides the one-sentence summary of the field's documentation
+// comment.
+func (f *Field) Summary() string {
+	return extractSummary(f.text())
+}
+
+// Doc provides the structured contents of the documentation comment for the
+// field. If the field has no documentation comment above it, the comment on
+// the same line as the field is used instead.
+func (f *Field) Doc() *Doc {
+	return NewDoc(f.cfg.docConfig(1, NewLocation(f.cfg, f.field), f.comment()), f.text())
+}
+
+// Deprecated reports whether the field's documentation marks it as
+// deprecated.
+func (f *Field) Deprecated() bool {
+	return isDeprecated(f.text())
+}
+
+// DeprecationNotice provides the text following "Deprecated: " in the field's
+// documentation, or an empty string if the field is not deprecated.
+func (f *Field) DeprecationNotice() string {
+	notice, _ := deprecationNotice(f.text())
+	return notice
+}
+
+// Anchor produces anchor text for the field.
+func (f *Field) Anchor() string {
+	return f.cfg.anchor(Symbol{
+		Kind:     FieldSymbolKind,
+		Receiver: f.recv,
+		Name:     f.name,
+	})
+}
+
+func (f *Field) text() string {
+	return fieldText(f.field)
+}
+
+// comment provides the comment that the field's documentation is read from.
+func (f *Field) comment() *ast.CommentGroup {
+	if f.field.Doc != nil {
+		return f.field.Doc
+	}
+
+	return f.field.Comment
+}
+
+// fieldText provides the documentation text for the provided struct field,
+// falling back to the comment on the same line if there is no documentation
+// comment above the field.
+func fieldText(field *ast.Field) string {
+	if field.Doc != nil {
+		return field.Doc.Text()
+	}
+
+	return field.Comment.Text()
+}
+
+// fieldNames provides the names declared by the provided struct field. For
+// embedded fields, this is the name of the embedded type, including types from
+// other packages.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		expr := field.Type
+		for {
+			switch v := expr.(type) {
+			case *ast.StarExpr:
+				expr = v.X
+			case *ast.IndexExpr:
+				expr = v.X
+			case *ast.IndexListExpr:
+				expr = v.X
+			case *ast.SelectorExpr:
+				return []string{v.Sel.Name}
+			case *ast.Ident:
+				return []string{v.Name}
+			default:
+				return nil
+			}
+		}
+	}
+
+	names := make([]string, len(field.Names))
+	for i, n := range field.Names {
+		names[i] = n.Name
+	}
+
+	return names
+}
@@ -0,0 +1,259 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// symbolKindNames holds the names used to refer to each symbol kind in
+// gomarkdoc's configuration.
+var symbolKindNames = map[SymbolKind]string{
+	TypeSymbolKind:   "type",
+	FuncSymbolKind:   "func",
+	ConstSymbolKind:  "const",
+	VarSymbolKind:    "var",
+	MethodSymbolKind: "method",
+	FieldSymbolKind:  "field",
+}
+
+// String provides the name of the symbol kind, such as "type" or "method".
+func (k SymbolKind) String() string {
+	if name, ok := symbolKindNames[k]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("SymbolKind(%d)", int(k))
+}
+
+// ParseSymbolKind finds the symbol kind with the provided name. Valid names are
+// type, func, const, var, method and field.
+func ParseSymbolKind(name string) (SymbolKind, error) {
+	for kind, n := range symbolKindNames {
+		if n == name {
+			return kind, nil
+		}
+	}
+
+	return 0, fmt.Errorf("gomarkdoc: invalid symbol kind %q", name)
+}
+
+// SymbolPatternFilter creates a filter for use with PackageWithSymbolFilter
+// that includes symbols by name. Patterns are matched against the name of the
+// symbol, qualified by its receiver for methods and fields (e.g.
+// Type.Method). Patterns surrounded by slashes (e.g. /^New/) are regular
+// expressions, while all other patterns are globs as supported by path.Match.
+//
+// If any include patterns are provided, only symbols matching one of them are
+// included. Methods and fields are also included if the type declaring them
+// matches. Symbols matching any of the exclude patterns are left out, even if
+// they match an include pattern.
+func SymbolPatternFilter(include, exclude []string) (func(Symbol) bool, error) {
+	includeMatchers, err := symbolMatchers(include)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeMatchers, err := symbolMatchers(exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(sym Symbol) bool {
+		name := symbolName(sym.Receiver, sym.Name)
+		for _, m := range excludeMatchers {
+			if m(name) {
+				return false
+			}
+		}
+
+		if len(includeMatchers) == 0 {
+			return true
+		}
+
+		for _, m := range includeMatchers {
+			if m(name) || (sym.Receiver != "" && m(strings.TrimLeft(sym.Receiver, "*"))) {
+				return true
+			}
+		}
+
+		return false
+	}, nil
+}
+
+// SymbolKindFilter creates a filter for use with PackageWithSymbolFilter that
+// includes symbols by kind. If any kinds are included, only the top-level
+// symbols of those kinds are included. Methods, fields and the funcs, consts
+// and vars documented with a type are kept along with the type, so included
+// kinds may only be type, func, const or var. Symbols of any of the excluded
+// kinds are left out, wherever they are declared.
+func SymbolKindFilter(include, exclude []SymbolKind) func(Symbol) bool {
+	return func(sym Symbol) bool {
+		for _, k := range exclude {
+			if sym.Kind == k {
+				return false
+			}
+		}
+
+		if len(include) == 0 || sym.Receiver != "" || (sym.Parent != nil && sym.Parent.Kind == TypeSymbolKind) {
+			return true
+		}
+
+		for _, k := range include {
+			if sym.Kind == k {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func symbolMatchers(patterns []string) ([]func(string) bool, error) {
+	matchers := make([]func(string) bool, len(patterns))
+	for i, pattern := range patterns {
+		if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+			if err != nil {
+				return nil, fmt.Errorf("gomarkdoc: invalid symbol pattern %q: %w", pattern, err)
+			}
+
+			matchers[i] = re.MatchString
+			continue
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("gomarkdoc: invalid symbol pattern %q: %w", pattern, err)
+		}
+
+		p := pattern
+		matchers[i] = func(name string) bool {
+			ok, _ := path.Match(p, name)
+			return ok
+		}
+	}
+
+	return matchers, nil
+}
+
+// filterSymbols removes the symbols that the provided filter doesn't keep from
+// the documentation for the package. Removing a type also removes its methods
+// and fields, while the funcs, consts and vars associated with it are moved to
+// the package level and filtered on their own. The funcs, consts and vars that
+// stay with a type are filtered with the type as their parent. A const or var
+// declaration is kept if any of the names it declares are kept.
+func filterSymbols(pkg *doc.Package, keep func(Symbol) bool) {
+	var moved bool
+	types := pkg.Types[:0]
+	for _, t := range pkg.Types {
+		typeSym := &Symbol{Name: t.Name, Kind: TypeSymbolKind}
+		if !keep(*typeSym) {
+			pkg.Funcs = append(pkg.Funcs, t.Funcs...)
+			pkg.Consts = append(pkg.Consts, t.Consts...)
+			pkg.Vars = append(pkg.Vars, t.Vars...)
+			moved = true
+			continue
+		}
+
+		t.Consts = filterValues(t.Consts, ConstSymbolKind, typeSym, keep)
+		t.Vars = filterValues(t.Vars, VarSymbolKind, typeSym, keep)
+		t.Funcs = filterFuncs(t.Funcs, typeSym, keep)
+		t.Methods = filterMethods(t.Name, t.Methods, keep)
+		filterFields(t, keep)
+		types = append(types, t)
+	}
+
+	pkg.Types = types
+	pkg.Consts = filterValues(pkg.Consts, ConstSymbolKind, nil, keep)
+	pkg.Vars = filterValues(pkg.Vars, VarSymbolKind, nil, keep)
+	pkg.Funcs = filterFuncs(pkg.Funcs, nil, keep)
+
+	if moved {
+		sortPackageSymbols(pkg)
+	}
+}
+
+// sortPackageSymbols restores the order used by the doc package for the funcs
+// and values at the package level after symbols were moved there, which sorts
+// funcs by name and values by declaration order.
+func sortPackageSymbols(pkg *doc.Package) {
+	sort.Slice(pkg.Funcs, func(i, j int) bool { return pkg.Funcs[i].Name < pkg.Funcs[j].Name })
+	sortValues(pkg.Consts)
+	sortValues(pkg.Vars)
+}
+
+func sortValues(values []*doc.Value) {
+	sort.SliceStable(values, func(i, j int) bool { return values[i].Decl.Pos() < values[j].Decl.Pos() })
+}
+
+func filterValues(values []*doc.Value, kind SymbolKind, parent *Symbol, keep func(Symbol) bool) []*doc.Value {
+	res := values[:0]
+	for _, v := range values {
+		for _, n := range v.Names {
+			if keep(Symbol{Name: n, Kind: kind, Parent: parent}) {
+				res = append(res, v)
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+func filterFuncs(funcs []*doc.Func, parent *Symbol, keep func(Symbol) bool) []*doc.Func {
+	res := funcs[:0]
+	for _, fn := range funcs {
+		if keep(Symbol{Name: fn.Name, Kind: FuncSymbolKind, Parent: parent}) {
+			res = append(res, fn)
+		}
+	}
+
+	return res
+}
+
+func filterMethods(recv string, methods []*doc.Func, keep func(Symbol) bool) []*doc.Func {
+	res := methods[:0]
+	for _, fn := range methods {
+		if keep(Symbol{Receiver: recv, Name: fn.Name, Kind: MethodSymbolKind}) {
+			res = append(res, fn)
+		}
+	}
+
+	return res
+}
+
+func filterFields(t *doc.Type, keep func(Symbol) bool) {
+	for _, s := range t.Decl.Specs {
+		spec, ok := s.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok || st.Fields == nil {
+			continue
+		}
+
+		fields := st.Fields.List[:0]
+		for _, f := range st.Fields.List {
+			var kept bool
+			for _, name := range fieldNames(f) {
+				kept = kept || keep(Symbol{Receiver: t.Name, Name: name, Kind: FieldSymbolKind})
+			}
+
+			if !kept {
+				// Let the printer note that fields were left out
+				st.Incomplete = true
+				continue
+			}
+
+			fields = append(fields, f)
+		}
+
+		st.Fields.List = fields
+	}
+}
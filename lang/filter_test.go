@@ -0,0 +1,173 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/lang"
+	"github.com/princjef/gomarkdoc/logger"
+)
+
+func TestSymbolPatternFilter(t *testing.T) {
+	is := is.New(t)
+
+	filter, err := lang.SymbolPatternFilter([]string{"Client", "/^New/"}, []string{"*.Undo", "NewInternal*"})
+	is.NoErr(err)
+
+	tests := []struct {
+		sym  lang.Symbol
+		keep bool
+	}{
+		{lang.Symbol{Name: "Client", Kind: lang.TypeSymbolKind}, true},
+		{lang.Symbol{Receiver: "Client", Name: "Do", Kind: lang.MethodSymbolKind}, true},
+		{lang.Symbol{Receiver: "*Client", Name: "Name", Kind: lang.FieldSymbolKind}, true},
+		{lang.Symbol{Receiver: "Client", Name: "Undo", Kind: lang.MethodSymbolKind}, false},
+		{lang.Symbol{Name: "NewClient", Kind: lang.FuncSymbolKind}, true},
+		{lang.Symbol{Name: "NewInternalState", Kind: lang.FuncSymbolKind}, false},
+		{lang.Symbol{Name: "InternalState", Kind: lang.TypeSymbolKind}, false},
+	}
+
+	for _, test := range tests {
+		is.Equal(filter(test.sym), test.keep) // unexpected result for symbol
+	}
+}
+
+func TestSymbolPatternFilter_invalid(t *testing.T) {
+	is := is.New(t)
+
+	_, err := lang.SymbolPatternFilter([]string{"[a-"}, nil)
+	is.True(err != nil) // invalid glob accepted
+
+	_, err = lang.SymbolPatternFilter(nil, []string{"/(/"})
+	is.True(err != nil) // invalid regex accepted
+}
+
+func TestSymbolKindFilter(t *testing.T) {
+	is := is.New(t)
+
+	filter := lang.SymbolKindFilter([]lang.SymbolKind{lang.TypeSymbolKind, lang.MethodSymbolKind}, nil)
+	is.True(filter(lang.Symbol{Name: "Client", Kind: lang.TypeSymbolKind}))
+	is.True(filter(lang.Symbol{Receiver: "Client", Name: "Do", Kind: lang.MethodSymbolKind}))
+	is.True(!filter(lang.Symbol{Name: "NewClient", Kind: lang.FuncSymbolKind}))
+
+	filter = lang.SymbolKindFilter(nil, []lang.SymbolKind{lang.VarSymbolKind})
+	is.True(filter(lang.Symbol{Name: "Client", Kind: lang.TypeSymbolKind}))
+	is.True(!filter(lang.Symbol{Name: "DefaultTimeout", Kind: lang.VarSymbolKind}))
+}
+
+func TestParseSymbolKind(t *testing.T) {
+	is := is.New(t)
+
+	for _, kind := range []lang.SymbolKind{
+		lang.TypeSymbolKind,
+		lang.FuncSymbolKind,
+		lang.ConstSymbolKind,
+		lang.VarSymbolKind,
+		lang.MethodSymbolKind,
+		lang.FieldSymbolKind,
+	} {
+		parsed, err := lang.ParseSymbolKind(kind.String())
+		is.NoErr(err)
+		is.Equal(parsed, kind)
+	}
+
+	_, err := lang.ParseSymbolKind("interface")
+	is.True(err != nil) // invalid kind accepted
+}
+
+func TestPackage_symbolFilter(t *testing.T) {
+	is := is.New(t)
+
+	filter, err := lang.SymbolPatternFilter(nil, []string{"Internal*", "Client.Undo"})
+	is.NoErr(err)
+
+	pkg, err := loadFilteredPackage(
+		lang.PackageWithSymbolFilter(filter),
+		lang.PackageWithSymbolFilter(lang.SymbolKindFilter(nil, []lang.SymbolKind{lang.VarSymbolKind})),
+	)
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Consts()), 1)
+	is.Equal(len(pkg.Vars()), 0)
+
+	// The constructor of the filtered type is documented on its own
+	funcs := pkg.Funcs()
+	is.Equal(len(funcs), 1)
+	is.Equal(funcs[0].Name(), "NewInternalState")
+
+	types := pkg.Types()
+	is.Equal(len(types), 1)
+	is.Equal(types[0].Name(), "Client")
+	is.Equal(len(types[0].Funcs()), 1)
+
+	methods := types[0].Methods()
+	is.Equal(len(methods), 1)
+	is.Equal(methods[0].Name(), "Do")
+
+	// Links to filtered symbols are plain text
+	for _, span := range methods[0].Doc().Blocks()[0].Spans() {
+		is.Equal(span.Kind(), lang.TextSpan)
+	}
+}
+
+func TestPackage_symbolKindFilterInclude(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadFilteredPackage(
+		lang.PackageWithSymbolFilter(lang.SymbolKindFilter([]lang.SymbolKind{lang.TypeSymbolKind}, nil)),
+	)
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Consts()), 0)
+	is.Equal(len(pkg.Vars()), 0)
+	is.Equal(len(pkg.Funcs()), 0)
+
+	// Constructors, methods and fields stay with the included type
+	typ, err := findType(pkg, "Client")
+	is.NoErr(err)
+	is.Equal(len(typ.Funcs()), 1)
+	is.Equal(len(typ.Methods()), 2)
+	is.Equal(len(typ.Fields()), 2)
+
+	pkg, err = loadFilteredPackage(lang.PackageWithSymbolFilter(lang.SymbolKindFilter(
+		[]lang.SymbolKind{lang.TypeSymbolKind},
+		[]lang.SymbolKind{lang.FuncSymbolKind, lang.MethodSymbolKind},
+	)))
+	is.NoErr(err)
+
+	typ, err = findType(pkg, "Client")
+	is.NoErr(err)
+	is.Equal(len(typ.Funcs()), 0)
+	is.Equal(len(typ.Methods()), 0)
+	is.Equal(len(typ.Fields()), 2)
+}
+
+func TestPackage_symbolFilterFields(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadFilteredPackage(lang.PackageWithSymbolFilter(func(sym lang.Symbol) bool {
+		return sym.Kind != lang.FieldSymbolKind || sym.Name != "State"
+	}))
+	is.NoErr(err)
+
+	typ, err := findType(pkg, "Client")
+	is.NoErr(err)
+
+	fields := typ.Fields()
+	is.Equal(len(fields), 1)
+	is.Equal(fields[0].Name(), "Name")
+
+	decl, err := typ.Decl()
+	is.NoErr(err)
+	is.Equal(decl, "type Client struct {\n    // Name is the name of the client.\n    Name string\n    // contains filtered or unexported fields\n}")
+}
+
+func loadFilteredPackage(opts ...lang.PackageOption) (*lang.Package, error) {
+	buildPkg, err := getBuildPackage("../testData/filters")
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.New(logger.ErrorLevel)
+	return lang.NewPackageFromBuild(log, buildPkg, opts...)
+}
@@ -49,6 +49,56 @@ func (fn *Func) Receiver() string {
 	return fn.doc.Recv
 }
 
+// Deprecated reports whether the function's documentation marks it as
+// deprecated. Following the Go convention, this is the case when a paragraph of
+// the documentation begins with "Deprecated: ".
+func (fn *Func) Deprecated() bool {
+	return isDeprecated(fn.doc.Doc)
+}
+
+// DeprecationNotice provides the text following "Deprecated: " in the
+// function's documentation, or an empty string if the function is not
+// deprecated.
+func (fn *Func) DeprecationNotice() string {
+	notice, _ := deprecationNotice(fn.doc.Doc)
+	return notice
+}
+
+// Availability describes the build contexts under which the function is
+// available, such as "linux, darwin only" or "requires tag: enterprise", if
+// the package was documented with a build matrix. An empty string is returned
+// if the function is available under all of the contexts or no build matrix
+// was used.
+func (fn *Func) Availability() string {
+	return fn.cfg.availability.symbol(fn.symbolName())
+}
+
+// Since provides the version of the release in which the function was added,
+// such as v1.2.0, if the package was documented with a version history. An
+// empty string is returned if the function was part of the first release, has
+// not been released yet or no version history was used.
+func (fn *Func) Since() string {
+	return fn.cfg.since[fn.symbolName()]
+}
+
+// Group provides the name of the group the function was placed in with a
+// //gomarkdoc:group directive, or an empty string if it has none.
+func (fn *Func) Group() string {
+	return fn.directives().group
+}
+
+// Order provides the position given to the function with a //gomarkdoc:order
+// directive, or 0 if it has none.
+func (fn *Func) Order() int {
+	return fn.directives().order
+}
+
+// ExampleOnly reports whether the function has a //gomarkdoc:example-only
+// directive, in which case its documentation consists of its examples alone.
+func (fn *Func) ExampleOnly() bool {
+	return fn.directives().exampleOnly
+}
+
 // Location returns a representation of the node's location in a file within a
 // repository.
 func (fn *Func) Location() Location {
@@ -64,7 +114,7 @@ func (fn *Func) Summary() string {
 // Doc provides the structured contents of the documentation comment for the
 // function.
 func (fn *Func) Doc() *Doc {
-	return NewDoc(fn.cfg.Inc(1), fn.doc.Doc)
+	return NewDoc(fn.cfg.docConfig(1, fn.Location(), fn.cfg.precedingComment(fn.doc.Decl)), fn.doc.Doc)
 }
 
 // Signature provides the raw text representation of the code for the
@@ -74,6 +124,23 @@ func (fn *Func) Signature() (string, error) {
 	return printNode(fn.doc.Decl, token.NewFileSet())
 }
 
+// SignatureSpans provides the code for the function's signature as a set of
+// spans. If type information is available for the package, identifiers that
+// refer to other symbols are provided as link spans pointing to the
+// documentation for those symbols. Otherwise, the signature is provided as a
+// single span of raw text.
+func (fn *Func) SignatureSpans() ([]*Span, error) {
+	// We use a custom FileSet so that we don't inherit multiline formatting
+	return codeSpans(fn.cfg, fn.doc.Decl, token.NewFileSet())
+}
+
+// TypeParams lists the type parameters of a generic function along with their
+// constraints. Methods cannot declare type parameters of their own, so the list
+// is always empty for methods, even when the receiver type is generic.
+func (fn *Func) TypeParams() []*TypeParam {
+	return typeParams(fn.cfg.Inc(1), fn.doc.Decl.Type.TypeParams)
+}
+
 // Examples provides the list of examples from the list given on initialization
 // that pertain to the function.
 func (fn *Func) Examples() (examples []*Example) {
@@ -106,17 +173,17 @@ func (fn *Func) Examples() (examples []*Example) {
 // Anchor produces anchor text for the func.
 func (fn *Func) Anchor() string {
 	if fn.doc.Recv != "" {
-		return Symbol{
+		return fn.cfg.anchor(Symbol{
 			Kind:     MethodSymbolKind,
 			Receiver: fn.doc.Recv,
 			Name:     fn.doc.Name,
-		}.Anchor()
+		})
 	}
 
-	return Symbol{
+	return fn.cfg.anchor(Symbol{
 		Kind: FuncSymbolKind,
 		Name: fn.doc.Name,
-	}.Anchor()
+	})
 }
 
 func (fn *Func) rawRecv() string {
@@ -129,3 +196,17 @@ func (fn *Func) rawRecv() string {
 
 	return recv
 }
+
+func (fn *Func) directives() directives {
+	return fn.cfg.directives[fn.symbolName()]
+}
+
+// symbolName provides the name of the function qualified by the type of its
+// receiver for methods (e.g. Type.Method).
+func (fn *Func) symbolName() string {
+	if fn.doc.Recv != "" {
+		return fmt.Sprintf("%s.%s", fn.rawRecv(), fn.doc.Name)
+	}
+
+	return fn.doc.Name
+}
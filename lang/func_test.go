@@ -2,6 +2,7 @@ package lang_test
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -99,6 +100,48 @@ func TestFunc_Examples_generic(t *testing.T) {
 	is.Equal(ex.Name(), "")
 }
 
+func TestFunc_Examples_output(t *testing.T) {
+	is := is.New(t)
+	fn, err := loadFunc("../testData/examples", "Greet")
+	is.NoErr(err)
+
+	examples := fn.Examples()
+	is.Equal(len(examples), 1)
+
+	ex := examples[0]
+	is.True(ex.HasOutput())
+	is.True(!ex.Unordered())
+	is.Equal(ex.Output(), "Hello, world!\nHello, gomarkdoc!\n")
+	is.True(ex.Runnable())
+
+	program, err := ex.Program()
+	is.NoErr(err)
+	is.True(strings.HasPrefix(program, "package main\n"))
+	is.True(strings.Contains(program, `"github.com/princjef/gomarkdoc/testData/examples"`))
+	is.True(strings.Contains(program, "func main() {"))
+}
+
+func TestFunc_Examples_unordered(t *testing.T) {
+	is := is.New(t)
+	pkg, err := loadPackage("../testData/examples")
+	is.NoErr(err)
+
+	typ, err := findType(pkg, "Set")
+	is.NoErr(err)
+
+	var values *lang.Func
+	for _, m := range typ.Methods() {
+		if m.Name() == "Values" {
+			values = m
+		}
+	}
+	is.True(values != nil)
+
+	examples := values.Examples()
+	is.Equal(len(examples), 1)
+	is.True(examples[0].Unordered())
+}
+
 func TestFunc_stringsCompare(t *testing.T) {
 	is := is.New(t)
 
@@ -201,6 +244,144 @@ func TestFunc_ioIoutilTempFile(t *testing.T) {
 	is.Equal(len(fn.Examples()), 2)
 }
 
+func TestFunc_SignatureSpans(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadTypedPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	fn, err := findFunc(pkg, "Standalone")
+	is.NoErr(err)
+
+	spans, err := fn.SignatureSpans()
+	is.NoErr(err)
+
+	var links []string
+	for _, s := range spans {
+		if s.Kind() == lang.LinkSpan {
+			links = append(links, s.Text()+" "+s.URL())
+		}
+	}
+
+	is.Equal(links, []string{
+		"int https://pkg.go.dev/builtin/#int",
+		"string https://pkg.go.dev/builtin/#string",
+		"int https://pkg.go.dev/builtin/#int",
+		"error https://pkg.go.dev/builtin/#error",
+	})
+	is.Equal(spanText(spans), "func Standalone(p1 int, p2 string) (int, error)")
+}
+
+func TestFunc_SignatureSpans_localType(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadTypedPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	fn, err := findFunc(pkg, "WithPtrReceiver")
+	is.NoErr(err)
+
+	spans, err := fn.SignatureSpans()
+	is.NoErr(err)
+
+	is.Equal(len(spans), 3)
+	is.Equal(spans[0].Text(), "func (r *")
+	is.Equal(spans[1].Kind(), lang.LinkSpan)
+	is.Equal(spans[1].Text(), "Receiver")
+	is.Equal(spans[1].URL(), "#Receiver")
+	is.Equal(spans[2].Text(), ") WithPtrReceiver()")
+}
+
+func TestFunc_SignatureSpans_noTypes(t *testing.T) {
+	is := is.New(t)
+
+	fn, err := loadFunc("../testData/lang/function", "Standalone")
+	is.NoErr(err)
+
+	spans, err := fn.SignatureSpans()
+	is.NoErr(err)
+
+	is.Equal(len(spans), 1)
+	is.Equal(spans[0].Kind(), lang.RawTextSpan)
+	is.Equal(spans[0].Text(), "func Standalone(p1 int, p2 string) (int, error)")
+}
+
+func TestFunc_Deprecated(t *testing.T) {
+	is := is.New(t)
+
+	fn, err := loadFunc("../testData/deprecated", "Start")
+	is.NoErr(err)
+
+	is.True(fn.Deprecated())
+	is.Equal(fn.DeprecationNotice(), "Use [Run] instead.")
+
+	fn, err = loadFunc("../testData/deprecated", "Run")
+	is.NoErr(err)
+
+	is.True(!fn.Deprecated())
+	is.Equal(fn.DeprecationNotice(), "")
+}
+
+func TestFunc_TypeParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []string
+	}{
+		{"Func", []string{"S int | float64 [] [int float64]"}},
+		{"Max", []string{"T Ordered [#Ordered] []"}},
+		{"Min", []string{"T cmp.Ordered [https://pkg.go.dev/cmp/#Ordered] []"}},
+		{"NewGeneric", []string{"T any [https://pkg.go.dev/builtin/#any] []"}},
+		{"Method", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			fn, err := loadFunc("../testData/generics", test.name)
+			is.NoErr(err)
+
+			is.Equal(typeParamSummary(fn.TypeParams()), test.params)
+		})
+	}
+}
+
+func TestFunc_TypeParams_typeCheck(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadTypedPackage("../testData/generics")
+	is.NoErr(err)
+
+	fn, err := findFunc(pkg, "Min")
+	is.NoErr(err)
+
+	is.Equal(typeParamSummary(fn.TypeParams()), []string{"T cmp.Ordered [https://pkg.go.dev/cmp/#Ordered] []"})
+}
+
+func typeParamSummary(params []*lang.TypeParam) []string {
+	var res []string
+	for _, p := range params {
+		constraint, err := p.Constraint()
+		if err != nil {
+			constraint = err.Error()
+		}
+
+		var terms []string
+		for _, term := range p.TypeSet() {
+			text, err := term.Text()
+			if err != nil {
+				text = err.Error()
+			}
+
+			terms = append(terms, text)
+		}
+
+		res = append(res, fmt.Sprintf("%s %s [%s] %v", p.Name(), constraint, p.ConstraintHref(), terms))
+	}
+
+	return res
+}
+
 func loadFunc(dir, name string) (*lang.Func, error) {
 	buildPkg, err := getBuildPackage(dir)
 	if err != nil {
@@ -213,6 +394,10 @@ func loadFunc(dir, name string) (*lang.Func, error) {
 		return nil, err
 	}
 
+	return findFunc(pkg, name)
+}
+
+func findFunc(pkg *lang.Package, name string) (*lang.Func, error) {
 	for _, f := range pkg.Funcs() {
 		if f.Name() == name {
 			return f, nil
@@ -235,3 +420,12 @@ func loadFunc(dir, name string) (*lang.Func, error) {
 
 	return nil, errors.New("func not found")
 }
+
+func spanText(spans []*lang.Span) string {
+	var b strings.Builder
+	for _, s := range spans {
+		b.WriteString(s.Text())
+	}
+
+	return b.String()
+}
@@ -0,0 +1,292 @@
+package lang
+
+import (
+	"fmt"
+	"go/doc"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type (
+	// SymbolOrder identifies how the top-level symbols of a package are
+	// ordered and grouped in its documentation.
+	SymbolOrder string
+
+	// Group holds a set of top-level symbols of a package that are documented
+	// together, in the order they are documented.
+	Group struct {
+		cfg     *Config
+		name    string
+		entries []*GroupEntry
+	}
+
+	// GroupEntry holds a single top-level symbol within a group. Exactly one of
+	// its Value, Func and Type methods returns a non-nil value.
+	GroupEntry struct {
+		value *Value
+		fn    *Func
+		typ   *Type
+		pos   token.Pos
+		order int
+		group string
+	}
+)
+
+const (
+	// AlphabeticalOrder documents the symbols in the order used by the go/doc
+	// package: constants, variables, funcs and types, with funcs and types
+	// sorted by name and values in declaration order. This is the default.
+	AlphabeticalOrder SymbolOrder = "alphabetical"
+
+	// SourceOrder documents the symbols in the order they are declared, with
+	// files ordered by name.
+	SourceOrder SymbolOrder = "source"
+
+	// FileOrder groups the symbols by the file declaring them, with the
+	// symbols of each file in declaration order.
+	FileOrder SymbolOrder = "file"
+
+	// CategoryOrder groups the symbols by the categories assigned to them with
+	// //gomarkdoc:group directives, with the symbols of each category in
+	// declaration order. Categories are documented in the order of their first
+	// symbol, after the symbols without a category.
+	CategoryOrder SymbolOrder = "category"
+)
+
+// ParseSymbolOrder finds the symbol order with the provided name. Valid names
+// are alphabetical, source, file and category.
+func ParseSymbolOrder(name string) (SymbolOrder, error) {
+	switch order := SymbolOrder(name); order {
+	case AlphabeticalOrder, SourceOrder, FileOrder, CategoryOrder:
+		return order, nil
+	default:
+		return "", fmt.Errorf("gomarkdoc: invalid symbol order %q", name)
+	}
+}
+
+// Groups lists the top-level symbols of the package arranged into groups
+// according to the provided order. With AlphabeticalOrder, the constants and
+// variables are grouped under the names "Constants" and "Variables", followed
+// by unnamed groups for the funcs and types. For all other orders, the symbols
+// of each group are sorted by their //gomarkdoc:order directives, with 0 for
+// symbols without one, and then by declaration order. The funcs and methods
+// associated with each type are sorted the same way. Symbols in named groups
+// are documented one level deeper than the symbols of the package.
+func (pkg *Package) Groups(order SymbolOrder) []*Group {
+	if order == "" || order == AlphabeticalOrder {
+		return pkg.alphabeticalGroups()
+	}
+
+	named := pkg.cfg.Inc(2)
+	named.order = order
+	unnamed := pkg.cfg.Inc(1)
+	unnamed.order = order
+
+	var groups []*Group
+	byName := make(map[string]*Group)
+	for _, entry := range pkg.groupEntries(named, unnamed, order) {
+		var name string
+		switch order {
+		case FileOrder:
+			name = filepath.Base(pkg.cfg.FileSet.Position(entry.pos).Filename)
+		case CategoryOrder:
+			name = entry.group
+		}
+
+		group, ok := byName[name]
+		if !ok {
+			group = &Group{pkg.cfg.Inc(1), name, nil}
+			byName[name] = group
+			groups = append(groups, group)
+		}
+
+		group.entries = append(group.entries, entry)
+	}
+
+	if order == FileOrder {
+		sort.SliceStable(groups, func(i, j int) bool { return groups[i].name < groups[j].name })
+	}
+
+	// Symbols without a category are documented directly under the package,
+	// so they need to come before any of the categories
+	if g, ok := byName[""]; ok && order == CategoryOrder {
+		res := []*Group{g}
+		for _, group := range groups {
+			if group != g {
+				res = append(res, group)
+			}
+		}
+
+		groups = res
+	}
+
+	return groups
+}
+
+// Level provides the default level that headers for the group should be
+// rendered.
+func (g *Group) Level() int {
+	return g.cfg.Level
+}
+
+// Name provides the name of the group, such as the name of the file or the
+// category of its symbols. Unnamed groups hold symbols that are documented
+// directly under the package.
+func (g *Group) Name() string {
+	return g.name
+}
+
+// Anchor produces anchor text for the header of a named group. It is empty
+// unless the package is documented in the same output as other packages, as
+// the header would otherwise collide with the headers of the same groups in the
+// other packages.
+func (g *Group) Anchor() string {
+	namespace := g.cfg.anchorNamespace()
+	if namespace == "" || g.name == "" {
+		return ""
+	}
+
+	slug := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return '-'
+	}, g.name)
+
+	// Symbol anchors can't contain dashes, so the two never collide
+	return fmt.Sprintf("%s-%s", namespace, slug)
+}
+
+// Entries lists the symbols in the group in the order they are documented.
+func (g *Group) Entries() []*GroupEntry {
+	return g.entries
+}
+
+// Value provides the const or var declaration of the entry, or nil if the entry
+// is not a const or var declaration.
+func (e *GroupEntry) Value() *Value {
+	return e.value
+}
+
+// Func provides the func of the entry, or nil if the entry is not a func.
+func (e *GroupEntry) Func() *Func {
+	return e.fn
+}
+
+// Type provides the type of the entry, or nil if the entry is not a type.
+func (e *GroupEntry) Type() *Type {
+	return e.typ
+}
+
+func (pkg *Package) alphabeticalGroups() []*Group {
+	var groups []*Group
+	add := func(name string, entries []*GroupEntry) {
+		if len(entries) > 0 {
+			groups = append(groups, &Group{pkg.cfg.Inc(1), name, entries})
+		}
+	}
+
+	var consts, vars, funcs, types []*GroupEntry
+	for _, c := range pkg.Consts() {
+		consts = append(consts, &GroupEntry{value: c})
+	}
+
+	for _, v := range pkg.Vars() {
+		vars = append(vars, &GroupEntry{value: v})
+	}
+
+	for _, fn := range pkg.Funcs() {
+		funcs = append(funcs, &GroupEntry{fn: fn})
+	}
+
+	for _, typ := range pkg.Types() {
+		types = append(types, &GroupEntry{typ: typ})
+	}
+
+	add("Constants", consts)
+	add("Variables", vars)
+	add("", funcs)
+	add("", types)
+
+	return groups
+}
+
+// groupEntries lists all of the top-level symbols of the package sorted by
+// their //gomarkdoc:order directives and declaration order. Symbols that end
+// up in named groups are created with the named config, while the rest use the
+// unnamed config.
+func (pkg *Package) groupEntries(named, unnamed *Config, order SymbolOrder) []*GroupEntry {
+	cfgFor := func(group string) *Config {
+		if order == FileOrder || (order == CategoryOrder && group != "") {
+			return named
+		}
+
+		return unnamed
+	}
+
+	var entries []*GroupEntry
+	for _, values := range [][]*doc.Value{pkg.doc.Consts, pkg.doc.Vars} {
+		for _, v := range values {
+			d := pkg.cfg.directives[v.Names[0]]
+			entries = append(entries, &GroupEntry{
+				value: NewValue(cfgFor(d.group), v),
+				pos:   v.Decl.Pos(),
+				order: d.order,
+				group: d.group,
+			})
+		}
+	}
+
+	for _, fn := range pkg.doc.Funcs {
+		d := pkg.cfg.directives[fn.Name]
+		entries = append(entries, &GroupEntry{
+			fn:    NewFunc(cfgFor(d.group), fn, pkg.examples),
+			pos:   fn.Decl.Pos(),
+			order: d.order,
+			group: d.group,
+		})
+	}
+
+	for _, typ := range pkg.doc.Types {
+		d := pkg.cfg.directives[typ.Name]
+		entries = append(entries, &GroupEntry{
+			typ:   NewType(cfgFor(d.group), typ, pkg.examples),
+			pos:   typ.Decl.Pos(),
+			order: d.order,
+			group: d.group,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].order != entries[j].order {
+			return entries[i].order < entries[j].order
+		}
+
+		return entries[i].pos < entries[j].pos
+	})
+
+	return entries
+}
+
+// orderFuncs sorts the funcs associated with a type the same way as the
+// top-level symbols of the package when they are documented in an order other
+// than AlphabeticalOrder.
+func orderFuncs(cfg *Config, funcs []*Func) []*Func {
+	if cfg.order == "" || cfg.order == AlphabeticalOrder {
+		return funcs
+	}
+
+	sort.SliceStable(funcs, func(i, j int) bool {
+		if oi, oj := funcs[i].Order(), funcs[j].Order(); oi != oj {
+			return oi < oj
+		}
+
+		return funcs[i].doc.Decl.Pos() < funcs[j].doc.Decl.Pos()
+	})
+
+	return funcs
+}
@@ -0,0 +1,125 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/lang"
+)
+
+func TestPackage_Groups_alphabetical(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/ordering")
+	is.NoErr(err)
+
+	groups := pkg.Groups(lang.AlphabeticalOrder)
+	is.Equal(groupNames(groups), []string{"Constants", "Variables", "", ""})
+	is.Equal(entryNames(groups[2]), []string{"LoadEnv", "Retry"})
+	is.Equal(entryNames(groups[3]), []string{"Client", "Config"})
+	is.Equal(groups[0].Level(), 2)
+}
+
+func TestPackage_Groups_source(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/ordering")
+	is.NoErr(err)
+
+	groups := pkg.Groups(lang.SourceOrder)
+	is.Equal(len(groups), 1)
+	is.Equal(groups[0].Name(), "")
+	is.Equal(entryNames(groups[0]), []string{
+		"LoadEnv",
+		"Client",
+		"Version",
+		"Config",
+		"ErrClosed",
+		"Retry",
+		"DefaultTimeout",
+	})
+
+	// Methods of types are sorted by their order and then by source order
+	client := groups[0].Entries()[1].Type()
+	methods := client.Methods()
+	is.Equal(len(methods), 2)
+	is.Equal(methods[0].Name(), "Close")
+	is.Equal(methods[1].Name(), "Send")
+	is.Equal(client.Level(), 2)
+}
+
+func TestPackage_Groups_file(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/ordering")
+	is.NoErr(err)
+
+	groups := pkg.Groups(lang.FileOrder)
+	is.Equal(groupNames(groups), []string{"client.go", "config.go", "errors.go"})
+	is.Equal(entryNames(groups[0]), []string{"Client", "Version"})
+	is.Equal(entryNames(groups[1]), []string{"LoadEnv", "Config", "DefaultTimeout"})
+	is.Equal(entryNames(groups[2]), []string{"ErrClosed", "Retry"})
+
+	// Symbols in named groups are nested under the group's header
+	is.Equal(groups[0].Level(), 2)
+	is.Equal(groups[0].Entries()[0].Type().Level(), 3)
+}
+
+func TestPackage_Groups_category(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/ordering")
+	is.NoErr(err)
+
+	groups := pkg.Groups(lang.CategoryOrder)
+	is.Equal(groupNames(groups), []string{"", "Configuration", "Clients"})
+	is.Equal(entryNames(groups[0]), []string{"Version", "Retry"})
+	is.Equal(entryNames(groups[1]), []string{"LoadEnv", "Config", "DefaultTimeout"})
+	is.Equal(entryNames(groups[2]), []string{"Client", "ErrClosed"})
+
+	is.Equal(groups[0].Entries()[1].Func().Level(), 2)
+	is.Equal(groups[1].Entries()[0].Func().Level(), 3)
+}
+
+func TestParseSymbolOrder(t *testing.T) {
+	is := is.New(t)
+
+	for _, order := range []lang.SymbolOrder{
+		lang.AlphabeticalOrder,
+		lang.SourceOrder,
+		lang.FileOrder,
+		lang.CategoryOrder,
+	} {
+		parsed, err := lang.ParseSymbolOrder(string(order))
+		is.NoErr(err)
+		is.Equal(parsed, order)
+	}
+
+	_, err := lang.ParseSymbolOrder("random")
+	is.True(err != nil) // invalid order accepted
+}
+
+func groupNames(groups []*lang.Group) []string {
+	names := make([]string, len(groups))
+	for i, g := range groups {
+		names[i] = g.Name()
+	}
+
+	return names
+}
+
+func entryNames(group *lang.Group) []string {
+	names := make([]string, len(group.Entries()))
+	for i, e := range group.Entries() {
+		switch {
+		case e.Value() != nil:
+			names[i] = e.Value().Anchor()
+		case e.Func() != nil:
+			names[i] = e.Func().Name()
+		default:
+			names[i] = e.Type().Name()
+		}
+	}
+
+	return names
+}
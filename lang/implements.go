@@ -0,0 +1,162 @@
+package lang
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+)
+
+// TypeRef holds a reference to a type that is related to the one being
+// documented, such as an interface that it implements.
+type TypeRef struct {
+	cfg     *Config
+	name    string
+	href    string
+	pointer bool
+}
+
+// Name provides the name of the referenced type. Types from other packages are
+// qualified by their package name.
+func (r *TypeRef) Name() string {
+	return r.name
+}
+
+// Href provides the href for the documentation of the referenced type, if it
+// has any.
+func (r *TypeRef) Href() string {
+	return r.href
+}
+
+// Pointer identifies whether the relationship only holds for a pointer to the
+// type rather than the type itself. For example, a type whose methods have
+// pointer receivers only implements an interface through a pointer.
+func (r *TypeRef) Pointer() bool {
+	return r.pointer
+}
+
+// implements lists the interfaces documented in the same run that are
+// implemented by the named type or a pointer to it.
+func implements(cfg *Config, name string) []*TypeRef {
+	named := lookupNamed(cfg, name)
+	if named == nil || types.IsInterface(named) || named.TypeParams().Len() > 0 {
+		return nil
+	}
+
+	var refs []*TypeRef
+	for _, obj := range candidateTypes(cfg) {
+		iface, ok := obj.Type().Underlying().(*types.Interface)
+		if !ok || obj.Type() == named || !isImplementable(obj, iface) {
+			continue
+		}
+
+		if types.Implements(named, iface) {
+			refs = append(refs, newTypeRef(cfg, obj, false))
+		} else if types.Implements(types.NewPointer(named), iface) {
+			refs = append(refs, newTypeRef(cfg, obj, true))
+		}
+	}
+
+	return refs
+}
+
+// implementors lists the concrete types documented in the same run that
+// implement the named interface, either directly or through a pointer.
+func implementors(cfg *Config, name string) []*TypeRef {
+	named := lookupNamed(cfg, name)
+	if named == nil {
+		return nil
+	}
+
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok || !isImplementable(named.Obj(), iface) {
+		return nil
+	}
+
+	var refs []*TypeRef
+	for _, obj := range candidateTypes(cfg) {
+		t, ok := obj.Type().(*types.Named)
+		if !ok || types.IsInterface(t) || t.TypeParams().Len() > 0 {
+			continue
+		}
+
+		if types.Implements(t, iface) {
+			refs = append(refs, newTypeRef(cfg, obj, false))
+		} else if types.Implements(types.NewPointer(t), iface) {
+			refs = append(refs, newTypeRef(cfg, obj, true))
+		}
+	}
+
+	return refs
+}
+
+// lookupNamed finds the type checked representation of the type with the
+// provided name in the package being documented, if type information is
+// available.
+func lookupNamed(cfg *Config, name string) *types.Named {
+	if cfg.TypeInfo == nil {
+		return nil
+	}
+
+	obj, ok := cfg.TypeInfo.Package().Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+
+	named, _ := obj.Type().(*types.Named)
+	return named
+}
+
+// candidateTypes lists the types that are considered when looking for
+// implementation relationships. These are the types documented by all packages
+// in the symbol table or, if there is none, the types documented by the
+// current package.
+func candidateTypes(cfg *Config) []*types.TypeName {
+	if cfg.SymbolTable != nil {
+		if res := cfg.SymbolTable.documentedTypes(); len(res) > 0 {
+			return res
+		}
+	}
+
+	return documentedTypes(cfg.TypeInfo.Package(), cfg.Symbols)
+}
+
+// documentedTypes lists the types from the package that have documentation,
+// sorted by name.
+func documentedTypes(pkg *types.Package, symbols map[string]Symbol) []*types.TypeName {
+	var res []*types.TypeName
+	for name, sym := range symbols {
+		if sym.Kind != TypeSymbolKind {
+			continue
+		}
+
+		if obj, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok && !obj.IsAlias() {
+			res = append(res, obj)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Name() < res[j].Name() })
+	return res
+}
+
+// isImplementable identifies whether the interface can be implemented by
+// ordinary types, which excludes empty interfaces, generic interfaces and
+// constraint interfaces with type sets.
+func isImplementable(obj *types.TypeName, iface *types.Interface) bool {
+	if iface.NumMethods() == 0 || !iface.IsMethodSet() {
+		return false
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	return !ok || named.TypeParams().Len() == 0
+}
+
+// newTypeRef creates a reference to the provided type.
+func newTypeRef(cfg *Config, obj *types.TypeName, pointer bool) *TypeRef {
+	name := obj.Name()
+	if obj.Pkg() != cfg.TypeInfo.Package() {
+		name = fmt.Sprintf("%s.%s", obj.Pkg().Name(), name)
+	}
+
+	href, _ := typeInfoHref(cfg, obj.Pkg(), "", obj.Name())
+	return &TypeRef{cfg, name, href, pointer}
+}
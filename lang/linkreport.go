@@ -0,0 +1,186 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	// LinkReport collects the doc links that can't be resolved while the
+	// documentation of a set of packages is rendered, such as links to symbols
+	// that don't exist. It can be shared by all of the packages documented in
+	// the same run.
+	LinkReport struct {
+		mu    sync.Mutex
+		links map[string]UnresolvedLink
+	}
+
+	// UnresolvedLink holds a doc link that couldn't be resolved.
+	UnresolvedLink struct {
+		// Text holds the text of the link without the surrounding brackets,
+		// such as Client.Do or io.Reader.
+		Text string
+
+		// Location holds the location of the line of the documentation
+		// comment that contains the link. If the line can't be found, it
+		// holds the location of the package, symbol or example whose
+		// documentation contains the link instead.
+		Location Location
+	}
+)
+
+// NewLinkReport creates an empty link report.
+func NewLinkReport() *LinkReport {
+	return &LinkReport{links: make(map[string]UnresolvedLink)}
+}
+
+// Links lists the unresolved doc links found so far, sorted by their location.
+// Links that appear in the same documentation several times are only listed
+// once.
+func (r *LinkReport) Links() []UnresolvedLink {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	links := make([]UnresolvedLink, 0, len(r.links))
+	for _, l := range r.links {
+		links = append(links, l)
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		a, b := links[i], links[j]
+		switch {
+		case a.Location.Filepath != b.Location.Filepath:
+			return a.Location.Filepath < b.Location.Filepath
+		case a.Location.Start.Line != b.Location.Start.Line:
+			return a.Location.Start.Line < b.Location.Start.Line
+		default:
+			return a.Text < b.Text
+		}
+	})
+
+	return links
+}
+
+// add records an unresolved link. Documentation can be rendered several times,
+// so links are identified by their text and location.
+func (r *LinkReport) add(link UnresolvedLink) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loc := link.Location
+	key := fmt.Sprintf("%s:%d:%d:%s", loc.Filepath, loc.Start.Line, loc.Start.Col, link.Text)
+	r.links[key] = link
+}
+
+// docConfig provides a copy of the config for rendering the documentation at
+// the provided location, incremented by the provided step. The comment holds
+// the documentation comment that the documentation is read from, if it is
+// known.
+func (c *Config) docConfig(step int, loc Location, comment *ast.CommentGroup) *Config {
+	cfg := c.Inc(step)
+	cfg.docLocation = &loc
+	cfg.docComment = comment
+	return cfg
+}
+
+// precedingComment finds the comment group in the files of the config that
+// ends on the line before the provided node, which holds the documentation
+// comment of a declaration. The doc package removes the documentation comments
+// from the declarations it documents, so they're found by their position
+// instead.
+func (c *Config) precedingComment(node ast.Node) *ast.CommentGroup {
+	pos := c.FileSet.Position(node.Pos())
+	for _, f := range c.Files {
+		if c.FileSet.Position(f.Pos()).Filename != pos.Filename {
+			continue
+		}
+
+		for _, group := range f.Comments {
+			if c.FileSet.Position(group.End()).Line == pos.Line-1 {
+				return group
+			}
+		}
+	}
+
+	return nil
+}
+
+// declaredNames lists the names of all of the symbols declared in the provided
+// source files of the package with the provided name, in the form used to
+// look up symbols (e.g. Type.Method). It includes the symbols that are left
+// out of the documentation, so that links to them can be told apart from links
+// to symbols that don't exist.
+func declaredNames(cfg *Config, name string, files []string) map[string]bool {
+	include := make(map[string]bool)
+	for _, f := range files {
+		include[f] = true
+	}
+
+	declared := make(map[string]bool)
+	for _, f := range cfg.Files {
+		if f.Name.Name != name || !include[filepath.Base(cfg.FileSet.Position(f.Package).Filename)] {
+			continue
+		}
+
+		for _, n := range declaredSymbols(f) {
+			declared[n] = true
+		}
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			for _, s := range gen.Specs {
+				spec, ok := s.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				st, ok := spec.Type.(*ast.StructType)
+				if !ok || st.Fields == nil {
+					continue
+				}
+
+				for _, field := range st.Fields.List {
+					for _, n := range fieldNames(field) {
+						declared[symbolName(spec.Name.Name, n)] = true
+					}
+				}
+			}
+		}
+	}
+
+	return declared
+}
+
+// reportUnresolvedLink records a doc link that couldn't be resolved in the link
+// report of the config. It returns false if there is no link report to record
+// the link in.
+func (c *Config) reportUnresolvedLink(text string) bool {
+	if c.linkReport == nil {
+		return false
+	}
+
+	var loc Location
+	if c.docLocation != nil {
+		loc = *c.docLocation
+	}
+
+	if c.docComment != nil {
+		for _, comment := range c.docComment.List {
+			if strings.Contains(comment.Text, fmt.Sprintf("[%s]", text)) {
+				loc = NewLocation(c, comment)
+				break
+			}
+		}
+	}
+
+	c.linkReport.add(UnresolvedLink{text, loc})
+	return true
+}
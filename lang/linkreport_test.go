@@ -0,0 +1,106 @@
+package lang_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/lang"
+	"github.com/princjef/gomarkdoc/logger"
+)
+
+func TestLinkReport(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/links")
+	is.NoErr(err)
+
+	// The package that the broken link points to is documented in the same
+	// run, but doesn't declare the symbol
+	table := lang.NewSymbolTable()
+	is.NoErr(table.Add("github.com/princjef/gomarkdoc/testData/links", "links/README.md", nil))
+
+	report := lang.NewLinkReport()
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(
+		log,
+		buildPkg,
+		lang.PackageWithLinkReport(report),
+		lang.PackageWithSymbolTable(table, "README.md"),
+	)
+	is.NoErr(err)
+
+	broken, err := findFunc(pkg, "Broken")
+	is.NoErr(err)
+
+	// Rendering the same documentation again doesn't repeat its links
+	broken.Doc().Blocks()
+	broken.Doc().Blocks()
+
+	links := report.Links()
+	is.Equal(len(links), 1)
+
+	is.Equal(links[0].Text, "github.com/princjef/gomarkdoc/testData/links.Missing")
+	is.Equal(filepath.Base(links[0].Location.Filepath), "links.go")
+	is.Equal(links[0].Location.Start.Line, 18) // location of the comment line
+}
+
+func TestLinkReport_filtered(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/links")
+	is.NoErr(err)
+
+	report := lang.NewLinkReport()
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(
+		log,
+		buildPkg,
+		lang.PackageWithLinkReport(report),
+		lang.PackageWithDeprecatedExcluded(),
+		lang.PackageWithSymbolFilter(lang.SymbolKindFilter(nil, []lang.SymbolKind{lang.ConstSymbolKind})),
+	)
+	is.NoErr(err)
+
+	fn, err := findFunc(pkg, "New")
+	is.NoErr(err)
+
+	// Links to symbols that were left out are plain text but aren't reported
+	for _, span := range fn.Doc().Blocks()[0].Spans() {
+		is.Equal(span.Kind(), lang.TextSpan)
+	}
+
+	is.Equal(len(report.Links()), 0)
+
+	// Neither are links to symbols hidden by directives
+	buildPkg, err = getBuildPackage("../testData/directives")
+	is.NoErr(err)
+
+	pkg, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithLinkReport(report))
+	is.NoErr(err)
+
+	typ, err := findType(pkg, "Parser")
+	is.NoErr(err)
+
+	typ.Doc().Blocks()
+	is.Equal(len(report.Links()), 0)
+}
+
+func TestLinkReport_resolved(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/simple")
+	is.NoErr(err)
+
+	report := lang.NewLinkReport()
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithLinkReport(report))
+	is.NoErr(err)
+
+	pkg.Doc().Blocks()
+	for _, typ := range pkg.Types() {
+		typ.Doc().Blocks()
+	}
+
+	is.Equal(len(report.Links()), 0)
+}
@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/princjef/gomarkdoc/logger"
+	"golang.org/x/tools/go/packages"
 )
 
 type (
@@ -30,7 +31,16 @@ type (
 	// and its documentation on creation.
 	PackageOptions struct {
 		includeUnexported   bool
+		excludeDeprecated   bool
 		repositoryOverrides *Repo
+		symbolTable         *SymbolTable
+		outputFile          string
+		docLinks            *DocLinks
+		buildMatrix         *BuildMatrix
+		matrixPackages      []*packages.Package
+		symbolFilter        func(Symbol) bool
+		versionHistory      *VersionHistory
+		linkReport          *LinkReport
 	}
 
 	// PackageOption configures one or more options for the package.
@@ -40,7 +50,7 @@ type (
 // NewPackage creates a representation of a package's documentation from the
 // raw documentation constructs provided by the standard library. This is only
 // recommended for advanced scenarios. Most consumers will find it easier to use
-// NewPackageFromBuild instead.
+// NewPackageFromPackages or NewPackageFromBuild instead.
 func NewPackage(cfg *Config, examples []*doc.Example) *Package {
 	return &Package{cfg, cfg.Pkg, examples}
 }
@@ -49,39 +59,189 @@ func NewPackage(cfg *Config, examples []*doc.Example) *Package {
 // from the build metadata for that package. It can be configured using the
 // provided options.
 func NewPackageFromBuild(log logger.Logger, pkg *build.Package, opts ...PackageOption) (*Package, error) {
-	var options PackageOptions
-	for _, opt := range opts {
-		if err := opt(&options); err != nil {
-			return nil, err
+	importPath := pkg.ImportPath
+	if pkg.ImportComment != "" {
+		importPath = pkg.ImportComment
+	}
+
+	if importPath == "." {
+		if modPath, ok := findImportPath(pkg.Dir); ok {
+			importPath = modPath
 		}
 	}
 
+	files := make([]string, 0, len(pkg.GoFiles)+len(pkg.CgoFiles))
+	files = append(files, pkg.GoFiles...)
+	files = append(files, pkg.CgoFiles...)
+
+	return newPackageFromFiles(log, pkg.Dir, pkg.Name, importPath, files, nil, opts...)
+}
+
+// NewPackageFromPackages creates a representation of a package's documentation
+// from a package loaded with the golang.org/x/tools/go/packages library. Unlike
+// NewPackageFromBuild, packages loaded this way are resolved using the full
+// module graph, including go.work files, vendor directories and the -mod build
+// flag. The package must have been loaded with at least the packages.NeedName
+// and packages.NeedFiles modes. It can be configured using the provided
+// options.
+//
+// If the package was also loaded with the packages.NeedTypes and
+// packages.NeedTypesInfo modes, identifiers in signatures and declarations are
+// linked to the documentation for the symbols they refer to. See
+// PackageWithMatrixPackages for linking identifiers in code that is only built
+// under some of the contexts of a build matrix.
+func NewPackageFromPackages(log logger.Logger, pkg *packages.Package, opts ...PackageOption) (*Package, error) {
+	if len(pkg.GoFiles) == 0 {
+		return nil, fmt.Errorf("gomarkdoc: no source-code package found for %s", pkg.ID)
+	}
+
+	options, err := newPackageOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, len(pkg.GoFiles))
+	for i, f := range pkg.GoFiles {
+		files[i] = filepath.Base(f)
+	}
+
+	var typeInfo *TypeInfo
+	for _, p := range append([]*packages.Package{pkg}, options.matrixPackages...) {
+		if p.Types == nil || p.TypesInfo == nil {
+			continue
+		}
+
+		info := NewTypeInfo(p.Fset, p.Types, p.TypesInfo)
+		if typeInfo == nil {
+			typeInfo = info
+		} else {
+			typeInfo.merge(info)
+		}
+	}
+
+	return newPackageFromFiles(log, filepath.Dir(pkg.GoFiles[0]), pkg.Name, pkg.PkgPath, files, typeInfo, opts...)
+}
+
+func newPackageFromFiles(
+	log logger.Logger,
+	dir, name, importPath string,
+	files []string,
+	typeInfo *TypeInfo,
+	opts ...PackageOption,
+) (*Package, error) {
+	options, err := newPackageOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	wd, err := os.Getwd()
 	if err != nil {
 		return nil, err
 	}
 
-	cfg, err := NewConfig(log, wd, pkg.Dir, ConfigWithRepoOverrides(options.repositoryOverrides))
+	cfg, err := NewConfig(log, wd, dir, ConfigWithRepoOverrides(options.repositoryOverrides))
 	if err != nil {
 		return nil, err
 	}
 
-	cfg.Pkg, err = getDocPkg(pkg, cfg.FileSet, options.includeUnexported)
+	if options.buildMatrix != nil {
+		cfg.availability, err = newAvailability(cfg, options.buildMatrix)
+		if err != nil {
+			return nil, err
+		}
+
+		// Document the symbols from the files included under any of the
+		// contexts instead of just the ones the package was loaded with
+		files = cfg.availability.fileNames()
+	}
+
+	docPkg, err := getDocPkg(dir, name, importPath, files, cfg.FileSet, options.includeUnexported)
 	if err != nil {
 		return nil, err
 	}
 
+	return newPackageFromDoc(cfg, docPkg, name, importPath, files, typeInfo, options)
+}
+
+func newPackageOptions(opts []PackageOption) (PackageOptions, error) {
+	var options PackageOptions
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return options, err
+		}
+	}
+
+	return options, nil
+}
+
+// newPackageFromDoc completes the documentation for a package from the
+// documentation produced by the doc package for the provided files, which
+// must also have been parsed into the Files of cfg.
+func newPackageFromDoc(
+	cfg *Config,
+	docPkg *doc.Package,
+	name, importPath string,
+	files []string,
+	typeInfo *TypeInfo,
+	options PackageOptions,
+) (*Package, error) {
+	cfg.Pkg = docPkg
+	cfg.sourceFiles = files
+	cfg.directives = newDirectives(cfg, name, files)
+	cfg.declared = declaredNames(cfg, name, files)
+	stripPackageDirectives(cfg.Pkg)
+
+	if cfg.hasHidden() {
+		filterSymbols(cfg.Pkg, cfg.isVisible)
+	}
+
+	if options.excludeDeprecated {
+		removeDeprecated(cfg.Pkg)
+	}
+
+	if options.versionHistory != nil {
+		since, err := options.versionHistory.symbols(cfg.PkgDir, name)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.since = since
+	}
+
+	if options.symbolFilter != nil {
+		filterSymbols(cfg.Pkg, options.symbolFilter)
+	}
+
 	sym := PackageSymbols(cfg.Pkg)
+	linkExampleOnlyFields(cfg, sym)
 	cfg.Symbols = sym
+	cfg.TypeInfo = typeInfo
+	cfg.DocLinks = options.docLinks
+	cfg.linkReport = options.linkReport
 
-	examples := doc.Examples(cfg.Files...)
+	if options.symbolTable != nil {
+		if err := options.symbolTable.Add(importPath, options.outputFile, sym); err != nil {
+			return nil, err
+		}
+
+		options.symbolTable.addName(importPath, name)
+		options.symbolTable.addDeclared(importPath, cfg.declared)
+		if typeInfo != nil {
+			options.symbolTable.addTypes(importPath, typeInfo.Package())
+		}
+
+		cfg.SymbolTable = options.symbolTable
+		cfg.OutputFile = options.outputFile
+	}
+
+	examples := doc.Examples(exampleFiles(cfg, name)...)
 
 	return NewPackage(cfg, examples), nil
 }
 
 // PackageWithUnexportedIncluded can be used along with the NewPackageFromBuild
-// function to specify that all symbols, including unexported ones, should be
-// included in the documentation for the package.
+// and NewPackageFromPackages functions to specify that all symbols, including
+// unexported ones, should be included in the documentation for the package.
 func PackageWithUnexportedIncluded() PackageOption {
 	return func(opts *PackageOptions) error {
 		opts.includeUnexported = true
@@ -89,9 +249,55 @@ func PackageWithUnexportedIncluded() PackageOption {
 	}
 }
 
+// PackageWithDeprecatedExcluded can be used along with the NewPackageFromBuild
+// and NewPackageFromPackages functions to specify that deprecated symbols
+// should be left out of the documentation for the package. Links to the
+// excluded symbols are rendered as plain text.
+func PackageWithDeprecatedExcluded() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.excludeDeprecated = true
+		return nil
+	}
+}
+
+// PackageWithSymbolFilter can be used along with the NewPackageFromBuild and
+// NewPackageFromPackages functions to choose which of the package's symbols
+// are documented. Only symbols for which the filter returns true are included.
+// Providing the option several times includes only the symbols that all of the
+// filters return true for. Links to the excluded symbols are rendered as plain
+// text. See SymbolPatternFilter and SymbolKindFilter for common filters.
+func PackageWithSymbolFilter(filter func(Symbol) bool) PackageOption {
+	return func(opts *PackageOptions) error {
+		if prev := opts.symbolFilter; prev != nil {
+			opts.symbolFilter = func(sym Symbol) bool {
+				return prev(sym) && filter(sym)
+			}
+
+			return nil
+		}
+
+		opts.symbolFilter = filter
+		return nil
+	}
+}
+
+// PackageWithMatrixPackages can be used along with the NewPackageFromPackages
+// function and PackageWithBuildMatrix when the package was loaded separately
+// under each of the contexts of the build matrix. The provided packages are
+// the package as loaded under the contexts other than the one it was passed to
+// NewPackageFromPackages with. Their type information is combined with that of
+// the package, so identifiers in code that is only built under some of the
+// contexts are linked as well.
+func PackageWithMatrixPackages(pkgs ...*packages.Package) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.matrixPackages = append(opts.matrixPackages, pkgs...)
+		return nil
+	}
+}
+
 // PackageWithRepositoryOverrides can be used along with the NewPackageFromBuild
-// function to define manual overrides to the automatic repository detection
-// logic.
+// and NewPackageFromPackages functions to define manual overrides to the
+// automatic repository detection logic.
 func PackageWithRepositoryOverrides(repo *Repo) PackageOption {
 	return func(opts *PackageOptions) error {
 		opts.repositoryOverrides = repo
@@ -99,6 +305,70 @@ func PackageWithRepositoryOverrides(repo *Repo) PackageOption {
 	}
 }
 
+// PackageWithSymbolTable can be used along with the NewPackageFromBuild and
+// NewPackageFromPackages functions to register the package's symbols in a
+// symbol table shared with other packages documented in the same run. The
+// outputFile is the file that the package's documentation will be written to,
+// or an empty string if it is not written to a file. Links to symbols in other
+// packages in the table point to their generated documentation instead of
+// pkg.go.dev. Links are only resolved for packages that were added to the
+// table before the documentation is rendered.
+func PackageWithSymbolTable(table *SymbolTable, outputFile string) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.symbolTable = table
+		opts.outputFile = outputFile
+		return nil
+	}
+}
+
+// PackageWithDocLinks can be used along with the NewPackageFromBuild and
+// NewPackageFromPackages functions to customize the URLs used for links to
+// the documentation of other packages. Packages with an import path matching
+// one of the provided rules link to the URL produced by the rule's template,
+// while all other packages link to pkg.go.dev.
+func PackageWithDocLinks(links *DocLinks) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.docLinks = links
+		return nil
+	}
+}
+
+// PackageWithBuildMatrix can be used along with the NewPackageFromBuild and
+// NewPackageFromPackages functions to document the package under each of the
+// build contexts of the provided matrix at once. The documentation includes
+// the symbols from the files included under any of the contexts, and symbols
+// that are only available under some of the contexts describe which ones
+// through their Availability methods.
+func PackageWithBuildMatrix(matrix *BuildMatrix) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.buildMatrix = matrix
+		return nil
+	}
+}
+
+// PackageWithVersionHistory can be used along with the NewPackageFromBuild and
+// NewPackageFromPackages functions to find the release in which each of the
+// package's symbols was added using the provided version history. The release
+// is available through the Since methods of the package's funcs and types.
+func PackageWithVersionHistory(history *VersionHistory) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.versionHistory = history
+		return nil
+	}
+}
+
+// PackageWithLinkReport can be used along with the NewPackageFromBuild and
+// NewPackageFromPackages functions to collect the doc links in the package's
+// documentation that can't be resolved in the provided report instead of
+// logging a warning for each of them. Links are collected as the documentation
+// is rendered.
+func PackageWithLinkReport(report *LinkReport) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.linkReport = report
+		return nil
+	}
+}
+
 // Level provides the default level that headers for the package's root
 // documentation should be rendered.
 func (pkg *Package) Level() int {
@@ -122,6 +392,13 @@ func (pkg *Package) Name() string {
 	return pkg.doc.Name
 }
 
+// Anchor produces anchor text for the header of the package. It is empty
+// unless the package is documented in the same output as other packages, as
+// links to the package otherwise go to the top of the output.
+func (pkg *Package) Anchor() string {
+	return pkg.cfg.anchorNamespace()
+}
+
 // Import provides the raw text for the import declaration that is used to
 // import code from the package. If your package's documentation is generated
 // from a local path and does not use Go Modules, this will typically print
@@ -147,7 +424,26 @@ func (pkg *Package) Summary() string {
 // package.
 func (pkg *Package) Doc() *Doc {
 	// TODO: level should only be + 1, but we have special knowledge for rendering
-	return NewDoc(pkg.cfg.Inc(2), pkg.doc.Doc)
+	loc, comment := pkg.docComment()
+	return NewDoc(pkg.cfg.docConfig(2, loc, comment), pkg.doc.Doc)
+}
+
+// docComment finds the package's documentation comment and its location. If
+// the package has no documentation comment, the location of its directory is
+// provided instead.
+func (pkg *Package) docComment() (Location, *ast.CommentGroup) {
+	include := make(map[string]bool)
+	for _, f := range pkg.cfg.sourceFiles {
+		include[f] = true
+	}
+
+	for _, f := range pkg.cfg.Files {
+		if f.Doc != nil && include[filepath.Base(pkg.cfg.FileSet.Position(f.Pos()).Filename)] {
+			return NewLocation(pkg.cfg, f.Doc), f.Doc
+		}
+	}
+
+	return Location{Filepath: pkg.cfg.PkgDir, WorkDir: pkg.cfg.WorkDir, Repo: pkg.cfg.Repo}, nil
 }
 
 // Consts lists the top-level constants provided by the package.
@@ -275,19 +571,18 @@ func findFileInParent(dir, filename string, fileIsDir bool) (*os.File, bool) {
 	return nil, false
 }
 
-func getDocPkg(pkg *build.Package, fs *token.FileSet, includeUnexported bool) (*doc.Package, error) {
+func getDocPkg(
+	dir, name, importPath string,
+	files []string,
+	fs *token.FileSet,
+	includeUnexported bool,
+) (*doc.Package, error) {
 	pkgs, err := parser.ParseDir(
 		fs,
-		pkg.Dir,
+		dir,
 		func(info os.FileInfo) bool {
-			for _, name := range pkg.GoFiles {
-				if name == info.Name() {
-					return true
-				}
-			}
-
-			for _, name := range pkg.CgoFiles {
-				if name == info.Name() {
+			for _, f := range files {
+				if f == info.Name() {
 					return true
 				}
 			}
@@ -301,30 +596,44 @@ func getDocPkg(pkg *build.Package, fs *token.FileSet, includeUnexported bool) (*
 		return nil, fmt.Errorf("gomarkdoc: failed to parse package: %w", err)
 	}
 
-	if len(pkgs) == 0 {
-		return nil, fmt.Errorf("gomarkdoc: no source-code package in directory %s", pkg.Dir)
-	}
-
-	if len(pkgs) > 1 {
-		return nil, fmt.Errorf("gomarkdoc: multiple packages in directory %s", pkg.Dir)
+	// Other packages in the directory, such as main packages excluded by build
+	// constraints, are ignored
+	astPkg, ok := pkgs[name]
+	if !ok {
+		return nil, fmt.Errorf("gomarkdoc: no source-code package in directory %s", dir)
 	}
 
-	astPkg := pkgs[pkg.Name]
+	return newDocPkg(astPkg, importPath, includeUnexported), nil
+}
 
-	if !includeUnexported {
-		ast.PackageExports(astPkg)
+func newDocPkg(astPkg *ast.Package, importPath string, includeUnexported bool) *doc.Package {
+	// Filtering is left to the doc package so that the package's imports are
+	// retained for resolving links to other packages.
+	var mode doc.Mode
+	if includeUnexported {
+		mode = doc.AllDecls
 	}
 
-	importPath := pkg.ImportPath
-	if pkg.ImportComment != "" {
-		importPath = pkg.ImportComment
-	}
+	return doc.New(astPkg, importPath, mode)
+}
 
-	if importPath == "." {
-		if modPath, ok := findImportPath(pkg.Dir); ok {
-			importPath = modPath
+// exampleFiles selects the test files containing the examples for the package
+// with the provided name from the files in the package's directory. This
+// includes the files of both the package itself and its external test package
+// (i.e. the package with the _test suffix). Files belonging to any other
+// package in the directory are left out.
+func exampleFiles(cfg *Config, name string) []*ast.File {
+	var files []*ast.File
+	for _, f := range cfg.Files {
+		filename := cfg.FileSet.Position(f.Package).Filename
+		if !strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+
+		if f.Name.Name == name || f.Name.Name == name+"_test" {
+			files = append(files, f)
 		}
 	}
 
-	return doc.New(astPkg, importPath, doc.AllDecls), nil
+	return files
 }
@@ -1,15 +1,18 @@
 package lang_test
 
 import (
+	"errors"
 	"go/build"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/matryer/is"
 	"github.com/princjef/gomarkdoc/lang"
 	"github.com/princjef/gomarkdoc/logger"
+	"golang.org/x/tools/go/packages"
 )
 
 func TestPackage_Consts(t *testing.T) {
@@ -43,6 +46,54 @@ func TestPackage_Vars(t *testing.T) {
 	is.Equal(decl, `var Variable = 5`)
 }
 
+func TestPackage_deprecatedExcluded(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/deprecated")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithDeprecatedExcluded())
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Consts()), 1)
+
+	vars := pkg.Vars()
+	is.Equal(len(vars), 1) // associated with Client, but not deprecated itself
+	is.Equal(vars[0].Anchor(), "DefaultClient")
+
+	var funcs []string
+	for _, fn := range pkg.Funcs() {
+		funcs = append(funcs, fn.Name())
+	}
+
+	is.Equal(funcs, []string{"NewClient", "Run"}) // constructor of Client is kept
+
+	types := pkg.Types()
+	is.Equal(len(types), 2) // Client and its methods are removed
+	is.Equal(types[0].Name(), "Mode")
+	is.Equal(types[1].Name(), "Settings")
+
+	consts := types[0].Consts()
+	is.Equal(len(consts), 1)
+
+	decl, err := consts[0].Decl()
+	is.NoErr(err)
+	is.True(!strings.Contains(decl, "ModeLegacy")) // deprecated spec of the group
+	is.True(strings.Contains(decl, "ModeCurrent Mode"))
+
+	methods := types[1].Methods()
+	is.Equal(len(methods), 1)
+	is.Equal(methods[0].Name(), "Apply")
+
+	var fields []string
+	for _, f := range types[1].Fields() {
+		fields = append(fields, f.Name())
+	}
+
+	is.Equal(fields, []string{"Name", "Timeout"})
+}
+
 func TestPackage_dotImport(t *testing.T) {
 	is := is.New(t)
 
@@ -156,6 +207,129 @@ func TestPackage_encoding(t *testing.T) {
 	is.Equal(len(pkg.Examples()), 0) // encoding should have no top-level examples
 }
 
+func TestPackage_fromPackages(t *testing.T) {
+	is := is.New(t)
+
+	loaded, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedModule,
+	}, "../testData/lang/function")
+	is.NoErr(err)
+	is.Equal(len(loaded), 1)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromPackages(log, loaded[0])
+	is.NoErr(err)
+
+	is.Equal(pkg.Name(), "function")
+	is.Equal(pkg.Dirname(), "function")
+	is.Equal(pkg.ImportPath(), `github.com/princjef/gomarkdoc/testData/lang/function`)
+	is.Equal(len(pkg.Consts()), 1)
+	is.Equal(len(pkg.Vars()), 1)
+	is.True(len(pkg.Funcs()) > 0)
+	is.True(len(pkg.Types()) > 0)
+}
+
+func TestPackage_fromPackagesNoFiles(t *testing.T) {
+	is := is.New(t)
+
+	loaded, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles,
+	}, "../testData/lang")
+	is.NoErr(err)
+	is.Equal(len(loaded), 1)
+
+	log := logger.New(logger.ErrorLevel)
+	_, err = lang.NewPackageFromPackages(log, loaded[0])
+	is.True(err != nil) // directory has no Go files
+}
+
+func TestPackage_multiplePackages(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := loadPackage("../testData/multipkg")
+	is.NoErr(err)
+
+	typedPkg, err := loadTypedPackage("../testData/multipkg")
+	is.NoErr(err)
+
+	for _, pkg := range []*lang.Package{buildPkg, typedPkg} {
+		is.Equal(pkg.Name(), "multipkg")
+
+		// The example in the generator is left out
+		examples := pkg.Examples()
+		is.Equal(len(examples), 1)
+		is.True(examples[0].Runnable()) // from the external test package
+
+		typ, err := findType(pkg, "Counter")
+		is.NoErr(err)
+
+		methods := typ.Methods()
+		is.Equal(len(methods), 2)
+
+		is.Equal(methods[0].Name(), "Count")
+		is.Equal(len(methods[0].Examples()), 1)
+		is.True(!methods[0].Examples()[0].Runnable()) // from the package itself
+
+		is.Equal(methods[1].Name(), "Inc")
+		is.Equal(len(methods[1].Examples()), 1)
+		is.True(methods[1].Examples()[0].Runnable())
+	}
+}
+
+func loadTypedPackage(dir string) (*lang.Package, error) {
+	loaded, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedTypes | packages.NeedTypesInfo |
+			packages.NeedSyntax | packages.NeedImports | packages.NeedDeps,
+	}, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(loaded) != 1 {
+		return nil, errors.New("expected a single package")
+	}
+
+	log := logger.New(logger.ErrorLevel)
+	return lang.NewPackageFromPackages(log, loaded[0])
+}
+
+// loadTypedPackages loads the packages in the provided directories together,
+// sharing a symbol table between them as if they were documented in the same
+// run with a README.md in each directory. The packages are returned sorted by
+// import path.
+func loadTypedPackages(dirs ...string) ([]*lang.Package, error) {
+	loaded, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedTypes | packages.NeedTypesInfo |
+			packages.NeedSyntax | packages.NeedImports | packages.NeedDeps,
+	}, dirs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(loaded) != len(dirs) {
+		return nil, errors.New("unexpected number of packages")
+	}
+
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i].PkgPath < loaded[j].PkgPath })
+
+	log := logger.New(logger.ErrorLevel)
+	table := lang.NewSymbolTable()
+	pkgs := make([]*lang.Package, len(loaded))
+	for i, l := range loaded {
+		if len(l.GoFiles) == 0 {
+			return nil, errors.New("no go files found")
+		}
+
+		outputFile := filepath.Join(filepath.Dir(l.GoFiles[0]), "README.md")
+		pkgs[i], err = lang.NewPackageFromPackages(log, l, lang.PackageWithSymbolTable(table, outputFile))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pkgs, nil
+}
+
 func getBuildPackage(path string) (*build.Package, error) {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -0,0 +1,499 @@
+package lang
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+type (
+	// Inherited holds the methods and fields that a type inherits from one of
+	// the types embedded within it, either directly or through another
+	// embedded type.
+	Inherited struct {
+		cfg     *Config
+		origin  string
+		href    string
+		methods []*Promoted
+		fields  []*Promoted
+	}
+
+	// Promoted holds information about a method or field that is promoted to
+	// a type from one of the types embedded within it.
+	Promoted struct {
+		cfg       *Config
+		kind      SymbolKind
+		name      string
+		origin    string
+		href      string
+		signature string
+	}
+
+	// promotedMember is a method or field found while walking the embedded
+	// types of a type that may be promoted, along with its depth.
+	promotedMember struct {
+		name   string
+		depth  int
+		origin *Inherited
+		member *Promoted
+	}
+)
+
+// Origin provides the name of the type that declares the inherited methods and
+// fields. Types from other packages are qualified by their package name.
+func (i *Inherited) Origin() string {
+	return i.origin
+}
+
+// Href provides the href for the documentation of the type that declares the
+// inherited methods and fields, if it has any.
+func (i *Inherited) Href() string {
+	return i.href
+}
+
+// Methods lists the methods inherited from the origin type, sorted by name.
+func (i *Inherited) Methods() []*Promoted {
+	return i.methods
+}
+
+// Fields lists the fields inherited from the origin type, sorted by name.
+func (i *Inherited) Fields() []*Promoted {
+	return i.fields
+}
+
+// Kind identifies whether the promoted symbol is a method or field.
+func (p *Promoted) Kind() SymbolKind {
+	return p.kind
+}
+
+// Name provides the name of the promoted method or field.
+func (p *Promoted) Name() string {
+	return p.name
+}
+
+// Origin provides the name of the type that declares the method or field.
+// Types from other packages are qualified by their package name.
+func (p *Promoted) Origin() string {
+	return p.origin
+}
+
+// Href provides the href for the documentation of the method or field within
+// the type that declares it, if it has any.
+func (p *Promoted) Href() string {
+	return p.href
+}
+
+// Signature provides the signature of the promoted method, or the name and type
+// of the promoted field.
+func (p *Promoted) Signature() string {
+	return p.signature
+}
+
+// inheritedMembers finds the methods and fields promoted to the type with the
+// provided documentation from its embedded types, grouped by the type that
+// declares them. Methods that are already part of the type's documentation are
+// excluded. Type information is used if available, which allows types embedded
+// from other packages to be resolved. Otherwise, only types embedded from the
+// same package are considered.
+func inheritedMembers(cfg *Config, typ *doc.Type) []*Inherited {
+	declared := make(map[string]bool)
+	for _, m := range typ.Methods {
+		declared[m.Name] = true
+	}
+
+	if cfg.TypeInfo != nil {
+		return typeInfoInherited(cfg, typ.Name, declared)
+	}
+
+	return astInherited(cfg, typ, declared)
+}
+
+// typeInfoInherited finds the promoted methods and fields for the named type
+// using the type checked representation of the package.
+func typeInfoInherited(cfg *Config, name string, declared map[string]bool) []*Inherited {
+	pkg := cfg.TypeInfo.Package()
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+
+	qualifier := func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+
+		return p.Name()
+	}
+
+	var groups []*Inherited
+	origins := make(map[*types.TypeName]*Inherited)
+	getOrigin := func(t types.Type) *Inherited {
+		n := namedType(t)
+		if n == nil {
+			return nil
+		}
+
+		o := n.Origin().Obj()
+		if g, ok := origins[o]; ok {
+			return g
+		}
+
+		origin := o.Name()
+		if q := qualifier(o.Pkg()); q != "" {
+			origin = fmt.Sprintf("%s.%s", q, origin)
+		}
+
+		g := &Inherited{cfg: cfg, origin: origin}
+		if href, ok := typeInfoHref(cfg, o.Pkg(), "", o.Name()); ok {
+			g.href = href
+		}
+
+		origins[o] = g
+		groups = append(groups, g)
+		return g
+	}
+
+	// Walk the embedded fields breadth first so that origins are ordered by
+	// depth, then by the order in which they are embedded.
+	var (
+		level = []types.Type{named}
+		seen  = map[*types.Named]bool{named: true}
+	)
+	for len(level) > 0 {
+		var next []types.Type
+		for _, t := range level {
+			if t != named {
+				getOrigin(t)
+			}
+
+			st, ok := t.Underlying().(*types.Struct)
+			if !ok {
+				continue
+			}
+
+			for i := 0; i < st.NumFields(); i++ {
+				f := st.Field(i)
+				if t != named && f.Exported() && isPromotedField(named, pkg, f) {
+					if g := getOrigin(t); g != nil {
+						g.fields = append(g.fields, &Promoted{
+							cfg:       cfg,
+							kind:      FieldSymbolKind,
+							name:      f.Name(),
+							origin:    g.origin,
+							href:      typeInfoMemberHref(cfg, t, f.Name()),
+							signature: fmt.Sprintf("%s %s", f.Name(), types.TypeString(f.Type(), qualifier)),
+						})
+					}
+				}
+
+				if !f.Embedded() {
+					continue
+				}
+
+				if n := namedType(f.Type()); n != nil && !seen[n] {
+					seen[n] = true
+					next = append(next, n)
+				}
+			}
+		}
+
+		level = next
+	}
+
+	mset := types.NewMethodSet(types.NewPointer(named))
+	for i := 0; i < mset.Len(); i++ {
+		sel := mset.At(i)
+		fn, ok := sel.Obj().(*types.Func)
+		if !ok || len(sel.Index()) < 2 || !fn.Exported() || declared[fn.Name()] {
+			continue
+		}
+
+		sig := fn.Type().(*types.Signature)
+		g := getOrigin(sig.Recv().Type())
+		if g == nil {
+			continue
+		}
+
+		g.methods = append(g.methods, &Promoted{
+			cfg:       cfg,
+			kind:      MethodSymbolKind,
+			name:      fn.Name(),
+			origin:    g.origin,
+			href:      typeInfoMemberHref(cfg, sig.Recv().Type(), fn.Name()),
+			signature: methodSignature(fn, sig, qualifier),
+		})
+	}
+
+	return finalizeInherited(groups)
+}
+
+// isPromotedField identifies whether the provided field is accessible as a
+// promoted field of the named type, i.e. it is not shadowed by another field
+// or method and is not ambiguous.
+func isPromotedField(named *types.Named, pkg *types.Package, f *types.Var) bool {
+	obj, index, _ := types.LookupFieldOrMethod(named, true, pkg, f.Name())
+	return obj == f && len(index) > 1
+}
+
+// namedType finds the named type that the provided type refers to, following
+// a pointer if necessary.
+func namedType(t types.Type) *types.Named {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	n, _ := t.(*types.Named)
+	return n
+}
+
+// typeInfoMemberHref produces the href for the documentation of the method or
+// field with the provided name declared by the provided type.
+func typeInfoMemberHref(cfg *Config, t types.Type, name string) string {
+	n := namedType(t)
+	if n == nil {
+		return ""
+	}
+
+	o := n.Origin().Obj()
+	href, _ := typeInfoHref(cfg, o.Pkg(), o.Name(), name)
+	return href
+}
+
+// methodSignature prints the signature of the provided method in the same form
+// as it is declared.
+func methodSignature(fn *types.Func, sig *types.Signature, qualifier types.Qualifier) string {
+	var b bytes.Buffer
+	b.WriteString("func ")
+
+	if recv := sig.Recv(); recv != nil {
+		b.WriteString("(")
+		if recv.Name() != "" && recv.Name() != "_" {
+			b.WriteString(recv.Name())
+			b.WriteString(" ")
+		}
+
+		b.WriteString(types.TypeString(recv.Type(), qualifier))
+		b.WriteString(") ")
+	}
+
+	b.WriteString(fn.Name())
+	types.WriteSignature(&b, sig, qualifier)
+	return b.String()
+}
+
+// astInherited finds the promoted methods and fields for the provided type
+// using only the documentation for the package. Only types embedded from the
+// same package are considered.
+func astInherited(cfg *Config, typ *doc.Type, declared map[string]bool) []*Inherited {
+	docTypes := make(map[string]*doc.Type, len(cfg.Pkg.Types))
+	for _, t := range cfg.Pkg.Types {
+		docTypes[t.Name] = t
+	}
+
+	var (
+		groups  []*Inherited
+		members []promotedMember
+		level   = []*doc.Type{typ}
+		seen    = map[string]bool{typ.Name: true}
+		printFs = token.NewFileSet()
+	)
+	for depth := 0; len(level) > 0; depth++ {
+		var next []*doc.Type
+		for _, t := range level {
+			var g *Inherited
+			if depth > 0 {
+				g = &Inherited{cfg: cfg, origin: t.Name}
+				if sym, ok := cfg.Symbols[t.Name]; ok {
+					g.href = fmt.Sprintf("#%s", cfg.anchor(sym))
+				}
+
+				groups = append(groups, g)
+			}
+
+			for _, m := range t.Methods {
+				var p *Promoted
+				if g != nil {
+					sig, err := printNode(m.Decl, printFs)
+					if err != nil {
+						cfg.Log.Debugf("unable to print promoted method %s.%s: %s", t.Name, m.Name, err)
+						continue
+					}
+
+					p = &Promoted{
+						cfg:       cfg,
+						kind:      MethodSymbolKind,
+						name:      m.Name,
+						origin:    t.Name,
+						href:      localMemberHref(cfg, t.Name, m.Name),
+						signature: sig,
+					}
+				}
+
+				members = append(members, promotedMember{m.Name, depth, g, p})
+			}
+
+			for _, f := range structFields(t) {
+				if f.Names == nil {
+					name := embeddedTypeName(f.Type)
+					if name == "" {
+						continue
+					}
+
+					var p *Promoted
+					if g != nil {
+						p = astPromotedField(cfg, t.Name, name, f.Type, printFs)
+					}
+
+					members = append(members, promotedMember{name, depth, g, p})
+
+					if e, ok := docTypes[name]; ok && !seen[name] {
+						seen[name] = true
+						next = append(next, e)
+					}
+
+					continue
+				}
+
+				for _, n := range f.Names {
+					var p *Promoted
+					if g != nil && n.IsExported() {
+						p = astPromotedField(cfg, t.Name, n.Name, f.Type, printFs)
+					}
+
+					members = append(members, promotedMember{n.Name, depth, g, p})
+				}
+			}
+		}
+
+		level = next
+	}
+
+	// A member is only promoted if no other member with the same name exists
+	// at a shallower depth and it is the only one at its own depth.
+	counts := make(map[string]map[int]int)
+	for _, m := range members {
+		if counts[m.name] == nil {
+			counts[m.name] = make(map[int]int)
+		}
+
+		counts[m.name][m.depth]++
+	}
+
+	for _, m := range members {
+		if m.member == nil || declared[m.name] || counts[m.name][m.depth] > 1 {
+			continue
+		}
+
+		shadowed := false
+		for d := range counts[m.name] {
+			if d < m.depth {
+				shadowed = true
+				break
+			}
+		}
+
+		if shadowed {
+			continue
+		}
+
+		if m.member.kind == MethodSymbolKind {
+			m.origin.methods = append(m.origin.methods, m.member)
+		} else {
+			m.origin.fields = append(m.origin.fields, m.member)
+		}
+	}
+
+	return finalizeInherited(groups)
+}
+
+// astPromotedField creates a promoted field from its declaration.
+func astPromotedField(cfg *Config, origin, name string, typ ast.Expr, fs *token.FileSet) *Promoted {
+	t, err := printNode(typ, fs)
+	if err != nil {
+		cfg.Log.Debugf("unable to print promoted field %s.%s: %s", origin, name, err)
+		return nil
+	}
+
+	return &Promoted{
+		cfg:       cfg,
+		kind:      FieldSymbolKind,
+		name:      name,
+		origin:    origin,
+		href:      localMemberHref(cfg, origin, name),
+		signature: fmt.Sprintf("%s %s", name, t),
+	}
+}
+
+// localMemberHref produces the href for the documentation of a method or field
+// of a type in the same package.
+func localMemberHref(cfg *Config, recv, name string) string {
+	sym, ok := cfg.Symbols[symbolName(recv, name)]
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("#%s", cfg.anchor(sym))
+}
+
+// structFields lists the fields declared by the type, if it is a struct.
+func structFields(t *doc.Type) []*ast.Field {
+	for _, s := range t.Decl.Specs {
+		spec, ok := s.(*ast.TypeSpec)
+		if !ok || spec.Name.Name != t.Name {
+			continue
+		}
+
+		if st, ok := spec.Type.(*ast.StructType); ok && st.Fields != nil {
+			return st.Fields.List
+		}
+	}
+
+	return nil
+}
+
+// embeddedTypeName provides the name of the type embedded by a field with the
+// provided type expression. Types from other packages are ignored.
+func embeddedTypeName(expr ast.Expr) string {
+	switch v := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedTypeName(v.X)
+	case *ast.IndexExpr:
+		return embeddedTypeName(v.X)
+	case *ast.IndexListExpr:
+		return embeddedTypeName(v.X)
+	case *ast.Ident:
+		return v.Name
+	default:
+		return ""
+	}
+}
+
+// finalizeInherited sorts the members of each group and removes groups that
+// have no promoted members.
+func finalizeInherited(groups []*Inherited) []*Inherited {
+	var res []*Inherited
+	for _, g := range groups {
+		if len(g.methods) == 0 && len(g.fields) == 0 {
+			continue
+		}
+
+		sort.Slice(g.methods, func(i, j int) bool { return g.methods[i].name < g.methods[j].name })
+		sort.Slice(g.fields, func(i, j int) bool { return g.fields[i].name < g.fields[j].name })
+		res = append(res, g)
+	}
+
+	return res
+}
@@ -0,0 +1,216 @@
+package lang
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type (
+	// RepoHost builds links to the source code of a repository, following the
+	// URL scheme of the server hosting it. The host of a repository is
+	// detected from its remote where possible, and can be set explicitly on
+	// the Repo otherwise. Custom hosts can be supported by implementing this
+	// interface.
+	RepoHost interface {
+		// SourceHref generates an href to the lines between the start and end
+		// positions of the file at the provided path. The path is
+		// slash-separated and relative to the root of the repository.
+		SourceHref(repo *Repo, path string, start, end Position) string
+	}
+
+	// GitHubHost is the RepoHost for repositories hosted on GitHub, including
+	// GitHub Enterprise Server.
+	GitHubHost struct{}
+
+	// GitLabHost is the RepoHost for repositories hosted on GitLab, including
+	// self-managed instances.
+	GitLabHost struct{}
+
+	// AzureDevOpsHost is the RepoHost for repositories hosted on Azure DevOps.
+	AzureDevOpsHost struct{}
+
+	// BitbucketCloudHost is the RepoHost for repositories hosted on
+	// bitbucket.org.
+	BitbucketCloudHost struct{}
+
+	// BitbucketServerHost is the RepoHost for repositories hosted on Bitbucket
+	// Server or Bitbucket Data Center.
+	BitbucketServerHost struct{}
+
+	// GiteaHost is the RepoHost for repositories hosted on Gitea or Forgejo,
+	// including Codeberg.
+	GiteaHost struct{}
+)
+
+// ParseRepoHost finds the built-in RepoHost with the provided name. Valid names
+// are github, gitlab, azure-devops, bitbucket-cloud, bitbucket-server, gitea
+// and forgejo.
+func ParseRepoHost(name string) (RepoHost, error) {
+	switch name {
+	case "github":
+		return &GitHubHost{}, nil
+	case "gitlab":
+		return &GitLabHost{}, nil
+	case "azure-devops":
+		return &AzureDevOpsHost{}, nil
+	case "bitbucket-cloud":
+		return &BitbucketCloudHost{}, nil
+	case "bitbucket-server":
+		return &BitbucketServerHost{}, nil
+	case "gitea", "forgejo":
+		return &GiteaHost{}, nil
+	default:
+		return nil, fmt.Errorf("gomarkdoc: invalid repository host %q", name)
+	}
+}
+
+var bitbucketServerPathRegex = regexp.MustCompile(`/(?:projects/[^/]+|users/[^/]+)/repos/[^/]+$`)
+
+// detectRepoHost identifies the host of a repository from its normalized
+// remote URL. Hosts are only detected for well-known domains and for URL
+// layouts unique to a host, so nil is returned for everything else.
+func detectRepoHost(remote string) RepoHost {
+	u, err := url.Parse(remote)
+	if err != nil {
+		return nil
+	}
+
+	hostname := strings.ToLower(u.Hostname())
+	switch {
+	case hostname == "github.com" || strings.HasSuffix(hostname, ".ghe.com"):
+		return &GitHubHost{}
+	case hostname == "gitlab.com" || strings.HasPrefix(hostname, "gitlab."):
+		return &GitLabHost{}
+	case hostname == "dev.azure.com":
+		return &AzureDevOpsHost{}
+	case hostname == "bitbucket.org":
+		return &BitbucketCloudHost{}
+	case bitbucketServerPathRegex.MatchString(u.Path):
+		return &BitbucketServerHost{}
+	case hostname == "codeberg.org" || strings.HasPrefix(hostname, "gitea.") ||
+		strings.HasPrefix(hostname, "forgejo."):
+		return &GiteaHost{}
+	default:
+		return nil
+	}
+}
+
+// SourceHref generates an href to the lines between the start and end
+// positions of the file at the provided path.
+func (h *GitHubHost) SourceHref(repo *Repo, path string, start, end Position) string {
+	var locStr string
+	if start.Line == end.Line {
+		locStr = fmt.Sprintf("L%d", start.Line)
+	} else {
+		locStr = fmt.Sprintf("L%d-L%d", start.Line, end.Line)
+	}
+
+	return fmt.Sprintf("%s/blob/%s/%s#%s", repo.Remote, repo.DefaultBranch, path, locStr)
+}
+
+// SourceHref generates an href to the lines between the start and end
+// positions of the file at the provided path.
+func (h *GitLabHost) SourceHref(repo *Repo, path string, start, end Position) string {
+	var locStr string
+	if start.Line == end.Line {
+		locStr = fmt.Sprintf("L%d", start.Line)
+	} else {
+		locStr = fmt.Sprintf("L%d-%d", start.Line, end.Line)
+	}
+
+	return fmt.Sprintf("%s/-/blob/%s/%s#%s", repo.Remote, repo.DefaultBranch, path, locStr)
+}
+
+// SourceHref generates an href to the code between the start and end
+// positions of the file at the provided path, including the columns.
+func (h *AzureDevOpsHost) SourceHref(repo *Repo, path string, start, end Position) string {
+	return fmt.Sprintf(
+		"%s?path=%s&version=GB%s&lineStyle=plain&line=%d&lineEnd=%d&lineStartColumn=%d&lineEndColumn=%d",
+		repo.Remote,
+		url.PathEscape(path),
+		repo.DefaultBranch,
+		start.Line,
+		end.Line,
+		start.Col,
+		end.Col,
+	)
+}
+
+// SourceHref generates an href to the lines between the start and end
+// positions of the file at the provided path.
+func (h *BitbucketCloudHost) SourceHref(repo *Repo, path string, start, end Position) string {
+	var locStr string
+	if start.Line == end.Line {
+		locStr = fmt.Sprintf("lines-%d", start.Line)
+	} else {
+		locStr = fmt.Sprintf("lines-%d:%d", start.Line, end.Line)
+	}
+
+	return fmt.Sprintf("%s/src/%s/%s#%s", repo.Remote, repo.DefaultBranch, path, locStr)
+}
+
+// SourceHref generates an href to the lines between the start and end
+// positions of the file at the provided path.
+func (h *BitbucketServerHost) SourceHref(repo *Repo, path string, start, end Position) string {
+	var locStr string
+	if start.Line == end.Line {
+		locStr = fmt.Sprintf("%d", start.Line)
+	} else {
+		locStr = fmt.Sprintf("%d-%d", start.Line, end.Line)
+	}
+
+	return fmt.Sprintf("%s/browse/%s?at=refs/heads/%s#%s", repo.Remote, path, repo.DefaultBranch, locStr)
+}
+
+// SourceHref generates an href to the lines between the start and end
+// positions of the file at the provided path.
+func (h *GiteaHost) SourceHref(repo *Repo, path string, start, end Position) string {
+	var locStr string
+	if start.Line == end.Line {
+		locStr = fmt.Sprintf("L%d", start.Line)
+	} else {
+		locStr = fmt.Sprintf("L%d-L%d", start.Line, end.Line)
+	}
+
+	return fmt.Sprintf("%s/src/branch/%s/%s#%s", repo.Remote, repo.DefaultBranch, path, locStr)
+}
+
+// SourceHref generates an href to the code at the location, using the host of
+// its repository to build it. The provided fallback host is used if the
+// repository's host isn't known. If the location has no repository, an empty
+// string is returned.
+func (l Location) SourceHref(fallback RepoHost) (string, error) {
+	// If there's no repo, we can't compute an href
+	if l.Repo == nil {
+		return "", nil
+	}
+
+	var (
+		relative string
+		err      error
+	)
+	if filepath.IsAbs(l.Filepath) {
+		relative, err = filepath.Rel(l.WorkDir, l.Filepath)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		relative = l.Filepath
+	}
+
+	full := filepath.Join(l.Repo.PathFromRoot, relative)
+	p, err := filepath.Rel(string(filepath.Separator), full)
+	if err != nil {
+		return "", err
+	}
+
+	host := l.Repo.Host
+	if host == nil {
+		host = fallback
+	}
+
+	return host.SourceHref(l.Repo, filepath.ToSlash(p), l.Start, l.End), nil
+}
@@ -0,0 +1,163 @@
+package lang
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestLocation_SourceHref(t *testing.T) {
+	wd, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		remote string
+		host   RepoHost
+		start  Position
+		end    Position
+		href   string
+	}{
+		"GitHub": {
+			remote: "https://github.com/org/repo",
+			host:   &GitHubHost{},
+			start:  Position{Line: 12, Col: 1},
+			end:    Position{Line: 20, Col: 2},
+			href:   "https://github.com/org/repo/blob/main/subdir/file.go#L12-L20",
+		},
+		"GitLab": {
+			remote: "https://gitlab.com/group/subgroup/repo",
+			host:   &GitLabHost{},
+			start:  Position{Line: 12, Col: 1},
+			end:    Position{Line: 20, Col: 2},
+			href:   "https://gitlab.com/group/subgroup/repo/-/blob/main/subdir/file.go#L12-20",
+		},
+		"Azure DevOps": {
+			remote: "https://dev.azure.com/org/project/_git/repo",
+			host:   &AzureDevOpsHost{},
+			start:  Position{Line: 12, Col: 1},
+			end:    Position{Line: 20, Col: 2},
+			href: "https://dev.azure.com/org/project/_git/repo?path=subdir%2Ffile.go&version=GBmain" +
+				"&lineStyle=plain&line=12&lineEnd=20&lineStartColumn=1&lineEndColumn=2",
+		},
+		"Bitbucket Cloud": {
+			remote: "https://bitbucket.org/workspace/repo",
+			host:   &BitbucketCloudHost{},
+			start:  Position{Line: 12, Col: 1},
+			end:    Position{Line: 20, Col: 2},
+			href:   "https://bitbucket.org/workspace/repo/src/main/subdir/file.go#lines-12:20",
+		},
+		"Bitbucket Cloud (single line)": {
+			remote: "https://bitbucket.org/workspace/repo",
+			host:   &BitbucketCloudHost{},
+			start:  Position{Line: 12, Col: 1},
+			end:    Position{Line: 12, Col: 20},
+			href:   "https://bitbucket.org/workspace/repo/src/main/subdir/file.go#lines-12",
+		},
+		"Bitbucket Server": {
+			remote: "https://git.example.com/projects/PROJ/repos/repo",
+			host:   &BitbucketServerHost{},
+			start:  Position{Line: 12, Col: 1},
+			end:    Position{Line: 20, Col: 2},
+			href:   "https://git.example.com/projects/PROJ/repos/repo/browse/subdir/file.go?at=refs/heads/main#12-20",
+		},
+		"Gitea": {
+			remote: "https://codeberg.org/org/repo",
+			host:   &GiteaHost{},
+			start:  Position{Line: 12, Col: 1},
+			end:    Position{Line: 20, Col: 2},
+			href:   "https://codeberg.org/org/repo/src/branch/main/subdir/file.go#L12-L20",
+		},
+		"Gitea (single line)": {
+			remote: "https://codeberg.org/org/repo",
+			host:   &GiteaHost{},
+			start:  Position{Line: 12, Col: 1},
+			end:    Position{Line: 12, Col: 20},
+			href:   "https://codeberg.org/org/repo/src/branch/main/subdir/file.go#L12",
+		},
+		"Fallback": {
+			remote: "https://git.example.com/org/repo",
+			start:  Position{Line: 12, Col: 1},
+			end:    Position{Line: 20, Col: 2},
+			href:   "https://git.example.com/org/repo/blob/main/subdir/file.go#L12-L20",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			loc := Location{
+				Start:    test.start,
+				End:      test.end,
+				Filepath: filepath.Join(wd, "subdir", "file.go"),
+				WorkDir:  wd,
+				Repo: &Repo{
+					Remote:        test.remote,
+					DefaultBranch: "main",
+					PathFromRoot:  "/",
+					Host:          test.host,
+				},
+			}
+
+			href, err := loc.SourceHref(&GitHubHost{})
+			is.NoErr(err)
+			is.Equal(href, test.href)
+		})
+	}
+}
+
+func TestLocation_SourceHref_noRepo(t *testing.T) {
+	is := is.New(t)
+
+	href, err := Location{Filepath: "file.go"}.SourceHref(&GitHubHost{})
+	is.NoErr(err)
+	is.Equal(href, "")
+}
+
+func TestDetectRepoHost(t *testing.T) {
+	tests := map[string]RepoHost{
+		"https://github.com/org/repo":                       &GitHubHost{},
+		"https://gitlab.com/group/subgroup/repo":            &GitLabHost{},
+		"https://gitlab.example.com/group/repo":             &GitLabHost{},
+		"https://dev.azure.com/org/project/_git/repo":       &AzureDevOpsHost{},
+		"https://bitbucket.org/workspace/repo":              &BitbucketCloudHost{},
+		"https://git.example.com/projects/PROJ/repos/repo":  &BitbucketServerHost{},
+		"https://git.example.com/users/someone/repos/repo":  &BitbucketServerHost{},
+		"https://codeberg.org/org/repo":                     &GiteaHost{},
+		"https://gitea.example.com/org/repo":                &GiteaHost{},
+		"https://git.example.com/org/repo":                  nil,
+		"https://git.example.com:8443/group/subgroup/repo":  nil,
+		"https://example.com/projects/PROJ/repos/repo/more": nil,
+	}
+
+	for remote, host := range tests {
+		t.Run(remote, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(detectRepoHost(remote), host)
+		})
+	}
+}
+
+func TestParseRepoHost(t *testing.T) {
+	is := is.New(t)
+
+	for name, host := range map[string]RepoHost{
+		"github":           &GitHubHost{},
+		"gitlab":           &GitLabHost{},
+		"azure-devops":     &AzureDevOpsHost{},
+		"bitbucket-cloud":  &BitbucketCloudHost{},
+		"bitbucket-server": &BitbucketServerHost{},
+		"gitea":            &GiteaHost{},
+		"forgejo":          &GiteaHost{},
+	} {
+		parsed, err := ParseRepoHost(name)
+		is.NoErr(err)
+		is.Equal(parsed, host)
+	}
+
+	_, err := ParseRepoHost("sourceforge")
+	is.Equal(err.Error(), `gomarkdoc: invalid repository host "sourceforge"`)
+}
@@ -0,0 +1,545 @@
+package lang
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/princjef/gomarkdoc/logger"
+)
+
+// Revision provides the packages of a git repository as of a specific
+// revision, such as a tag, branch or commit. Packages are parsed straight from
+// the git objects of the revision without checking it out, so the working
+// tree is left untouched.
+type Revision struct {
+	log  logger.Logger
+	name string
+	root string
+	tree *object.Tree
+}
+
+// NewRevision opens the git repository containing the provided directory at
+// the revision with the provided name. Any revision understood by git rev-parse
+// that go-git supports can be used, such as v1.2.0, main or HEAD~2.
+func NewRevision(log logger.Logger, dir, name string) (*Revision, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to open repository: %w", err)
+	}
+
+	t, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to open repository: %w", err)
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(name))
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to resolve revision %s: %w", name, err)
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to read commit for revision %s: %w", name, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to read commit for revision %s: %w", name, err)
+	}
+
+	log.Debugf("resolved revision %s to commit %s", name, hash)
+
+	return &Revision{log, name, t.Filesystem.Root(), tree}, nil
+}
+
+// Name provides the name of the revision as it was provided when the revision
+// was opened.
+func (r *Revision) Name() string {
+	return r.name
+}
+
+// PackageDirs lists the directories containing Go source files within the provided
+// directory as of the revision, including the directory itself. Like Package,
+// only the files matching the build constraints of the current platform are
+// considered, so directories that only hold files for other platforms or
+// build tags (e.g. tools) are left out. If recursive is true, the
+// subdirectories of the directory are included as well, except for the ones
+// that the go command ignores (e.g. testdata). Directories are provided as
+// paths within the working tree of the repository and are sorted by name.
+func (r *Revision) PackageDirs(dir string, recursive bool) ([]string, error) {
+	rel, err := r.relDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := r.subtree(rel)
+	if err != nil || tree == nil {
+		return nil, err
+	}
+
+	var dirs []string
+	var walk func(rel string, tree *object.Tree) error
+	walk = func(rel string, tree *object.Tree) error {
+		for _, entry := range tree.Entries {
+			if entry.Mode.IsFile() || !recursive || isIgnoredDir(entry.Name) {
+				continue
+			}
+
+			sub, err := tree.Tree(entry.Name)
+			if err != nil {
+				// Submodules have no tree in the repository
+				continue
+			}
+
+			if err := walk(path.Join(rel, entry.Name), sub); err != nil {
+				return err
+			}
+		}
+
+		hasGo, err := r.hasGoFiles(rel, tree)
+		if err != nil {
+			return err
+		}
+
+		if hasGo {
+			dirs = append(dirs, filepath.Join(r.root, filepath.FromSlash(rel)))
+		}
+
+		return nil
+	}
+
+	if err := walk(rel, tree); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// hasGoFiles identifies whether the tree for the provided directory contains
+// any non-test Go files that match the build constraints of the current
+// platform.
+func (r *Revision) hasGoFiles(rel string, tree *object.Tree) (bool, error) {
+	sources, err := r.goFiles(tree)
+	if err != nil {
+		return false, err
+	}
+
+	dir := filepath.Join(r.root, filepath.FromSlash(rel))
+	ctx := buildContext(sources)
+	for name := range sources {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		if ok, err := ctx.MatchFile(dir, name); err == nil && ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Package creates a representation of the documentation for the package in
+// the provided directory as of the revision. Files are selected using the
+// build constraints of the current platform, and the import path of the
+// package is derived from the go.mod file of its module at the revision. When
+// the directory contains several packages, the one named after the directory
+// is documented. The options that depend on the working tree, such as build
+// matrices, are ignored.
+func (r *Revision) Package(dir string, opts ...PackageOption) (*Package, error) {
+	options, err := newPackageOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := r.relDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := r.subtree(rel)
+	if err != nil {
+		return nil, err
+	}
+
+	if tree == nil {
+		return nil, fmt.Errorf("gomarkdoc: no directory %s at revision %s", dir, r.name)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	pkgDir := filepath.Join(r.root, filepath.FromSlash(rel))
+	cfg := &Config{
+		FileSet: token.NewFileSet(),
+		Level:   1,
+		PkgDir:  pkgDir,
+		WorkDir: wd,
+		Log:     r.log,
+
+		revision: r,
+	}
+
+	sources, err := r.goFiles(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := buildContext(sources)
+
+	var files []string
+	names := make(map[string]bool)
+	for _, name := range sortedKeys(sources) {
+		f, err := parser.ParseFile(cfg.FileSet, filepath.Join(pkgDir, name), sources[name], parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to parse %s at revision %s: %w", name, r.name, err)
+		}
+
+		cfg.Files = append(cfg.Files, f)
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		if ok, err := ctx.MatchFile(pkgDir, name); err != nil || !ok {
+			continue
+		}
+
+		files = append(files, name)
+		names[f.Name.Name] = true
+	}
+
+	name := path.Base(rel)
+	if !names[name] {
+		name = ""
+		for _, n := range sortedKeys(names) {
+			if name == "" || name == "main" {
+				name = n
+			}
+		}
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("gomarkdoc: no source-code package in directory %s at revision %s", dir, r.name)
+	}
+
+	astPkg := &ast.Package{Name: name, Files: make(map[string]*ast.File)}
+	for _, file := range files {
+		// The doc package modifies the files it documents, so it gets its own
+		// copy of them
+		filename := filepath.Join(pkgDir, file)
+		f, err := parser.ParseFile(cfg.FileSet, filename, sources[file], parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to parse %s at revision %s: %w", file, r.name, err)
+		}
+
+		if f.Name.Name == name {
+			astPkg.Files[filename] = f
+		}
+	}
+
+	importPath := r.importPath(rel)
+	docPkg := newDocPkg(astPkg, importPath, options.includeUnexported)
+
+	return newPackageFromDoc(cfg, docPkg, name, importPath, files, nil, options)
+}
+
+// importer provides an importer that type checks the packages of the module
+// containing the provided directory from their source as of the revision.
+// Packages from outside of the module are imported with the fallback importer.
+func (r *Revision) importer(dir string, fallback types.Importer) types.Importer {
+	imp := &revisionImporter{
+		rev:      r,
+		fallback: fallback,
+		fset:     token.NewFileSet(),
+		pkgs:     make(map[string]*types.Package),
+	}
+
+	if rel, err := r.relDir(dir); err == nil {
+		imp.modPath, imp.modDir = r.module(rel)
+	}
+
+	return imp
+}
+
+// revisionImporter imports the packages of a module as of a revision.
+type revisionImporter struct {
+	rev      *Revision
+	fallback types.Importer
+	fset     *token.FileSet
+	modPath  string
+	modDir   string
+	pkgs     map[string]*types.Package
+}
+
+// Import imports the package with the provided import path like ImportFrom
+// without a source directory.
+func (imp *revisionImporter) Import(importPath string) (*types.Package, error) {
+	return imp.ImportFrom(importPath, "", 0)
+}
+
+// ImportFrom type checks the package with the provided import path from its
+// files at the revision if it belongs to the module, or imports it with the
+// fallback importer otherwise. Type errors are ignored, as the type checker
+// still resolves everything it can.
+func (imp *revisionImporter) ImportFrom(importPath, dir string, mode types.ImportMode) (*types.Package, error) {
+	if pkg, ok := imp.pkgs[importPath]; ok {
+		if pkg == nil {
+			return nil, fmt.Errorf("gomarkdoc: import cycle through %s at revision %s", importPath, imp.rev.name)
+		}
+
+		return pkg, nil
+	}
+
+	rel, ok := imp.moduleDir(importPath)
+	if !ok {
+		if from, ok := imp.fallback.(types.ImporterFrom); ok {
+			return from.ImportFrom(importPath, dir, mode)
+		}
+
+		return imp.fallback.Import(importPath)
+	}
+
+	imp.pkgs[importPath] = nil
+	files, err := imp.rev.importFiles(imp.fset, rel)
+	if err != nil {
+		delete(imp.pkgs, importPath)
+		return nil, err
+	}
+
+	conf := types.Config{
+		Importer: imp,
+		Error: func(err error) {
+			imp.rev.log.Debugf("type checking %s at revision %s: %s", importPath, imp.rev.name, err)
+		},
+	}
+
+	pkg, _ := conf.Check(importPath, imp.fset, files, nil)
+	imp.pkgs[importPath] = pkg
+	return pkg, nil
+}
+
+// moduleDir finds the directory relative to the root of the repository for
+// the package with the provided import path if it belongs to the module.
+func (imp *revisionImporter) moduleDir(importPath string) (string, bool) {
+	if imp.modPath == "" || (importPath != imp.modPath && !strings.HasPrefix(importPath, imp.modPath+"/")) {
+		return "", false
+	}
+
+	rel := path.Join(imp.modDir, strings.TrimPrefix(importPath, imp.modPath))
+
+	// Packages in nested modules aren't part of the module
+	if modPath, _ := imp.rev.module(rel); modPath != imp.modPath {
+		return "", false
+	}
+
+	return rel, true
+}
+
+// importFiles parses the files of the package in the provided directory
+// relative to the root of the repository that are built on the current
+// platform, leaving out tests.
+func (r *Revision) importFiles(fset *token.FileSet, rel string) ([]*ast.File, error) {
+	tree, err := r.subtree(rel)
+	if err != nil {
+		return nil, err
+	}
+
+	if tree == nil {
+		return nil, fmt.Errorf("gomarkdoc: no directory %s at revision %s", rel, r.name)
+	}
+
+	sources, err := r.goFiles(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := buildContext(sources)
+	pkgDir := filepath.Join(r.root, filepath.FromSlash(rel))
+
+	var files []*ast.File
+	for _, name := range sortedKeys(sources) {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		if ok, err := ctx.MatchFile(pkgDir, name); err != nil || !ok {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, filepath.Join(pkgDir, name), sources[name], 0)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to parse %s at revision %s: %w", name, r.name, err)
+		}
+
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
+// buildContext provides a build context for the current platform that reads
+// the files of a package from the provided sources, keyed by file name.
+func buildContext(sources map[string][]byte) build.Context {
+	ctx := build.Default
+	ctx.OpenFile = func(p string) (io.ReadCloser, error) {
+		if src, ok := sources[filepath.Base(p)]; ok {
+			return io.NopCloser(bytes.NewReader(src)), nil
+		}
+
+		return nil, os.ErrNotExist
+	}
+
+	return ctx
+}
+
+// relDir finds the slash-separated path of the provided directory relative to
+// the root of the repository.
+func (r *Revision) relDir(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(r.root, abs)
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to find directory %s in repository: %w", dir, err)
+	}
+
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("gomarkdoc: directory %s is outside of the repository", dir)
+	}
+
+	return rel, nil
+}
+
+// subtree finds the tree for the provided directory relative to the root of
+// the repository, or nil if it doesn't exist at the revision.
+func (r *Revision) subtree(rel string) (*object.Tree, error) {
+	if rel == "." {
+		return r.tree, nil
+	}
+
+	tree, err := r.tree.Tree(rel)
+	if errors.Is(err, object.ErrDirectoryNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to read directory %s at revision %s: %w", rel, r.name, err)
+	}
+
+	return tree, nil
+}
+
+// goFiles reads the contents of the Go files directly within the tree.
+func (r *Revision) goFiles(tree *object.Tree) (map[string][]byte, error) {
+	sources := make(map[string][]byte)
+	for _, entry := range tree.Entries {
+		if !entry.Mode.IsFile() || path.Ext(entry.Name) != ".go" {
+			continue
+		}
+
+		file, err := tree.TreeEntryFile(&entry)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to read %s at revision %s: %w", entry.Name, r.name, err)
+		}
+
+		contents, err := file.Contents()
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to read %s at revision %s: %w", entry.Name, r.name, err)
+		}
+
+		sources[entry.Name] = []byte(contents)
+	}
+
+	return sources, nil
+}
+
+// ModulePath provides the path of the module containing the provided
+// directory as of the revision, as declared by the go.mod file of the closest
+// module. If the directory isn't in a module, an empty string is provided.
+func (r *Revision) ModulePath(dir string) (string, error) {
+	rel, err := r.relDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	modPath, _ := r.module(rel)
+	return modPath, nil
+}
+
+// importPath finds the import path of the package in the provided directory
+// relative to the root of the repository from the go.mod file of the closest
+// module containing it at the revision. If there is no module, the directory
+// itself is used.
+func (r *Revision) importPath(rel string) string {
+	modPath, modDir := r.module(rel)
+	if modPath == "" {
+		return rel
+	}
+
+	sub, _ := filepath.Rel(modDir, rel)
+	return path.Join(modPath, filepath.ToSlash(sub))
+}
+
+// module finds the path and directory of the module containing the provided
+// directory relative to the root of the repository.
+func (r *Revision) module(rel string) (string, string) {
+	for dir := rel; ; dir = path.Dir(dir) {
+		f, err := r.tree.File(path.Join(dir, "go.mod"))
+		if err == nil {
+			contents, err := f.Contents()
+			if err != nil {
+				break
+			}
+
+			m := goModRegex.FindStringSubmatch(contents)
+			if m == nil {
+				break
+			}
+
+			return m[1], dir
+		}
+
+		if dir == "." {
+			break
+		}
+	}
+
+	return "", ""
+}
+
+// isIgnoredDir identifies directories that the go command ignores when
+// matching packages.
+func isIgnoredDir(name string) bool {
+	return name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,237 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/princjef/gomarkdoc/logger"
+	"golang.org/x/mod/semver"
+)
+
+type (
+	// VersionHistory finds the release of a repository in which each of the
+	// symbols of its packages was added, based on the semantic version tags
+	// of the repository. It can be shared by all of the packages documented
+	// from the same repository.
+	VersionHistory struct {
+		log      logger.Logger
+		repo     *git.Repository
+		root     string
+		tags     []versionTag
+		packages map[string]map[string]string
+	}
+
+	// versionTag holds a release tag of the repository. Tags of modules in
+	// subdirectories of the repository have the module's directory as their
+	// prefix (e.g. sub/v1.2.0).
+	versionTag struct {
+		prefix  string
+		version string
+		commit  plumbing.Hash
+	}
+)
+
+// NewVersionHistory creates a version history for the git repository
+// containing the provided directory. Only tags that are valid semantic versions
+// without a prerelease suffix (e.g. v1.2.0) are considered releases.
+func NewVersionHistory(log logger.Logger, dir string) (*VersionHistory, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to open repository for version history: %w", err)
+	}
+
+	t, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to open repository for version history: %w", err)
+	}
+
+	refs, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to list repository tags: %w", err)
+	}
+
+	var tags []versionTag
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+
+		var prefix, version string
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			prefix, version = name[:i], name[i+1:]
+		} else {
+			version = name
+		}
+
+		if !semver.IsValid(version) || semver.Prerelease(version) != "" || semver.Build(version) != "" {
+			return nil
+		}
+
+		// Annotated tags point to a tag object rather than the commit
+		commit, err := repo.ResolveRevision(plumbing.Revision(ref.Name().String()))
+		if err != nil {
+			log.Warnf("unable to resolve commit for tag %s: %s", name, err)
+			return nil
+		}
+
+		tags = append(tags, versionTag{prefix, version, *commit})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to list repository tags: %w", err)
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return semver.Compare(tags[i].version, tags[j].version) < 0
+	})
+
+	log.Debugf("found %d release tags for version history", len(tags))
+
+	return &VersionHistory{
+		log:      log,
+		repo:     repo,
+		root:     t.Filesystem.Root(),
+		tags:     tags,
+		packages: make(map[string]map[string]string),
+	}, nil
+}
+
+// symbols finds the version in which each of the symbols of the package with
+// the provided name in the provided directory was added. Symbols are keyed the
+// same way as by declaredSymbols. Symbols that were part of the first release
+// of the module, as well as symbols that haven't been released yet, are left
+// out.
+func (h *VersionHistory) symbols(dir, name string) (map[string]string, error) {
+	rel, err := filepath.Rel(h.root, dir)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to find package in repository: %w", err)
+	}
+
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		// Packages from outside of the repository have no history in it
+		return nil, nil
+	}
+
+	key := fmt.Sprintf("%s:%s", rel, name)
+	if symbols, ok := h.packages[key]; ok {
+		return symbols, nil
+	}
+
+	tags := h.moduleTags(rel)
+	symbols := make(map[string]string)
+	for i, tag := range tags {
+		names, err := h.tagSymbols(tag, rel, name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range names {
+			if _, ok := symbols[n]; !ok {
+				symbols[n] = tag.version
+			}
+		}
+
+		// Everything in the first release was there from the start
+		if i == 0 {
+			for n := range symbols {
+				symbols[n] = ""
+			}
+		}
+	}
+
+	for n, version := range symbols {
+		if version == "" {
+			delete(symbols, n)
+		}
+	}
+
+	h.packages[key] = symbols
+	return symbols, nil
+}
+
+// moduleTags selects the tags of the module containing the package in the
+// provided directory, relative to the root of the repository. The module is
+// identified by the longest tag prefix that contains the directory.
+func (h *VersionHistory) moduleTags(rel string) []versionTag {
+	var prefix string
+	for _, tag := range h.tags {
+		if len(tag.prefix) > len(prefix) && (rel == tag.prefix || strings.HasPrefix(rel, tag.prefix+"/")) {
+			prefix = tag.prefix
+		}
+	}
+
+	var tags []versionTag
+	for _, tag := range h.tags {
+		if tag.prefix == prefix {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// tagSymbols lists the symbols declared by the package with the provided name
+// in the provided directory at the commit of the tag. The files of the package
+// are parsed in memory without checking out the commit, and build constraints
+// are ignored.
+func (h *VersionHistory) tagSymbols(tag versionTag, rel, name string) ([]string, error) {
+	commit, err := h.repo.CommitObject(tag.commit)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to read commit for tag %s: %w", tag.version, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to read commit for tag %s: %w", tag.version, err)
+	}
+
+	if rel != "." {
+		tree, err = tree.Tree(rel)
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			// The package didn't exist yet
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to read directory %s for tag %s: %w", rel, tag.version, err)
+		}
+	}
+
+	fs := token.NewFileSet()
+	var names []string
+	for _, entry := range tree.Entries {
+		if !entry.Mode.IsFile() || path.Ext(entry.Name) != ".go" || strings.HasSuffix(entry.Name, "_test.go") {
+			continue
+		}
+
+		file, err := tree.TreeEntryFile(&entry)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to read %s for tag %s: %w", entry.Name, tag.version, err)
+		}
+
+		contents, err := file.Contents()
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to read %s for tag %s: %w", entry.Name, tag.version, err)
+		}
+
+		f, err := parser.ParseFile(fs, entry.Name, contents, parser.SkipObjectResolution)
+		if err != nil {
+			h.log.Debugf("skipping %s for tag %s: %s", entry.Name, tag.version, err)
+			continue
+		}
+
+		if f.Name.Name == name {
+			names = append(names, declaredSymbols(f)...)
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,100 @@
+package lang_test
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/lang"
+	"github.com/princjef/gomarkdoc/logger"
+)
+
+func TestPackage_since(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	is.NoErr(err)
+
+	release(t, repo, dir, "v1.0.0", false, map[string]string{
+		"go.mod":     "module example.com/history\n",
+		"history.go": "package history\n\nfunc Original() {}\n",
+	})
+	release(t, repo, dir, "v1.1.0", true, map[string]string{
+		"added.go": "package history\n\ntype Client struct{}\n\nfunc NewClient() *Client { return nil }\n",
+	})
+	release(t, repo, dir, "v1.2.0-rc.1", false, map[string]string{
+		"method.go": "package history\n\nfunc (c *Client) Close() {}\n",
+	})
+	release(t, repo, dir, "tools/v2.0.0", false, map[string]string{
+		"tools/tools.go": "package tools\n",
+	})
+
+	buildPkg, err := build.ImportDir(dir, build.ImportComment)
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	history, err := lang.NewVersionHistory(log, dir)
+	is.NoErr(err)
+
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithVersionHistory(history))
+	is.NoErr(err)
+
+	// Symbols from the first release have no annotation
+	original, err := findFunc(pkg, "Original")
+	is.NoErr(err)
+	is.Equal(original.Since(), "")
+
+	client, err := findType(pkg, "Client")
+	is.NoErr(err)
+	is.Equal(client.Since(), "v1.1.0")
+	is.Equal(client.Funcs()[0].Since(), "v1.1.0")
+
+	// Prerelease tags aren't releases, so the method is unreleased
+	is.Equal(client.Methods()[0].Since(), "")
+}
+
+func TestPackage_noVersionHistory(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/simple")
+	is.NoErr(err)
+
+	for _, fn := range pkg.Funcs() {
+		is.Equal(fn.Since(), "")
+	}
+}
+
+// release commits the provided files to the repository and tags the commit
+// with the provided version.
+func release(t *testing.T, repo *git.Repository, dir, version string, annotated bool, files map[string]string) {
+	is := is.New(t)
+
+	wt, err := repo.Worktree()
+	is.NoErr(err)
+
+	for name, contents := range files {
+		is.NoErr(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		is.NoErr(os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+
+		_, err := wt.Add(name)
+		is.NoErr(err)
+	}
+
+	sig := &object.Signature{Name: "gomarkdoc", Email: "gomarkdoc@example.com", When: time.Now()}
+	hash, err := wt.Commit(version, &git.CommitOptions{Author: sig})
+	is.NoErr(err)
+
+	var opts *git.CreateTagOptions
+	if annotated {
+		opts = &git.CreateTagOptions{Tagger: sig, Message: version}
+	}
+
+	_, err = repo.CreateTag(version, hash, opts)
+	is.NoErr(err)
+}
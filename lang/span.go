@@ -75,15 +75,35 @@ func ParseSpans(cfg *Config, texts []comment.Text) []*Span {
 			if v.ImportPath == "" {
 				name := symbolName(v.Recv, v.Name)
 				if sym, ok := cfg.Symbols[name]; ok {
-					s = append(s, NewSpan(cfg.Inc(0), LinkSpan, str, fmt.Sprintf("#%s", sym.Anchor())))
+					s = append(s, NewSpan(cfg.Inc(0), LinkSpan, str, fmt.Sprintf("#%s", cfg.anchor(sym))))
 				} else {
-					cfg.Log.Warnf("Unable to find symbol %s", name)
+					// Symbols left out of the documentation on purpose have
+					// nowhere to link to, but the link itself is fine
+					if cfg.declared[name] {
+						cfg.Log.Debugf("symbol %s is left out of the documentation", name)
+					} else if !cfg.reportUnresolvedLink(str) {
+						cfg.Log.Warnf("Unable to find symbol %s", name)
+					}
+
 					s = append(s, NewSpan(cfg.Inc(0), TextSpan, collapseWhitespace(str), ""))
 				}
 				break
 			}
 
-			s = append(s, NewSpan(cfg.Inc(0), LinkSpan, str, v.DefaultURL("https://pkg.go.dev/")))
+			href, ok := symbolHref(cfg, v.ImportPath, v.Recv, v.Name)
+			if !ok {
+				name := symbolName(v.Recv, v.Name)
+				if cfg.SymbolTable.declares(v.ImportPath, v.Recv, v.Name) {
+					cfg.Log.Debugf("symbol %s in %s is left out of the documentation", name, v.ImportPath)
+				} else if !cfg.reportUnresolvedLink(str) {
+					cfg.Log.Warnf("Unable to find symbol %s in %s", name, v.ImportPath)
+				}
+
+				s = append(s, NewSpan(cfg.Inc(0), TextSpan, str, ""))
+				break
+			}
+
+			s = append(s, NewSpan(cfg.Inc(0), LinkSpan, str, href))
 		case *comment.Link:
 			var b strings.Builder
 			printText(&b, v.Text...)
@@ -101,6 +121,48 @@ func ParseSpans(cfg *Config, texts []comment.Text) []*Span {
 	return s
 }
 
+// defaultDocLinkBase is the base URL for documentation of symbols outside of
+// the packages being documented when no doc link rule matches.
+const defaultDocLinkBase = "https://pkg.go.dev/"
+
+// symbolHref produces the href for the documentation of a symbol in the
+// package with the provided import path. Packages documented in the same run
+// are linked to their generated documentation when possible, with everything
+// else linking to an external site. The second return value is false if the
+// symbol was left out of the documentation of a package documented in the
+// same run, in which case there is nowhere to link to.
+func symbolHref(cfg *Config, importPath, recv, name string) (string, bool) {
+	if cfg.SymbolTable != nil {
+		if href, ok := cfg.SymbolTable.Href(cfg.OutputFile, importPath, recv, name); ok {
+			return href, true
+		}
+
+		if cfg.SymbolTable.excludes(importPath, recv, name) {
+			return "", false
+		}
+	}
+
+	return externalHref(cfg, importPath, recv, name), true
+}
+
+// externalHref produces the href for the documentation of a symbol in the
+// package with the provided import path on an external site. If no name is
+// provided, the href points to the package itself. Configured doc links take
+// precedence over the default of pkg.go.dev.
+func externalHref(cfg *Config, importPath, recv, name string) string {
+	if cfg.DocLinks != nil {
+		href, ok, err := cfg.DocLinks.Href(importPath, recv, name)
+		if err != nil {
+			cfg.Log.Warnf("unable to resolve doc link: %s", err)
+		} else if ok {
+			return href
+		}
+	}
+
+	link := comment.DocLink{ImportPath: importPath, Recv: recv, Name: name}
+	return link.DefaultURL(defaultDocLinkBase)
+}
+
 func printText(b *strings.Builder, text ...comment.Text) {
 	for i, t := range text {
 		if i > 0 {
@@ -104,12 +104,11 @@ func typeSymbols(sym map[string]Symbol, t *doc.Type) {
 		}
 
 		for _, f := range typ.Fields.List {
-			for _, n := range f.Names {
-				sym[symbolName(t.Name, n.String())] = Symbol{
+			for _, n := range fieldNames(f) {
+				sym[symbolName(t.Name, n)] = Symbol{
 					Receiver: t.Name,
-					Name:     n.String(),
+					Name:     n,
 					Kind:     FieldSymbolKind,
-					Parent:   &typeSym,
 				}
 			}
 		}
@@ -168,6 +167,33 @@ func (s Symbol) Anchor() string {
 	}
 }
 
+// namespacedAnchor produces anchor text for the symbol, prefixed with the
+// provided namespace if there is one.
+func namespacedAnchor(namespace string, s Symbol) string {
+	if namespace == "" {
+		return s.Anchor()
+	}
+
+	return fmt.Sprintf("%s.%s", namespace, s.Anchor())
+}
+
+// anchor produces anchor text for a symbol of the package being documented.
+// The anchor is namespaced by the package name if the package is documented
+// in the same output as other packages in its symbol table.
+func (c *Config) anchor(s Symbol) string {
+	return namespacedAnchor(c.anchorNamespace(), s)
+}
+
+// anchorNamespace provides the namespace for the anchors of the package being
+// documented, if it needs one.
+func (c *Config) anchorNamespace() string {
+	if c.SymbolTable == nil || c.Pkg == nil {
+		return ""
+	}
+
+	return c.SymbolTable.anchorNamespace(c.Pkg.ImportPath)
+}
+
 // symbolName returns the string representation of the symbol.
 func symbolName(receiver string, name string) string {
 	receiver = strings.TrimLeft(receiver, "*")
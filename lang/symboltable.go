@@ -0,0 +1,252 @@
+package lang
+
+import (
+	"fmt"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type (
+	// SymbolTable holds the symbols for all of the packages whose
+	// documentation is generated together, along with the file that each
+	// package's documentation is written to. It allows links between these
+	// packages to point to the generated documentation instead of an external
+	// site.
+	SymbolTable struct {
+		packages map[string]symbolTableEntry
+	}
+
+	symbolTableEntry struct {
+		name       string
+		outputFile string
+		symbols    map[string]Symbol
+		declared   map[string]bool
+		types      *types.Package
+	}
+)
+
+// NewSymbolTable creates an empty symbol table.
+func NewSymbolTable() *SymbolTable {
+	return &SymbolTable{packages: make(map[string]symbolTableEntry)}
+}
+
+// Add registers the symbols for the package with the provided import path,
+// along with the file that the package's documentation will be written to. An
+// empty output file indicates that the documentation is not written to a file,
+// in which case links to the package can only be resolved from documentation
+// written to the same destination.
+func (t *SymbolTable) Add(importPath, outputFile string, symbols map[string]Symbol) error {
+	if outputFile != "" {
+		var err error
+		if outputFile, err = filepath.Abs(outputFile); err != nil {
+			return err
+		}
+	}
+
+	t.packages[importPath] = symbolTableEntry{outputFile: outputFile, symbols: symbols}
+	return nil
+}
+
+// addTypes registers the type checked representation of a package that was
+// previously added to the table.
+func (t *SymbolTable) addTypes(importPath string, pkg *types.Package) {
+	entry, ok := t.packages[importPath]
+	if !ok {
+		return
+	}
+
+	entry.types = pkg
+	t.packages[importPath] = entry
+}
+
+// addName registers the name of a package that was previously added to the
+// table.
+func (t *SymbolTable) addName(importPath, name string) {
+	entry, ok := t.packages[importPath]
+	if !ok {
+		return
+	}
+
+	entry.name = name
+	t.packages[importPath] = entry
+}
+
+// addDeclared registers the names of all of the symbols declared by a package
+// that was previously added to the table, including the ones left out of its
+// documentation.
+func (t *SymbolTable) addDeclared(importPath string, declared map[string]bool) {
+	entry, ok := t.packages[importPath]
+	if !ok {
+		return
+	}
+
+	entry.declared = declared
+	t.packages[importPath] = entry
+}
+
+// anchorNamespace provides the namespace for the anchors in the documentation
+// of the package with the provided import path. When several packages are
+// documented in the same output, their anchors are prefixed with the package
+// name so that they don't collide. If another package in the output has the
+// same name, the namespace is derived from the import path instead (e.g.
+// v1-api for example.com/v1/api). Otherwise, there is no namespace.
+func (t *SymbolTable) anchorNamespace(importPath string) string {
+	entry, ok := t.packages[importPath]
+	if !ok {
+		return ""
+	}
+
+	var (
+		shared   bool
+		sameName []string
+		names    = make(map[string]bool)
+	)
+	for path, other := range t.packages {
+		if path == importPath || other.outputFile != entry.outputFile {
+			continue
+		}
+
+		shared = true
+		names[other.name] = true
+		if other.name == entry.name {
+			sameName = append(sameName, path)
+		}
+	}
+
+	switch {
+	case !shared:
+		return ""
+	case len(sameName) == 0:
+		return entry.name
+	default:
+		return pathNamespace(importPath, sameName, names)
+	}
+}
+
+// pathNamespace derives a namespace for the package with the provided import
+// path from the fewest trailing elements of the path that set it apart from
+// the other import paths, joined by dashes. A single element is only used if
+// it isn't also the name of one of the other packages in the output.
+func pathNamespace(importPath string, others []string, names map[string]bool) string {
+	elems := strings.Split(importPath, "/")
+	for n := 1; n < len(elems); n++ {
+		suffix := strings.Join(elems[len(elems)-n:], "/")
+		if n == 1 && names[suffix] {
+			continue
+		}
+
+		unique := true
+		for _, other := range others {
+			if other == suffix || strings.HasSuffix(other, "/"+suffix) {
+				unique = false
+				break
+			}
+		}
+
+		if unique {
+			return strings.Join(elems[len(elems)-n:], "-")
+		}
+	}
+
+	return strings.Join(elems, "-")
+}
+
+// documentedTypes lists the type checked representation of each type that is
+// documented by a package in the table, ordered by import path and then by
+// name. Packages without type information are skipped.
+func (t *SymbolTable) documentedTypes() []*types.TypeName {
+	paths := make([]string, 0, len(t.packages))
+	for path, entry := range t.packages {
+		if entry.types != nil {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	var res []*types.TypeName
+	for _, path := range paths {
+		entry := t.packages[path]
+		res = append(res, documentedTypes(entry.types, entry.symbols)...)
+	}
+
+	return res
+}
+
+// excludes reports whether the package with the provided import path is in the
+// table but the symbol with the provided receiver and name was left out of its
+// documentation.
+func (t *SymbolTable) excludes(importPath, recv, name string) bool {
+	entry, ok := t.packages[importPath]
+	if !ok || name == "" {
+		return false
+	}
+
+	_, ok = entry.symbols[symbolName(recv, name)]
+	return !ok
+}
+
+// declares reports whether the package with the provided import path is in
+// the table and declares the symbol with the provided receiver and name, even
+// if it was left out of the package's documentation.
+func (t *SymbolTable) declares(importPath, recv, name string) bool {
+	if t == nil {
+		return false
+	}
+
+	entry, ok := t.packages[importPath]
+	return ok && entry.declared[symbolName(recv, name)]
+}
+
+// Href produces the href for the symbol with the provided receiver and name in
+// the package with the provided import path, relative to the documentation
+// written to fromFile. If no name is provided, the href points to the
+// package's documentation itself (see Package.Anchor). The second return value is false if the
+// package or symbol is not in the table or the href cannot be determined.
+func (t *SymbolTable) Href(fromFile, importPath, recv, name string) (string, bool) {
+	entry, ok := t.packages[importPath]
+	if !ok {
+		return "", false
+	}
+
+	var anchor string
+	if name != "" {
+		sym, ok := entry.symbols[symbolName(recv, name)]
+		if !ok {
+			return "", false
+		}
+
+		anchor = fmt.Sprintf("#%s", namespacedAnchor(t.anchorNamespace(importPath), sym))
+	}
+
+	if fromFile != "" {
+		var err error
+		if fromFile, err = filepath.Abs(fromFile); err != nil {
+			return "", false
+		}
+	}
+
+	if entry.outputFile == fromFile {
+		// Links within the same output don't need a path. Links to the package
+		// itself go to its header, which is the top of the output unless other
+		// packages are documented in it too.
+		if anchor == "" {
+			anchor = fmt.Sprintf("#%s", t.anchorNamespace(importPath))
+		}
+
+		return anchor, true
+	}
+
+	if entry.outputFile == "" || fromFile == "" {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(filepath.Dir(fromFile), entry.outputFile)
+	if err != nil {
+		return "", false
+	}
+
+	return filepath.ToSlash(rel) + anchor, true
+}
@@ -0,0 +1,167 @@
+package lang_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/princjef/gomarkdoc/lang"
+	"github.com/princjef/gomarkdoc/logger"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestSymbolTable_Href(t *testing.T) {
+	table := lang.NewSymbolTable()
+	symbols := map[string]lang.Symbol{
+		"Thing": {Name: "Thing", Kind: lang.TypeSymbolKind},
+		"Thing.Do": {
+			Receiver: "Thing",
+			Name:     "Do",
+			Kind:     lang.MethodSymbolKind,
+		},
+	}
+
+	if err := table.Add("example.com/mod/inner", "docs/inner/README.md", symbols); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := table.Add("example.com/mod/stdout", "", symbols); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"example.com/mod/shared/a", "example.com/mod/shared/b"} {
+		if err := table.Add(path, "docs/shared/README.md", symbols); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name                       string
+		fromFile, importPath, recv string
+		symbol                     string
+		href                       string
+		ok                         bool
+	}{
+		{"symbol", "docs/README.md", "example.com/mod/inner", "", "Thing", "inner/README.md#Thing", true},
+		{"method", "docs/README.md", "example.com/mod/inner", "Thing", "Do", "inner/README.md#Thing.Do", true},
+		{"package", "docs/README.md", "example.com/mod/inner", "", "", "inner/README.md", true},
+		{"parent dir", "docs/other/README.md", "example.com/mod/inner", "", "Thing", "../inner/README.md#Thing", true},
+		{"same file", "docs/inner/README.md", "example.com/mod/inner", "", "Thing", "#Thing", true},
+		{"same file package", "docs/inner/README.md", "example.com/mod/inner", "", "", "#", true},
+		{"shared file package", "docs/shared/README.md", "example.com/mod/shared/b", "", "", "#b", true},
+		{"missing symbol", "docs/README.md", "example.com/mod/inner", "", "Other", "", false},
+		{"missing package", "docs/README.md", "example.com/mod/other", "", "Thing", "", false},
+		{"stdout from file", "docs/README.md", "example.com/mod/stdout", "", "Thing", "", false},
+		{"stdout to stdout", "", "example.com/mod/stdout", "", "Thing", "#Thing", true},
+		{"file from stdout", "", "example.com/mod/inner", "", "Thing", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			href, ok := table.Href(test.fromFile, test.importPath, test.recv, test.symbol)
+			is.Equal(ok, test.ok)
+			is.Equal(href, test.href)
+		})
+	}
+}
+
+func TestSymbolTable_sharedOutputAnchors(t *testing.T) {
+	is := is.New(t)
+
+	loaded, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax,
+	}, "../testData/shared", "../testData/shared/inner")
+	is.NoErr(err)
+	is.Equal(len(loaded), 2)
+
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i].PkgPath < loaded[j].PkgPath })
+
+	// Both packages are documented in the same file
+	log := logger.New(logger.ErrorLevel)
+	table := lang.NewSymbolTable()
+	pkgs := make([]*lang.Package, len(loaded))
+	for i, l := range loaded {
+		pkgs[i], err = lang.NewPackageFromPackages(log, l, lang.PackageWithSymbolTable(table, "README.md"))
+		is.NoErr(err)
+	}
+
+	shared, inner := pkgs[0], pkgs[1]
+
+	typ, err := findType(shared, "Client")
+	is.NoErr(err)
+	is.Equal(typ.Anchor(), "shared.Client")
+	is.Equal(typ.Methods()[0].Anchor(), "shared.Client.Do")
+
+	typ, err = findType(inner, "Config")
+	is.NoErr(err)
+	is.Equal(typ.Anchor(), "inner.Config")
+	is.Equal(typ.Fields()[0].Anchor(), "inner.Config.Name")
+	is.Equal(inner.Consts()[0].Anchor(), "inner.Version")
+
+	// Links within the file and between the packages use the same anchors
+	typ, err = findType(shared, "Config")
+	is.NoErr(err)
+
+	var hrefs []string
+	for _, span := range typ.Doc().Blocks()[0].Spans() {
+		if span.Kind() == lang.LinkSpan {
+			hrefs = append(hrefs, span.URL())
+		}
+	}
+
+	is.Equal(hrefs, []string{"#shared.Client", "#inner.Config"})
+
+	groups := inner.Groups(lang.AlphabeticalOrder)
+	is.Equal(groups[0].Name(), "Constants")
+	is.Equal(groups[0].Anchor(), "inner-constants")
+}
+
+func TestSymbolTable_sameNameAnchors(t *testing.T) {
+	is := is.New(t)
+
+	symbols := map[string]lang.Symbol{
+		"Thing": {Name: "Thing", Kind: lang.TypeSymbolKind},
+	}
+
+	// Packages with the same name in the same output are namespaced by their
+	// import paths
+	table := lang.NewSymbolTable()
+	for _, path := range []string{"example.com/mod/v1/api", "example.com/mod/v2/api", "example.com/api"} {
+		is.NoErr(table.Add(path, "README.md", symbols))
+	}
+
+	tests := []struct {
+		importPath string
+		href       string
+	}{
+		{"example.com/mod/v1/api", "#v1-api.Thing"},
+		{"example.com/mod/v2/api", "#v2-api.Thing"},
+		{"example.com/api", "#example.com-api.Thing"},
+	}
+
+	for _, test := range tests {
+		href, ok := table.Href("README.md", test.importPath, "", "Thing")
+		is.True(ok)
+		is.Equal(href, test.href)
+	}
+}
+
+func TestSymbolTable_separateOutputAnchors(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/shared")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	table := lang.NewSymbolTable()
+	is.NoErr(table.Add("github.com/princjef/gomarkdoc/testData/shared/inner", "inner/README.md", nil))
+
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithSymbolTable(table, "README.md"))
+	is.NoErr(err)
+
+	typ, err := findType(pkg, "Client")
+	is.NoErr(err)
+	is.Equal(typ.Anchor(), "Client")
+}
@@ -2,6 +2,7 @@ package lang
 
 import (
 	"fmt"
+	"go/ast"
 	"go/doc"
 	"strings"
 )
@@ -37,6 +38,56 @@ func (typ *Type) Title() string {
 	return fmt.Sprintf("type %s", typ.doc.Name)
 }
 
+// Deprecated reports whether the type's documentation marks it as deprecated.
+// Following the Go convention, this is the case when a paragraph of the
+// documentation begins with "Deprecated: ".
+func (typ *Type) Deprecated() bool {
+	return isDeprecated(typ.doc.Doc)
+}
+
+// DeprecationNotice provides the text following "Deprecated: " in the type's
+// documentation, or an empty string if the type is not deprecated.
+func (typ *Type) DeprecationNotice() string {
+	notice, _ := deprecationNotice(typ.doc.Doc)
+	return notice
+}
+
+// Availability describes the build contexts under which the type is available,
+// such as "linux, darwin only" or "requires tag: enterprise", if the package
+// was documented with a build matrix. An empty string is returned if the type
+// is available under all of the contexts or no build matrix was used.
+func (typ *Type) Availability() string {
+	return typ.cfg.availability.symbol(typ.doc.Name)
+}
+
+// Since provides the version of the release in which the type was added, such
+// as v1.2.0, if the package was documented with a version history. An empty
+// string is returned if the type was part of the first release, has not been
+// released yet or no version history was used.
+func (typ *Type) Since() string {
+	return typ.cfg.since[typ.doc.Name]
+}
+
+// Group provides the name of the group the type was placed in with a
+// //gomarkdoc:group directive, or an empty string if it has none.
+func (typ *Type) Group() string {
+	return typ.cfg.directives[typ.doc.Name].group
+}
+
+// Order provides the position given to the type with a //gomarkdoc:order
+// directive, or 0 if it has none.
+func (typ *Type) Order() int {
+	return typ.cfg.directives[typ.doc.Name].order
+}
+
+// ExampleOnly reports whether the type has a //gomarkdoc:example-only
+// directive, in which case its declaration and doc comment are left out in
+// favor of its examples. The funcs, methods and values associated with the type
+// are still documented.
+func (typ *Type) ExampleOnly() bool {
+	return typ.cfg.directives[typ.doc.Name].exampleOnly
+}
+
 // Location returns a representation of the node's location in a file within a
 // repository.
 func (typ *Type) Location() Location {
@@ -52,7 +103,7 @@ func (typ *Type) Summary() string {
 // Doc provides the structured contents of the documentation comment for the
 // type.
 func (typ *Type) Doc() *Doc {
-	return NewDoc(typ.cfg.Inc(1), typ.doc.Doc)
+	return NewDoc(typ.cfg.docConfig(1, typ.Location(), typ.docComment()), typ.doc.Doc)
 }
 
 // Decl provides the raw text representation of the code for the type's
@@ -61,6 +112,61 @@ func (typ *Type) Decl() (string, error) {
 	return printNode(typ.doc.Decl, typ.cfg.FileSet)
 }
 
+// DeclSpans provides the code for the type's declaration as a set of spans.
+// If type information is available for the package, identifiers that refer to
+// other symbols are provided as link spans pointing to the documentation for
+// those symbols. Otherwise, the declaration is provided as a single span of raw
+// text.
+func (typ *Type) DeclSpans() ([]*Span, error) {
+	return codeSpans(typ.cfg, typ.doc.Decl, typ.cfg.FileSet)
+}
+
+// TypeParams lists the type parameters of a generic type along with their
+// constraints.
+func (typ *Type) TypeParams() []*TypeParam {
+	if spec := typ.spec(); spec != nil {
+		return typeParams(typ.cfg.Inc(1), spec.TypeParams)
+	}
+
+	return nil
+}
+
+// TypeSet lists the terms of the type set of the type if it is a constraint
+// interface, such as the terms ~int and ~string for an interface embedding
+// ~int | ~string. The list is empty for all other types.
+func (typ *Type) TypeSet() []*TypeTerm {
+	spec := typ.spec()
+	if spec == nil {
+		return nil
+	}
+
+	if iface, ok := spec.Type.(*ast.InterfaceType); ok {
+		return interfaceTypeSet(typ.cfg.Inc(1), iface)
+	}
+
+	return nil
+}
+
+func (typ *Type) spec() *ast.TypeSpec {
+	for _, s := range typ.doc.Decl.Specs {
+		if spec, ok := s.(*ast.TypeSpec); ok && spec.Name.Name == typ.doc.Name {
+			return spec
+		}
+	}
+
+	return nil
+}
+
+// docComment finds the comment that the type's documentation is read from,
+// which is the comment on its spec when it is declared in a group.
+func (typ *Type) docComment() *ast.CommentGroup {
+	if spec := typ.spec(); spec != nil {
+		return typ.cfg.precedingComment(spec)
+	}
+
+	return typ.cfg.precedingComment(typ.doc.Decl)
+}
+
 // Examples lists the examples pertaining to the type from the set provided on
 // initialization.
 func (typ *Type) Examples() (examples []*Example) {
@@ -103,7 +209,7 @@ func (typ *Type) Funcs() []*Func {
 		funcs[i] = NewFunc(typ.cfg.Inc(1), fn, typ.examples)
 	}
 
-	return funcs
+	return orderFuncs(typ.cfg, funcs)
 }
 
 // Methods lists the funcs that use the type as a value or pointer receiver.
@@ -113,9 +219,71 @@ func (typ *Type) Methods() []*Func {
 		methods[i] = NewFunc(typ.cfg.Inc(1), fn, typ.examples)
 	}
 
+	return orderFuncs(typ.cfg, methods)
+}
+
+// Inherited lists the methods and fields that the type inherits from the types
+// embedded within it, grouped by the type that declares them. Groups are
+// ordered by the depth at which the declaring type is embedded. If type
+// information is available for the package, types embedded from other packages
+// are included. Otherwise, only types from the same package are considered.
+func (typ *Type) Inherited() []*Inherited {
+	return inheritedMembers(typ.cfg.Inc(1), typ.doc)
+}
+
+// PromotedMethods lists the methods promoted to the type from the types
+// embedded within it. Methods that are already listed by Methods are not
+// included. See Inherited for details on how the methods are found.
+func (typ *Type) PromotedMethods() []*Promoted {
+	var methods []*Promoted
+	for _, i := range typ.Inherited() {
+		methods = append(methods, i.Methods()...)
+	}
+
 	return methods
 }
 
+// PromotedFields lists the fields promoted to the type from the types embedded
+// within it. See Inherited for details on how the fields are found.
+func (typ *Type) PromotedFields() []*Promoted {
+	var fields []*Promoted
+	for _, i := range typ.Inherited() {
+		fields = append(fields, i.Fields()...)
+	}
+
+	return fields
+}
+
+// Implements lists the interfaces implemented by the type, either directly or
+// through a pointer to the type. Only interfaces from packages documented along
+// with this one are considered. This requires type information for the
+// package, without which the list is always empty.
+func (typ *Type) Implements() []*TypeRef {
+	return implements(typ.cfg, typ.doc.Name)
+}
+
+// Implementors lists the types that implement the type if it is an interface,
+// either directly or through a pointer. Only types from packages documented
+// along with this one are considered. This requires type information for the
+// package, without which the list is always empty.
+func (typ *Type) Implementors() []*TypeRef {
+	return implementors(typ.cfg, typ.doc.Name)
+}
+
+// Fields lists the documented fields of the type if it is a struct type, in
+// the order in which they are declared. Embedded fields are included and named
+// after the type they embed.
+func (typ *Type) Fields() []*Field {
+	var fields []*Field
+	for _, f := range structFields(typ.doc) {
+		for _, name := range fieldNames(f) {
+			fields = append(fields, NewField(typ.cfg.Inc(1), typ.doc.Name, name, f))
+		}
+	}
+
+	return fields
+}
+
 // Consts lists the const declaration blocks containing values of this type.
 func (typ *Type) Consts() []*Value {
 	consts := make([]*Value, len(typ.doc.Consts))
@@ -138,8 +306,8 @@ func (typ *Type) Vars() []*Value {
 
 // Anchor produces anchor text for the type.
 func (typ *Type) Anchor() string {
-	return Symbol{
+	return typ.cfg.anchor(Symbol{
 		Kind: TypeSymbolKind,
 		Name: typ.doc.Name,
-	}.Anchor()
+	})
 }
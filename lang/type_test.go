@@ -2,6 +2,8 @@ package lang_test
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/matryer/is"
@@ -22,6 +24,279 @@ func TestType_Examples(t *testing.T) {
 	is.Equal(ex[1].Name(), "Sub Test")
 }
 
+func TestType_TypeParams(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/generics", "Pair")
+	is.NoErr(err)
+
+	is.Equal(typeParamSummary(typ.TypeParams()), []string{
+		"K comparable [https://pkg.go.dev/builtin/#comparable] []",
+		"V any [https://pkg.go.dev/builtin/#any] []",
+	})
+
+	typ, err = loadType("../testData/generics", "Number")
+	is.NoErr(err)
+
+	is.Equal(len(typ.TypeParams()), 0)
+}
+
+func TestType_TypeSet(t *testing.T) {
+	tests := []struct {
+		name  string
+		terms []string
+	}{
+		{"Number", []string{
+			"~int (int) [https://pkg.go.dev/builtin/#int]",
+			"~int64 (int64) [https://pkg.go.dev/builtin/#int64]",
+			"~float64 (float64) [https://pkg.go.dev/builtin/#float64]",
+		}},
+		{"Ordered", []string{"Number (Number) [#Number]", "~string (string) [https://pkg.go.dev/builtin/#string]"}},
+		{"Pair", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			typ, err := loadType("../testData/generics", test.name)
+			is.NoErr(err)
+
+			var terms []string
+			for _, term := range typ.TypeSet() {
+				text, err := term.Text()
+				is.NoErr(err)
+
+				typeText, err := term.Type()
+				is.NoErr(err)
+
+				is.Equal(term.Tilde(), strings.HasPrefix(text, "~"))
+				terms = append(terms, fmt.Sprintf("%s (%s) [%s]", text, typeText, term.Href()))
+			}
+
+			is.Equal(terms, test.terms)
+		})
+	}
+}
+
+func TestType_Deprecated(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/deprecated", "Client")
+	is.NoErr(err)
+
+	is.True(typ.Deprecated())
+	is.Equal(
+		typ.DeprecationNotice(),
+		"Use [Settings] with the new client package instead. This type will be removed in the next major version.",
+	)
+
+	typ, err = loadType("../testData/deprecated", "Settings")
+	is.NoErr(err)
+
+	is.True(!typ.Deprecated())
+	is.Equal(typ.DeprecationNotice(), "")
+}
+
+func TestType_Fields(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/deprecated", "Settings")
+	is.NoErr(err)
+
+	fields := typ.Fields()
+	is.Equal(len(fields), 3)
+
+	is.Equal(fields[0].Name(), "Name")
+	is.Equal(fields[0].Summary(), "Name holds the name of the client.")
+	is.True(!fields[0].Deprecated())
+
+	is.Equal(fields[1].Name(), "Retries")
+	is.True(fields[1].Deprecated())
+	is.Equal(fields[1].DeprecationNotice(), "Retries are no longer supported and this field is ignored.")
+
+	is.Equal(fields[2].Name(), "Timeout")
+	is.Equal(fields[2].Summary(), "Timeout holds the request timeout in seconds.")
+}
+
+func TestType_Fields_details(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/fields", "Request")
+	is.NoErr(err)
+
+	var summary []string
+	for _, f := range typ.Fields() {
+		fieldType, err := f.Type()
+		is.NoErr(err)
+
+		summary = append(
+			summary,
+			fmt.Sprintf("%s %s %q %t %s", f.Anchor(), fieldType, f.Tag(), f.Embedded(), f.Summary()),
+		)
+	}
+
+	is.Equal(summary, []string{
+		`Request.Method string "json:\"method\"" false Method holds the HTTP method of the request, such as GET.`,
+		`Request.Header http.Header "json:\"header,omitempty\"" false ` +
+			`Header holds the headers to send with the request.`,
+		`Request.Width int "" false Width and Height hold the size of the request's payload.`,
+		`Request.Height int "" false Width and Height hold the size of the request's payload.`,
+		`Request.Options *Options "" true `,
+		`Request.Body []byte "" false Body holds the raw payload | if any.`,
+		`Request.Retries int "json:\"retries\"" false Retries holds the number of retries to attempt.`,
+	})
+}
+
+func TestType_Fields_embedded(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/promoted", "Top")
+	is.NoErr(err)
+
+	var names []string
+	for _, f := range typ.Fields() {
+		names = append(names, f.Name())
+	}
+
+	is.Equal(names, []string{"Middle", "Other", "Mutex", "Name"})
+}
+
+func TestType_Inherited(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadTypedPackage("../testData/promoted")
+	is.NoErr(err)
+
+	typ, err := findType(pkg, "Top")
+	is.NoErr(err)
+
+	inherited := typ.Inherited()
+	is.Equal(inheritedSummary(inherited), []string{
+		"Middle (#Middle): Base Base [#Middle.Base], func (m Middle) Goodbye() string [#Middle.Goodbye]",
+		"Other (#Other): Extra bool [#Other.Extra]",
+		"sync.Mutex (https://pkg.go.dev/sync/#Mutex): " +
+			"func (m *sync.Mutex) Lock() [https://pkg.go.dev/sync/#Mutex.Lock], " +
+			"func (m *sync.Mutex) TryLock() bool [https://pkg.go.dev/sync/#Mutex.TryLock], " +
+			"func (m *sync.Mutex) Unlock() [https://pkg.go.dev/sync/#Mutex.Unlock]",
+		"Base (#Base): ID int [#Base.ID], func (b Base) Hello() string [#Base.Hello]",
+	})
+
+	is.Equal(len(typ.PromotedMethods()), 5)
+	is.Equal(len(typ.PromotedFields()), 3)
+}
+
+func TestType_Inherited_noTypes(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/promoted", "Top")
+	is.NoErr(err)
+
+	is.Equal(inheritedSummary(typ.Inherited()), []string{
+		"Middle (#Middle): Base Base [#Middle.Base], func (m Middle) Goodbye() string [#Middle.Goodbye]",
+		"Other (#Other): Extra bool [#Other.Extra]",
+		"Base (#Base): ID int [#Base.ID], func (b Base) Hello() string [#Base.Hello]",
+	})
+}
+
+func TestType_Inherited_none(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadTypedPackage("../testData/promoted")
+	is.NoErr(err)
+
+	typ, err := findType(pkg, "Base")
+	is.NoErr(err)
+
+	is.Equal(len(typ.Inherited()), 0)
+	is.Equal(len(typ.PromotedMethods()), 0)
+	is.Equal(len(typ.PromotedFields()), 0)
+}
+
+func TestType_Implements(t *testing.T) {
+	is := is.New(t)
+
+	pkgs, err := loadTypedPackages("../testData/implements", "../testData/implements/plugins")
+	is.NoErr(err)
+
+	square, err := findType(pkgs[0], "Square")
+	is.NoErr(err)
+	is.Equal(typeRefSummary(square.Implements()), []string{"Named (#Named) pointer", "Shape (#Shape)"})
+
+	point, err := findType(pkgs[0], "Point")
+	is.NoErr(err)
+	is.Equal(len(point.Implements()), 0)
+
+	circle, err := findType(pkgs[1], "Circle")
+	is.NoErr(err)
+	is.Equal(typeRefSummary(circle.Implements()), []string{"implements.Shape (../README.md#Shape)"})
+}
+
+func TestType_Implementors(t *testing.T) {
+	is := is.New(t)
+
+	pkgs, err := loadTypedPackages("../testData/implements", "../testData/implements/plugins")
+	is.NoErr(err)
+
+	shape, err := findType(pkgs[0], "Shape")
+	is.NoErr(err)
+	is.Equal(typeRefSummary(shape.Implementors()), []string{
+		"Square (#Square)",
+		"plugins.Circle (plugins/README.md#Circle)",
+	})
+
+	named, err := findType(pkgs[0], "Named")
+	is.NoErr(err)
+	is.Equal(typeRefSummary(named.Implementors()), []string{"Square (#Square) pointer"})
+
+	for _, name := range []string{"Empty", "Number", "Square"} {
+		typ, err := findType(pkgs[0], name)
+		is.NoErr(err)
+		is.Equal(len(typ.Implementors()), 0) // not an implementable interface
+	}
+}
+
+func TestType_Implements_noTypes(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/implements", "Square")
+	is.NoErr(err)
+
+	is.Equal(len(typ.Implements()), 0)
+}
+
+func typeRefSummary(refs []*lang.TypeRef) []string {
+	var res []string
+	for _, r := range refs {
+		s := fmt.Sprintf("%s (%s)", r.Name(), r.Href())
+		if r.Pointer() {
+			s += " pointer"
+		}
+
+		res = append(res, s)
+	}
+
+	return res
+}
+
+func inheritedSummary(inherited []*lang.Inherited) []string {
+	var res []string
+	for _, i := range inherited {
+		var members []string
+		for _, f := range i.Fields() {
+			members = append(members, fmt.Sprintf("%s [%s]", f.Signature(), f.Href()))
+		}
+
+		for _, m := range i.Methods() {
+			members = append(members, fmt.Sprintf("%s [%s]", m.Signature(), m.Href()))
+		}
+
+		res = append(res, fmt.Sprintf("%s (%s): %s", i.Origin(), i.Href(), strings.Join(members, ", ")))
+	}
+
+	return res
+}
+
 func loadType(dir, name string) (*lang.Type, error) {
 	buildPkg, err := getBuildPackage(dir)
 	if err != nil {
@@ -34,6 +309,10 @@ func loadType(dir, name string) (*lang.Type, error) {
 		return nil, err
 	}
 
+	return findType(pkg, name)
+}
+
+func findType(pkg *lang.Package, name string) (*lang.Type, error) {
 	for _, t := range pkg.Types() {
 		if t.Name() == name {
 			return t, nil
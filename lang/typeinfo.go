@@ -0,0 +1,184 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"path/filepath"
+)
+
+type (
+	// TypeInfo holds the results of type checking a package. Objects are
+	// indexed by the location of the identifiers referring to them, which
+	// allows identifiers from independently parsed copies of the package's
+	// files to be resolved.
+	TypeInfo struct {
+		pkg     *types.Package
+		objects map[typeInfoKey]types.Object
+	}
+
+	typeInfoKey struct {
+		filename string
+		offset   int
+	}
+)
+
+// NewTypeInfo creates a TypeInfo from the type checked representation of a
+// package, the file set used when parsing the package's files and the
+// information recorded while type checking it.
+func NewTypeInfo(fset *token.FileSet, pkg *types.Package, info *types.Info) *TypeInfo {
+	objects := make(map[typeInfoKey]types.Object, len(info.Uses))
+	for ident, obj := range info.Uses {
+		objects[newTypeInfoKey(fset, ident.Pos())] = obj
+	}
+
+	return &TypeInfo{pkg, objects}
+}
+
+// merge adds the objects from another TypeInfo for the same package, such as
+// one type checked under a different build context. Identifiers that both of
+// them know about keep the object they already refer to.
+func (ti *TypeInfo) merge(other *TypeInfo) {
+	for key, obj := range other.objects {
+		if _, ok := ti.objects[key]; !ok {
+			ti.objects[key] = obj
+		}
+	}
+}
+
+// Package provides the type checked package.
+func (ti *TypeInfo) Package() *types.Package {
+	return ti.pkg
+}
+
+// ObjectOf finds the object referred to by the provided identifier, which must
+// have been parsed from one of the package's files using the provided file
+// set. If the identifier does not refer to a known object, nil is returned.
+func (ti *TypeInfo) ObjectOf(fset *token.FileSet, ident *ast.Ident) types.Object {
+	return ti.objects[newTypeInfoKey(fset, ident.Pos())]
+}
+
+func newTypeInfoKey(fset *token.FileSet, pos token.Pos) typeInfoKey {
+	p := fset.Position(pos)
+	return typeInfoKey{filepath.Clean(p.Filename), p.Offset}
+}
+
+// codeSpans prints the provided node and splits the result into spans. When
+// type information is available, identifiers referring to other symbols are
+// emitted as links to the symbol's documentation. The node is printed using
+// printFset, which may differ from the file set that the node was parsed with
+// in order to control formatting.
+func codeSpans(cfg *Config, node ast.Node, printFset *token.FileSet) ([]*Span, error) {
+	code, err := printNode(node, printFset)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.TypeInfo == nil {
+		return []*Span{NewSpan(cfg.Inc(0), RawTextSpan, code, "")}, nil
+	}
+
+	var idents []*ast.Ident
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			idents = append(idents, ident)
+		}
+
+		return true
+	})
+
+	// Walk the identifiers in the printed code, which appear in the same order
+	// as they do in the syntax tree.
+	var (
+		s       scanner.Scanner
+		spans   []*Span
+		cursor  int
+		current int
+	)
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(code))
+	s.Init(file, []byte(code), nil, 0)
+
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		if tok != token.IDENT {
+			continue
+		}
+
+		if current >= len(idents) || idents[current].Name != lit {
+			cfg.Log.Debugf("unable to match identifier %s in printed code; skipping links", lit)
+			return []*Span{NewSpan(cfg.Inc(0), RawTextSpan, code, "")}, nil
+		}
+
+		ident := idents[current]
+		current++
+
+		href, ok := objectHref(cfg, cfg.TypeInfo.ObjectOf(cfg.FileSet, ident))
+		if !ok {
+			continue
+		}
+
+		offset := file.Offset(pos)
+		if offset > cursor {
+			spans = append(spans, NewSpan(cfg.Inc(0), RawTextSpan, code[cursor:offset], ""))
+		}
+
+		spans = append(spans, NewSpan(cfg.Inc(0), LinkSpan, lit, href))
+		cursor = offset + len(lit)
+	}
+
+	if cursor < len(code) {
+		spans = append(spans, NewSpan(cfg.Inc(0), RawTextSpan, code[cursor:], ""))
+	}
+
+	return spans, nil
+}
+
+// objectHref produces the href for the documentation of the provided object,
+// if it has any.
+func objectHref(cfg *Config, obj types.Object) (string, bool) {
+	if obj == nil {
+		return "", false
+	}
+
+	if v, ok := obj.(*types.PkgName); ok {
+		return symbolHref(cfg, v.Imported().Path(), "", "")
+	}
+
+	if obj.Parent() == types.Universe {
+		// Predeclared identifiers are documented in the builtin package
+		return externalHref(cfg, "builtin", "", obj.Name()), true
+	}
+
+	// Only package-level symbols have documentation
+	if obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+		return "", false
+	}
+
+	return typeInfoHref(cfg, obj.Pkg(), "", obj.Name())
+}
+
+// typeInfoHref produces the href for the documentation of the symbol with the
+// provided receiver and name in the provided package, if it has any.
+func typeInfoHref(cfg *Config, pkg *types.Package, recv, name string) (string, bool) {
+	if pkg == nil {
+		return "", false
+	}
+
+	if pkg == cfg.TypeInfo.Package() {
+		sym, ok := cfg.Symbols[symbolName(recv, name)]
+		if !ok {
+			return "", false
+		}
+
+		return fmt.Sprintf("#%s", cfg.anchor(sym)), true
+	}
+
+	return symbolHref(cfg, pkg.Path(), recv, name)
+}
@@ -0,0 +1,221 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+type (
+	// TypeParam holds documentation information for a single type parameter
+	// of a generic func or type.
+	TypeParam struct {
+		cfg        *Config
+		name       string
+		constraint ast.Expr
+	}
+
+	// TypeTerm holds a single term of the type set of a constraint, such as
+	// ~int in the constraint ~int | ~string.
+	TypeTerm struct {
+		cfg   *Config
+		tilde bool
+		typ   ast.Expr
+	}
+)
+
+// NewTypeParam creates a TypeParam from the name of the type parameter and the
+// expression for its constraint.
+func NewTypeParam(cfg *Config, name string, constraint ast.Expr) *TypeParam {
+	return &TypeParam{cfg, name, constraint}
+}
+
+// Name provides the name of the type parameter.
+func (tp *TypeParam) Name() string {
+	return tp.name
+}
+
+// Constraint provides the raw text representation of the type parameter's
+// constraint.
+func (tp *TypeParam) Constraint() (string, error) {
+	return printNode(tp.constraint, token.NewFileSet())
+}
+
+// ConstraintHref provides the href for the documentation of the type
+// parameter's constraint if it refers to a named type, such as any or
+// cmp.Ordered. Constraints declared inline have no href, in which case an
+// empty string is returned.
+func (tp *TypeParam) ConstraintHref() string {
+	return typeExprHref(tp.cfg, tp.constraint)
+}
+
+// TypeSet lists the terms of the type set of the type parameter's constraint
+// if the constraint is declared inline, such as ~int | ~string. Constraints
+// that refer to a named type have no terms listed here. Their type set is
+// documented along with the named type instead.
+func (tp *TypeParam) TypeSet() []*TypeTerm {
+	if iface, ok := tp.constraint.(*ast.InterfaceType); ok {
+		return interfaceTypeSet(tp.cfg, iface)
+	}
+
+	if !isTypeSetElement(tp.constraint) {
+		return nil
+	}
+
+	return typeTerms(tp.cfg, tp.constraint)
+}
+
+// Tilde reports whether the term includes all types with the term's type as
+// their underlying type, which is written as ~T.
+func (t *TypeTerm) Tilde() bool {
+	return t.tilde
+}
+
+// Type provides the raw text representation of the term's type, without the
+// tilde.
+func (t *TypeTerm) Type() (string, error) {
+	return printNode(t.typ, token.NewFileSet())
+}
+
+// Text provides the raw text representation of the term, including the tilde
+// if there is one.
+func (t *TypeTerm) Text() (string, error) {
+	typ, err := t.Type()
+	if err != nil {
+		return "", err
+	}
+
+	if t.tilde {
+		return fmt.Sprintf("~%s", typ), nil
+	}
+
+	return typ, nil
+}
+
+// Href provides the href for the documentation of the term's type if it is a
+// named type, or an empty string otherwise.
+func (t *TypeTerm) Href() string {
+	return typeExprHref(t.cfg, t.typ)
+}
+
+// typeParams lists the type parameters declared by the provided field list.
+func typeParams(cfg *Config, fields *ast.FieldList) []*TypeParam {
+	if fields == nil {
+		return nil
+	}
+
+	var params []*TypeParam
+	for _, f := range fields.List {
+		for _, n := range f.Names {
+			params = append(params, NewTypeParam(cfg, n.Name, f.Type))
+		}
+	}
+
+	return params
+}
+
+// interfaceTypeSet lists the terms of the type set elements embedded in the
+// provided interface. Embedded interfaces that are not part of a union are
+// considered part of the interface's method set instead and are not listed.
+func interfaceTypeSet(cfg *Config, iface *ast.InterfaceType) []*TypeTerm {
+	if iface.Methods == nil {
+		return nil
+	}
+
+	var terms []*TypeTerm
+	for _, f := range iface.Methods.List {
+		if len(f.Names) > 0 || !isTypeSetElement(f.Type) {
+			continue
+		}
+
+		terms = append(terms, typeTerms(cfg, f.Type)...)
+	}
+
+	return terms
+}
+
+// isTypeSetElement identifies whether the provided interface element restricts
+// the type set of the interface, as opposed to embedding another interface's
+// methods. Without type information, a lone named type can only be identified
+// as a type set element if it is a predeclared non-interface type.
+func isTypeSetElement(expr ast.Expr) bool {
+	switch v := expr.(type) {
+	case *ast.BinaryExpr:
+		return v.Op == token.OR
+	case *ast.UnaryExpr:
+		return v.Op == token.TILDE
+	case *ast.ParenExpr:
+		return isTypeSetElement(v.X)
+	case *ast.Ident:
+		obj, ok := types.Universe.Lookup(v.Name).(*types.TypeName)
+		return ok && !types.IsInterface(obj.Type())
+	case *ast.ArrayType, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.StructType, *ast.StarExpr:
+		return true
+	default:
+		return false
+	}
+}
+
+// typeTerms splits the provided union expression into its terms.
+func typeTerms(cfg *Config, expr ast.Expr) []*TypeTerm {
+	switch v := expr.(type) {
+	case *ast.BinaryExpr:
+		if v.Op == token.OR {
+			return append(typeTerms(cfg, v.X), typeTerms(cfg, v.Y)...)
+		}
+	case *ast.UnaryExpr:
+		if v.Op == token.TILDE {
+			return []*TypeTerm{{cfg, true, v.X}}
+		}
+	case *ast.ParenExpr:
+		return typeTerms(cfg, v.X)
+	}
+
+	return []*TypeTerm{{cfg, false, expr}}
+}
+
+// typeExprHref produces the href for the documentation of the named type
+// referred to by the provided type expression. Instantiations of generic types
+// link to the generic type itself. An empty string is returned for any other
+// kind of expression.
+func typeExprHref(cfg *Config, expr ast.Expr) string {
+	switch v := expr.(type) {
+	case *ast.IndexExpr:
+		return typeExprHref(cfg, v.X)
+	case *ast.IndexListExpr:
+		return typeExprHref(cfg, v.X)
+	case *ast.ParenExpr:
+		return typeExprHref(cfg, v.X)
+	case *ast.Ident:
+		if cfg.TypeInfo != nil {
+			href, _ := objectHref(cfg, cfg.TypeInfo.ObjectOf(cfg.FileSet, v))
+			return href
+		}
+
+		if sym, ok := cfg.Symbols[v.Name]; ok && sym.Kind == TypeSymbolKind {
+			return fmt.Sprintf("#%s", cfg.anchor(sym))
+		}
+
+		if _, ok := types.Universe.Lookup(v.Name).(*types.TypeName); ok {
+			return externalHref(cfg, "builtin", "", v.Name)
+		}
+	case *ast.SelectorExpr:
+		if cfg.TypeInfo != nil {
+			href, _ := objectHref(cfg, cfg.TypeInfo.ObjectOf(cfg.FileSet, v.Sel))
+			return href
+		}
+
+		pkg, ok := v.X.(*ast.Ident)
+		if !ok || cfg.Pkg == nil {
+			return ""
+		}
+
+		if importPath, ok := cfg.Pkg.Parser().LookupPackage(pkg.Name); ok {
+			href, _ := symbolHref(cfg, importPath, "", v.Sel.Name)
+			return href
+		}
+	}
+
+	return ""
+}
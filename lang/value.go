@@ -22,6 +22,42 @@ func (v *Value) Level() int {
 	return v.cfg.Level
 }
 
+// Deprecated reports whether the documentation of the const or var declaration
+// marks it as deprecated. Following the Go convention, this is the case when a
+// paragraph of the documentation begins with "Deprecated: ".
+func (v *Value) Deprecated() bool {
+	return isDeprecated(v.doc.Doc)
+}
+
+// DeprecationNotice provides the text following "Deprecated: " in the
+// documentation of the const or var declaration, or an empty string if it is
+// not deprecated.
+func (v *Value) DeprecationNotice() string {
+	notice, _ := deprecationNotice(v.doc.Doc)
+	return notice
+}
+
+// Availability describes the build contexts under which the const or var
+// declaration is available, such as "linux, darwin only" or "requires tag:
+// enterprise", if the package was documented with a build matrix. An empty
+// string is returned if the declaration is available under all of the
+// contexts or no build matrix was used.
+func (v *Value) Availability() string {
+	return v.cfg.availability.node(v.cfg, v.doc.Decl)
+}
+
+// Group provides the name of the group the const or var declaration was placed
+// in with a //gomarkdoc:group directive, or an empty string if it has none.
+func (v *Value) Group() string {
+	return v.directives().group
+}
+
+// Order provides the position given to the const or var declaration with a
+// //gomarkdoc:order directive, or 0 if it has none.
+func (v *Value) Order() int {
+	return v.directives().order
+}
+
 // Location returns a representation of the node's location in a file within a
 // repository.
 func (v *Value) Location() Location {
@@ -37,7 +73,7 @@ func (v *Value) Summary() string {
 // Doc provides the structured contents of the documentation comment for the
 // example.
 func (v *Value) Doc() *Doc {
-	return NewDoc(v.cfg.Inc(1), v.doc.Doc)
+	return NewDoc(v.cfg.docConfig(1, v.Location(), v.cfg.precedingComment(v.doc.Decl)), v.doc.Doc)
 }
 
 // Decl provides the raw text representation of the code for declaring the const
@@ -46,6 +82,15 @@ func (v *Value) Decl() (string, error) {
 	return printNode(v.doc.Decl, v.cfg.FileSet)
 }
 
+// DeclSpans provides the code for declaring the const or var as a set of
+// spans. If type information is available for the package, identifiers that
+// refer to other symbols are provided as link spans pointing to the
+// documentation for those symbols. Otherwise, the declaration is provided as a
+// single span of raw text.
+func (v *Value) DeclSpans() ([]*Span, error) {
+	return codeSpans(v.cfg, v.doc.Decl, v.cfg.FileSet)
+}
+
 // Anchor produces anchor text for the value.
 func (v *Value) Anchor() string {
 	var kind SymbolKind
@@ -55,8 +100,12 @@ func (v *Value) Anchor() string {
 		kind = VarSymbolKind
 	}
 
-	return Symbol{
+	return v.cfg.anchor(Symbol{
 		Kind: kind,
 		Name: v.doc.Names[0],
-	}.Anchor()
+	})
+}
+
+func (v *Value) directives() directives {
+	return v.cfg.directives[v.doc.Names[0]]
 }
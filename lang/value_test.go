@@ -54,6 +54,22 @@ func TestValue_Location(t *testing.T) {
 	is.True(strings.HasSuffix(loc.Filepath, "value.go"))
 }
 
+func TestValue_Deprecated(t *testing.T) {
+	is := is.New(t)
+
+	val, err := loadValue("../testData/deprecated", "OldVersion")
+	is.NoErr(err)
+
+	is.True(val.Deprecated())
+	is.Equal(val.DeprecationNotice(), "Use Version instead.")
+
+	val, err = loadValue("../testData/deprecated", "const Version")
+	is.NoErr(err)
+
+	is.True(!val.Deprecated())
+	is.Equal(val.DeprecationNotice(), "")
+}
+
 func loadValue(dir, name string) (*lang.Value, error) {
 	buildPkg, err := getBuildPackage(dir)
 	if err != nil {
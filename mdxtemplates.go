@@ -0,0 +1,25 @@
+// Code generated by gentmpl.sh; DO NOT EDIT.
+
+package gomarkdoc
+
+var mdxTemplates = map[string]string{
+	"file": `{/* Code generated by gomarkdoc. DO NOT EDIT */}
+
+{{if .Header -}}
+	{{- .Header -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- range .Packages -}}
+	{{- template "package" . -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if .Footer -}}
+	{{- .Footer -}}
+	{{- spacer -}}
+{{- end -}}
+
+Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
+`,
+}
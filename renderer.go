@@ -14,17 +14,38 @@ type (
 	// Renderer provides capabilities for rendering various types of
 	// documentation with the configured format and templates.
 	Renderer struct {
-		templateOverrides map[string]string
-		tmpl              *template.Template
-		format            format.Format
-		templateFuncs     map[string]any
+		templateOverrides  map[string]string
+		tmpl               *template.Template
+		format             format.Format
+		templateFuncs      map[string]any
+		collapseDeprecated bool
+		tableOfContents    bool
+		fieldStyle         FieldStyle
+		symbolOrder        lang.SymbolOrder
 	}
 
 	// RendererOption configures the renderer's behavior.
 	RendererOption func(renderer *Renderer) error
+
+	// FieldStyle identifies the style used to render the fields of struct
+	// types.
+	FieldStyle string
+)
+
+const (
+	// FieldStyleList renders the fields of struct types as a definition list,
+	// with each field's name, type and tag followed by its documentation.
+	FieldStyleList FieldStyle = "list"
+
+	// FieldStyleTable renders the fields of struct types as a table with a row
+	// for each field.
+	FieldStyleTable FieldStyle = "table"
 )
 
 //go:generate ./gentmpl.sh templates templates
+//go:generate ./gentmpl.sh htmlTemplates htmltemplates templates/html
+//go:generate ./gentmpl.sh gitlabTemplates gitlabtemplates templates/gitlab
+//go:generate ./gentmpl.sh mdxTemplates mdxtemplates templates/mdx
 
 // NewRenderer initializes a Renderer configured using the provided options. If
 // nothing special is provided, the created renderer will use the default set of
@@ -34,6 +55,8 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 		templateOverrides: make(map[string]string),
 		format:            &format.GitHubFlavoredMarkdown{},
 		templateFuncs:     map[string]any{},
+		fieldStyle:        FieldStyleList,
+		symbolOrder:       lang.AlphabeticalOrder,
 	}
 
 	for _, opt := range opts {
@@ -42,10 +65,13 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 		}
 	}
 
+	formatTmpls := formatTemplates(renderer.format)
 	for name, tmplStr := range templates {
-		// Use the override if present
+		// Use the override if present, then the format's own template
 		if val, ok := renderer.templateOverrides[name]; ok {
 			tmplStr = val
+		} else if val, ok := formatTmpls[name]; ok {
+			tmplStr = val
 		}
 
 		if renderer.tmpl == nil {
@@ -64,6 +90,23 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 	return renderer, nil
 }
 
+// formatTemplates provides the templates that take the place of the default
+// templates for the provided format, if any. The default templates produce
+// markdown, so formats that produce anything else need their own. Formats may
+// also replace individual templates to make use of features specific to them.
+func formatTemplates(f format.Format) map[string]string {
+	switch f.(type) {
+	case *format.HTML:
+		return htmlTemplates
+	case *format.GitLabFlavoredMarkdown:
+		return gitlabTemplates
+	case *format.MDX:
+		return mdxTemplates
+	default:
+		return nil
+	}
+}
+
 // WithTemplateOverride adds a template that overrides the template with the
 // provided name using the value provided in the tmpl parameter.
 func WithTemplateOverride(name, tmpl string) RendererOption {
@@ -87,6 +130,55 @@ func WithFormat(format format.Format) RendererOption {
 	}
 }
 
+// WithDeprecatedCollapsed changes the renderer to collapse the documentation
+// for deprecated symbols into an accordion, leaving only the symbol's header
+// visible by default.
+func WithDeprecatedCollapsed() RendererOption {
+	return func(renderer *Renderer) error {
+		renderer.collapseDeprecated = true
+		return nil
+	}
+}
+
+// WithTableOfContents changes the renderer to add a table of contents to the
+// top of each file, for formats whose file template supports it. Only the
+// gitlab format's template does, using GitLab's [[_TOC_]] tag.
+func WithTableOfContents() RendererOption {
+	return func(renderer *Renderer) error {
+		renderer.tableOfContents = true
+		return nil
+	}
+}
+
+// WithFieldStyle changes the style used to render the fields of struct types.
+// Fields are rendered as a definition list by default.
+func WithFieldStyle(style FieldStyle) RendererOption {
+	return func(renderer *Renderer) error {
+		switch style {
+		case FieldStyleList, FieldStyleTable:
+		default:
+			return fmt.Errorf(`gomarkdoc: invalid field style "%s"`, style)
+		}
+
+		renderer.fieldStyle = style
+		return nil
+	}
+}
+
+// WithSymbolOrder changes the order in which the top-level symbols of each
+// package are documented, and how they are grouped. Symbols are documented in
+// lang.AlphabeticalOrder by default.
+func WithSymbolOrder(order lang.SymbolOrder) RendererOption {
+	return func(renderer *Renderer) error {
+		if _, err := lang.ParseSymbolOrder(string(order)); err != nil {
+			return err
+		}
+
+		renderer.symbolOrder = order
+		return nil
+	}
+}
+
 // WithTemplateFunc adds the provided function with the given name to the list
 // of functions that can be used by the rendering templates.
 //
@@ -140,6 +232,11 @@ func (out *Renderer) Example(ex *lang.Example) (string, error) {
 // data object to a string. It uses the set of templates provided to the
 // renderer as a template library.
 func (out *Renderer) writeTemplate(name string, data interface{}) (string, error) {
+	// Each rendered output gets its own set of header ids
+	if r, ok := out.format.(format.Resetter); ok {
+		r.Reset()
+	}
+
 	var result strings.Builder
 	if err := out.tmpl.ExecuteTemplate(&result, name, data); err != nil {
 		return "", err
@@ -175,6 +272,18 @@ func (out *Renderer) getTemplate(name string) *template.Template {
 
 			return b.String(), nil
 		},
+		"collapseDeprecated": func() bool {
+			return out.collapseDeprecated
+		},
+		"tableOfContents": func() bool {
+			return out.tableOfContents
+		},
+		"fieldStyle": func() string {
+			return string(out.fieldStyle)
+		},
+		"symbolOrder": func() lang.SymbolOrder {
+			return out.symbolOrder
+		},
 		"iter": func(l any) (any, error) {
 			type iter struct {
 				First bool
@@ -202,14 +311,19 @@ func (out *Renderer) getTemplate(name string) *template.Template {
 		},
 
 		"bold":                out.format.Bold,
+		"badge":               out.badge,
 		"anchor":              out.format.Anchor,
 		"anchorHeader":        out.format.AnchorHeader,
 		"header":              out.format.Header,
 		"rawAnchorHeader":     out.format.RawAnchorHeader,
 		"rawHeader":           out.format.RawHeader,
+		"code":                out.code,
 		"codeBlock":           out.format.CodeBlock,
+		"linkedCodeBlock":     out.linkedCodeBlock,
 		"link":                out.format.Link,
 		"listEntry":           out.format.ListEntry,
+		"tableHeader":         out.tableHeader,
+		"tableRow":            out.tableRow,
 		"accordion":           out.format.Accordion,
 		"accordionHeader":     out.format.AccordionHeader,
 		"accordionTerminator": out.format.AccordionTerminator,
@@ -226,3 +340,33 @@ func (out *Renderer) getTemplate(name string) *template.Template {
 	tmpl.Funcs(baseTemplateFuncs)
 	return tmpl
 }
+
+// linkedCodeBlock renders a code block with links using the renderer's format,
+// falling back to a plain code block if the format doesn't support links.
+func (out *Renderer) linkedCodeBlock(language string, spans []*lang.Span) (string, error) {
+	return format.LinkedCodeBlock(out.format, language, spans)
+}
+
+// badge formats a badge using the renderer's format, falling back to plain text
+// if the format doesn't support badges.
+func (out *Renderer) badge(text string) (string, error) {
+	return format.Badge(out.format, text)
+}
+
+// code formats inline code using the renderer's format, falling back to plain
+// text if the format doesn't support inline code.
+func (out *Renderer) code(code string) (string, error) {
+	return format.Code(out.format, code)
+}
+
+// tableHeader generates the header row of a table using the renderer's format,
+// falling back to a markdown table if the format doesn't support tables.
+func (out *Renderer) tableHeader(columns ...string) (string, error) {
+	return format.TableHeader(out.format, columns...)
+}
+
+// tableRow generates a row of a table using the renderer's format, falling
+// back to a markdown table if the format doesn't support tables.
+func (out *Renderer) tableRow(cells ...string) (string, error) {
+	return format.TableRow(out.format, cells...)
+}
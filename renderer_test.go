@@ -9,6 +9,7 @@ import (
 
 	"github.com/matryer/is"
 	"github.com/princjef/gomarkdoc"
+	"github.com/princjef/gomarkdoc/format"
 	"github.com/princjef/gomarkdoc/format/formatcore"
 	"github.com/princjef/gomarkdoc/lang"
 	"github.com/princjef/gomarkdoc/logger"
@@ -40,6 +41,43 @@ func TestWithTemplateFunc(t *testing.T) {
 	is.True(strings.Contains(f2, "FUNC IS PRESENT IN THIS FILE."))
 }
 
+// baseFormat only implements format.Format, like formats written before the
+// optional format interfaces were added.
+type baseFormat struct {
+	format.Format
+}
+
+func TestWithFormat_baseFormat(t *testing.T) {
+	is := is.New(t)
+
+	fn, err := loadFunc("./testData/docs", "Func")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithFormat(baseFormat{&format.GitHubFlavoredMarkdown{}}))
+	is.NoErr(err)
+
+	f, err := r.Func(fn)
+	is.NoErr(err)
+
+	is.True(strings.Contains(f, "```go\nfunc Func(param int) int\n```"))
+}
+
+func TestWithFieldStyle_invalid(t *testing.T) {
+	is := is.New(t)
+
+	_, err := gomarkdoc.NewRenderer(gomarkdoc.WithFieldStyle("grid"))
+	is.True(err != nil)
+	is.Equal(err.Error(), `gomarkdoc: invalid field style "grid"`)
+}
+
+func TestWithSymbolOrder_invalid(t *testing.T) {
+	is := is.New(t)
+
+	_, err := gomarkdoc.NewRenderer(gomarkdoc.WithSymbolOrder("random"))
+	is.True(err != nil)
+	is.Equal(err.Error(), `gomarkdoc: invalid symbol order "random"`)
+}
+
 func getBuildPackage(path string) (*build.Package, error) {
 	wd, err := os.Getwd()
 	if err != nil {
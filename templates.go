@@ -37,6 +37,53 @@ var templates = map[string]string{
 
 {{- accordionTerminator -}}
 
+`,
+	"fields": `{{- if eq fieldStyle "table" -}}
+
+	{{- tableHeader "Field" "Type" "Description" -}}
+
+	{{- range .Fields -}}
+		{{- inlineSpacer -}}
+
+		{{- $name := escape .Name -}}
+		{{- if .Deprecated -}}
+			{{- $name = printf "%s \\(deprecated\\)" $name -}}
+		{{- end -}}
+
+		{{- $type := code .Type -}}
+		{{- if .Tag -}}
+			{{- $type = printf "%s %s" $type (code .Tag) -}}
+		{{- end -}}
+
+		{{- tableRow (printf "%s%s" (anchor .Anchor) $name) $type (include "doc" .Doc) -}}
+	{{- end -}}
+
+{{- else -}}
+
+	{{- range (iter .Fields) -}}
+		{{- $term := "" -}}
+		{{- if .Entry.Embedded -}}
+			{{- $term = code .Entry.Type -}}
+		{{- else -}}
+			{{- $term = printf "%s %s" .Entry.Name .Entry.Type | code -}}
+		{{- end -}}
+		{{- if .Entry.Tag -}}
+			{{- $term = printf "%s %s" $term (code .Entry.Tag) -}}
+		{{- end -}}
+		{{- if .Entry.Deprecated -}}
+			{{- $term = printf "%s \\(deprecated\\)" $term -}}
+		{{- end -}}
+
+		{{- $entry := printf "%s%s" (anchor .Entry.Anchor) $term -}}
+		{{- if len .Entry.Doc.Blocks -}}
+			{{- $entry = printf "%s\n\n%s" $entry (include "doc" .Entry.Doc) -}}
+		{{- end -}}
+
+		{{- hangingIndent $entry 2 | listEntry 0 -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+
+{{- end -}}
 `,
 	"file": `<!-- Code generated by gomarkdoc. DO NOT EDIT -->
 
@@ -57,66 +104,166 @@ var templates = map[string]string{
 
 Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 `,
-	"func": `{{- if .Receiver -}}
-	{{- rawAnchorHeader .Level (codeHref .Location | link (escape .Name) | printf "func \\(%s\\) %s" (escape .Receiver)) .Anchor -}}
+	"func": `{{- $title := "" -}}
+{{- if .Receiver -}}
+	{{- $title = codeHref .Location | link (escape .Name) | printf "func \\(%s\\) %s" (escape .Receiver) -}}
 {{- else -}}
-	{{- rawAnchorHeader .Level (codeHref .Location | link (escape .Name) | printf "func %s") .Anchor -}}
+	{{- $title = codeHref .Location | link (escape .Name) | printf "func %s" -}}
+{{- end -}}
+{{- if .Deprecated -}}
+	{{- $title = printf "%s \\(deprecated\\)" $title -}}
 {{- end -}}
+{{- rawAnchorHeader .Level $title .Anchor -}}
 {{- spacer -}}
 
-{{- codeBlock "go" .Signature -}}
-{{- spacer -}}
+{{- if .Availability -}}
+	{{- badge .Availability -}}
+	{{- spacer -}}
+{{- end -}}
 
-{{- template "doc" .Doc -}}
+{{- if .Since -}}
+	{{- printf "Added in %s" .Since | badge -}}
+	{{- spacer -}}
+{{- end -}}
 
-{{- if len .Examples -}}
+{{- $collapse := and .Deprecated collapseDeprecated -}}
+{{- if $collapse -}}
+	{{- accordionHeader "Deprecated" -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if not .ExampleOnly -}}
+	{{- linkedCodeBlock "go" .SignatureSpans -}}
 	{{- spacer -}}
 
+	{{- template "doc" .Doc -}}
+
+	{{- if len .TypeParams -}}
+		{{- spacer -}}
+		{{- template "typeparams" .TypeParams -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .Examples -}}
+	{{- if not .ExampleOnly -}}{{- spacer -}}{{- end -}}
+
 	{{- range (iter .Examples) -}}
 		{{- template "example" .Entry -}}
 		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
 	{{- end -}}
 {{- end -}}
 
+{{- if $collapse -}}
+	{{- spacer -}}
+	{{- accordionTerminator -}}
+{{- end -}}
 `,
-	"import": `{{- codeBlock "go" .Import -}}`,
-	"index": `{{- if len .Consts -}}
-
-	{{- localHref "Constants" | link "Constants" | listEntry 0 -}}
-	{{- inlineSpacer -}}
-	
+	"group": `{{- if .Name -}}
+	{{- if .Anchor -}}
+		{{- anchorHeader .Level .Name .Anchor -}}
+	{{- else -}}
+		{{- header .Level .Name -}}
+	{{- end -}}
+	{{- spacer -}}
 {{- end -}}
 
-{{- if len .Vars -}}
-
-	{{- localHref "Variables" | link "Variables" | listEntry 0 -}}
-	{{- inlineSpacer -}}
-
+{{- range (iter .Entries) -}}
+	{{- if .Entry.Value -}}
+		{{- template "value" .Entry.Value -}}
+	{{- else if .Entry.Func -}}
+		{{- template "func" .Entry.Func -}}
+	{{- else -}}
+		{{- template "type" .Entry.Type -}}
+	{{- end -}}
+	{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
 {{- end -}}
+`,
+	"import": `{{- codeBlock "go" .Import -}}`,
+	"index": `{{- range (.Groups symbolOrder) -}}
+
+	{{- $depth := 0 -}}
+	{{- if .Name -}}
+		{{- if .Anchor -}}
+			{{- rawLocalHref .Anchor | link .Name | listEntry 0 -}}
+		{{- else -}}
+			{{- localHref .Name | link .Name | listEntry 0 -}}
+		{{- end -}}
+		{{- inlineSpacer -}}
+		{{- $depth = 1 -}}
+	{{- end -}}
 
-{{- range .Funcs -}}
+	{{- range .Entries -}}
+
+		{{- if .Func -}}
+			{{- if .Func.Deprecated -}}
+				{{- (link .Func.Signature (rawLocalHref .Func.Anchor)) | printf "%s \\(deprecated\\)" | listEntry $depth -}}
+			{{- else -}}
+				{{- (link .Func.Signature (rawLocalHref .Func.Anchor)) | listEntry $depth -}}
+			{{- end -}}
+			{{- inlineSpacer -}}
+		{{- end -}}
+
+		{{- if .Type -}}
+			{{- if .Type.Deprecated -}}
+				{{- (link .Type.Title (rawLocalHref .Type.Anchor)) | printf "%s \\(deprecated\\)" | listEntry $depth -}}
+			{{- else -}}
+				{{- (link .Type.Title (rawLocalHref .Type.Anchor)) | listEntry $depth -}}
+			{{- end -}}
+			{{- inlineSpacer -}}
+
+			{{- range .Type.Funcs -}}
+				{{- if .Deprecated -}}
+					{{- (link .Signature (rawLocalHref .Anchor)) | printf "%s \\(deprecated\\)" | listEntry (add $depth 1) -}}
+				{{- else -}}
+					{{- (link .Signature (rawLocalHref .Anchor)) | listEntry (add $depth 1) -}}
+				{{- end -}}
+				{{- inlineSpacer -}}
+			{{- end -}}
+
+			{{- range .Type.Methods -}}
+				{{- if .Deprecated -}}
+					{{- (link .Signature (rawLocalHref .Anchor)) | printf "%s \\(deprecated\\)" | listEntry (add $depth 1) -}}
+				{{- else -}}
+					{{- (link .Signature (rawLocalHref .Anchor)) | listEntry (add $depth 1) -}}
+				{{- end -}}
+				{{- inlineSpacer -}}
+			{{- end -}}
+		{{- end -}}
 
-	{{- (link .Signature (rawLocalHref .Anchor)) | listEntry 0 -}}
-	{{- inlineSpacer -}}
+	{{- end -}}
 
 {{- end -}}
+`,
+	"inherited": `{{- accordionHeader (printf "Inherited from %s" .Origin) -}}
+{{- spacer -}}
 
-{{- range .Types -}}
-
-	{{- (link .Title (rawLocalHref .Anchor)) | listEntry 0 -}}
-	{{- inlineSpacer -}}
+{{- if .Href -}}
+	{{- link .Origin .Href | printf "Methods and fields promoted from %s." -}}
+{{- else -}}
+	{{- escape .Origin | printf "Methods and fields promoted from %s." -}}
+{{- end -}}
+{{- spacer -}}
 
-	{{- range .Funcs -}}
-		{{- (link .Signature (rawLocalHref .Anchor)) | listEntry 1 -}}
-		{{- inlineSpacer -}}
+{{- range .Fields -}}
+	{{- if .Href -}}
+		{{- link .Signature .Href | listEntry 0 -}}
+	{{- else -}}
+		{{- escape .Signature | listEntry 0 -}}
 	{{- end -}}
+	{{- inlineSpacer -}}
+{{- end -}}
 
-	{{- range .Methods -}}
-		{{- (link .Signature (rawLocalHref .Anchor)) | listEntry 1 -}}
-		{{- inlineSpacer -}}
+{{- range .Methods -}}
+	{{- if .Href -}}
+		{{- link .Signature .Href | listEntry 0 -}}
+	{{- else -}}
+		{{- escape .Signature | listEntry 0 -}}
 	{{- end -}}
-
+	{{- inlineSpacer -}}
 {{- end -}}
+{{- inlineSpacer -}}
+
+{{- accordionTerminator -}}
 `,
 	"list": `{{- range (iter .Items) -}}
     {{- if eq .Entry.Kind "ordered" -}}
@@ -134,10 +281,14 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
     {{- end -}}
 
 {{- end -}}`,
-	"package": `{{- if eq .Name "main" -}}
-	{{- header .Level .Dirname -}}
+	"package": `{{- $title := .Name -}}
+{{- if eq .Name "main" -}}
+	{{- $title = .Dirname -}}
+{{- end -}}
+{{- if .Anchor -}}
+	{{- anchorHeader .Level $title .Anchor -}}
 {{- else -}}
-	{{- header .Level .Name -}}
+	{{- header .Level $title -}}
 {{- end -}}
 {{- spacer -}}
 
@@ -159,68 +310,118 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 
 {{- template "index" . -}}
 
-{{- if len .Consts -}}
-	{{- spacer -}}
-
-	{{- header (add .Level 1) "Constants" -}}
+{{- range (.Groups symbolOrder) -}}
 	{{- spacer -}}
-
-	{{- range (iter .Consts) -}}
-		{{- template "value" .Entry -}}
-		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- template "group" . -}}
+{{- end -}}
+`,
+	"text": `{{- range . -}}
+	{{- if eq .Kind "text" -}}
+		{{- escape .Text -}}
+	{{- else if eq .Kind "rawText" -}}
+		{{- .Text -}}
+	{{- else if eq .Kind "autolink" -}}
+		{{- .Text -}}
+	{{- else if eq .Kind "link" -}}
+		{{- link (escape .Text) .URL -}}
 	{{- end -}}
+{{- end -}}`,
+	"type": `{{- $title := codeHref .Location | link (escape .Name) | printf "type %s" -}}
+{{- if .Deprecated -}}
+	{{- $title = printf "%s \\(deprecated\\)" $title -}}
+{{- end -}}
+{{- rawAnchorHeader .Level $title .Anchor -}}
+{{- spacer -}}
 
+{{- if .Availability -}}
+	{{- badge .Availability -}}
+	{{- spacer -}}
 {{- end -}}
 
-{{- if len .Vars -}}
+{{- if .Since -}}
+	{{- printf "Added in %s" .Since | badge -}}
 	{{- spacer -}}
+{{- end -}}
 
-	{{- header (add .Level 1) "Variables" -}}
+{{- $collapse := and .Deprecated collapseDeprecated -}}
+{{- if $collapse -}}
+	{{- accordionHeader "Deprecated" -}}
 	{{- spacer -}}
+{{- end -}}
 
-	{{- range (iter .Vars) -}}
-		{{- template "value" .Entry -}}
+{{- if .ExampleOnly -}}
+	{{- range (iter .Examples) -}}
+		{{- template "example" .Entry -}}
 		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
 	{{- end -}}
+{{- else -}}
+	{{- template "doc" .Doc -}}
+	{{- spacer -}}
+
+	{{- linkedCodeBlock "go" .DeclSpans -}}
+
+	{{- if len .TypeParams -}}
+		{{- spacer -}}
+		{{- template "typeparams" .TypeParams -}}
+	{{- end -}}
 
-{{- end -}}
+	{{- if len .TypeSet -}}
+		{{- spacer -}}
 
-{{- if len .Funcs -}}
-	{{- spacer -}}
+		{{- bold "Type set" -}}
+		{{- spacer -}}
 
-	{{- range (iter .Funcs) -}}
-		{{- template "func" .Entry -}}
-		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+		{{- range (iter .TypeSet) -}}
+			{{- if .Entry.Href -}}
+				{{- link .Entry.Text .Entry.Href | listEntry 0 -}}
+			{{- else -}}
+				{{- code .Entry.Text | listEntry 0 -}}
+			{{- end -}}
+			{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+		{{- end -}}
 	{{- end -}}
-{{- end -}}
 
-{{- if len .Types -}}
-	{{- spacer -}}
+	{{- if len .Fields -}}
+		{{- spacer -}}
 
-	{{- range (iter .Types) -}}
-		{{- template "type" .Entry -}}
-		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+		{{- bold "Fields" -}}
+		{{- spacer -}}
+
+		{{- template "fields" . -}}
 	{{- end -}}
-{{- end -}}
-`,
-	"text": `{{- range . -}}
-	{{- if eq .Kind "text" -}}
-		{{- escape .Text -}}
-	{{- else if eq .Kind "rawText" -}}
-		{{- .Text -}}
-	{{- else if eq .Kind "autolink" -}}
-		{{- .Text -}}
-	{{- else if eq .Kind "link" -}}
-		{{- link (escape .Text) .URL -}}
+
+	{{- if len .Implements -}}
+		{{- spacer -}}
+
+		{{- bold "Implements" -}}
+		{{- spacer -}}
+
+		{{- range (iter .Implements) -}}
+			{{- if .Entry.Pointer -}}
+				{{- link .Entry.Name .Entry.Href | printf "%s (pointer receiver)" | listEntry 0 -}}
+			{{- else -}}
+				{{- link .Entry.Name .Entry.Href | listEntry 0 -}}
+			{{- end -}}
+			{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+		{{- end -}}
 	{{- end -}}
-{{- end -}}`,
-	"type": `{{- rawAnchorHeader .Level (codeHref .Location | link (escape .Name) | printf "type %s") .Anchor -}}
-{{- spacer -}}
 
-{{- template "doc" .Doc -}}
-{{- spacer -}}
+	{{- if len .Implementors -}}
+		{{- spacer -}}
 
-{{- codeBlock "go" .Decl -}}
+		{{- bold "Implemented by" -}}
+		{{- spacer -}}
+
+		{{- range (iter .Implementors) -}}
+			{{- if .Entry.Pointer -}}
+				{{- link (printf "*%s" .Entry.Name) .Entry.Href | listEntry 0 -}}
+			{{- else -}}
+				{{- link .Entry.Name .Entry.Href | listEntry 0 -}}
+			{{- end -}}
+			{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
 
 {{- if len .Consts -}}
 	{{- spacer -}}
@@ -240,7 +441,7 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 	{{- end -}}
 {{- end -}}
 
-{{- if len .Examples -}}
+{{- if and (len .Examples) (not .ExampleOnly) -}}
 	{{- spacer -}}
 	
 	{{- range (iter .Examples) -}}
@@ -267,12 +468,60 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 	{{- end -}}
 {{- end -}}
 
+
+{{- if len .Inherited -}}
+	{{- spacer -}}
+
+	{{- range (iter .Inherited) -}}
+		{{- template "inherited" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if $collapse -}}
+	{{- spacer -}}
+	{{- accordionTerminator -}}
+{{- end -}}
+`,
+	"typeparams": `{{- bold "Type parameters" -}}
+{{- spacer -}}
+
+{{- range (iter .) -}}
+	{{- $constraint := "" -}}
+	{{- if .Entry.ConstraintHref -}}
+		{{- $constraint = link .Entry.Constraint .Entry.ConstraintHref -}}
+	{{- else -}}
+		{{- $constraint = code .Entry.Constraint -}}
+	{{- end -}}
+
+	{{- printf "%s %s" (code .Entry.Name) $constraint | listEntry 0 -}}
+	{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+{{- end -}}
 `,
 	"value": `{{- anchor .Anchor -}}
+
+{{- if .Availability -}}
+	{{- badge .Availability -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- $collapse := and .Deprecated collapseDeprecated -}}
+{{- if $collapse -}}
+	{{- accordionHeader "Deprecated" -}}
+	{{- spacer -}}
+{{- else if .Deprecated -}}
+	{{- bold "Deprecated" -}}
+	{{- spacer -}}
+{{- end -}}
+
 {{- template "doc" .Doc -}}
 {{- spacer -}}
 
-{{- codeBlock "go" .Decl -}}
+{{- linkedCodeBlock "go" .DeclSpans -}}
 
+{{- if $collapse -}}
+	{{- spacer -}}
+	{{- accordionTerminator -}}
+{{- end -}}
 `,
 }
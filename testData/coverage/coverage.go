@@ -0,0 +1,47 @@
+// Package coverage exercises the documentation coverage report.
+package coverage
+
+// Limit is documented.
+const Limit = 10
+
+const Undocumented = 1
+
+// Modes of operation.
+const (
+	Fast = iota
+	// Slow is documented separately.
+	Slow
+)
+
+// Some comment that doesn't start with the name.
+var Default = Config{}
+
+// Config holds the configuration.
+type Config struct {
+	// Name is the name.
+	Name    string
+	Timeout int
+	retries int
+}
+
+// NewConfig creates a config.
+func NewConfig() Config { return Config{} }
+
+// Apply applies the config.
+func (c Config) Apply() {}
+
+func (c Config) Reset() {}
+
+// Runner runs things.
+type Runner interface {
+	// Run runs.
+	Run()
+	Stop()
+}
+
+type hidden struct{}
+
+// Visible is exported, but its type isn't.
+func (hidden) Visible() {}
+
+func Do() {}
@@ -0,0 +1,11 @@
+package coverage_test
+
+import "github.com/princjef/gomarkdoc/testData/coverage"
+
+func ExampleNewConfig() {
+	coverage.NewConfig()
+}
+
+func ExampleConfig_Apply() {
+	coverage.NewConfig().Apply()
+}
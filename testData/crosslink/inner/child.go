@@ -0,0 +1,15 @@
+// Package inner is linked to from its parent package.
+package inner
+
+// Value is a value in the child package.
+type Value int
+
+// Child is in the child package.
+func Child() Value {
+	return 234
+}
+
+// Double doubles the value.
+func (v Value) Double() Value {
+	return v * 2
+}
@@ -0,0 +1,19 @@
+// Package crosslink has links to symbols in another package documented in the
+// same run. See [inner] for details.
+package crosslink
+
+import (
+	"strings"
+
+	"github.com/princjef/gomarkdoc/testData/crosslink/inner"
+)
+
+// Parent returns the result of [inner.Child] and [inner.Value.Double].
+func Parent() inner.Value {
+	return inner.Child().Double()
+}
+
+// External links to [strings.Builder], which is not part of the run.
+func External() *strings.Builder {
+	return nil
+}
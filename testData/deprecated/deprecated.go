@@ -0,0 +1,82 @@
+// Package deprecated exercises the rendering of deprecated symbols.
+package deprecated
+
+// Version is the current version of the package.
+const Version = "v2"
+
+// OldVersion is the version of the package before the rewrite.
+//
+// Deprecated: Use Version instead.
+const OldVersion = "v1"
+
+// Default holds the default settings.
+//
+// Deprecated: Use [NewSettings] to create settings instead.
+var Default = Settings{}
+
+// Settings holds the settings for a [Client].
+type Settings struct {
+	// Name holds the name of the client.
+	Name string
+
+	// Retries holds the number of times to retry a request.
+	//
+	// Deprecated: Retries are no longer supported and this field is ignored.
+	Retries int
+
+	Timeout int // Timeout holds the request timeout in seconds.
+}
+
+// NewSettings creates settings with the provided name.
+func NewSettings(name string) Settings {
+	return Settings{Name: name}
+}
+
+// Client sends requests.
+//
+// Deprecated: Use [Settings] with the new client package instead. This type
+// will be removed in the next major version.
+type Client struct{}
+
+// NewClient creates a Client.
+func NewClient() *Client {
+	return &Client{}
+}
+
+// DefaultClient is the client used by [Run].
+var DefaultClient *Client
+
+// Mode configures how requests are sent.
+type Mode int
+
+// Modes that requests can be sent with.
+const (
+	// ModeLegacy sends requests with the original protocol.
+	//
+	// Deprecated: Use ModeCurrent instead.
+	ModeLegacy Mode = iota
+
+	// ModeCurrent sends requests with the current protocol.
+	ModeCurrent
+)
+
+// Send sends a request.
+func (c *Client) Send() error {
+	return nil
+}
+
+// Run runs the default client.
+func Run() {}
+
+// Start starts the default client.
+//
+// Deprecated: Use [Run] instead.
+func Start() {}
+
+// Apply applies the settings.
+func (s Settings) Apply() {}
+
+// Reset resets the settings.
+//
+// Deprecated: Settings are immutable, so this does nothing.
+func (s *Settings) Reset() {}
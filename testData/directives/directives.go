@@ -0,0 +1,86 @@
+// Package directives uses gomarkdoc directives to curate its documentation.
+// See [Parser] for the public API.
+package directives
+
+// Limits used by the [Parser].
+//
+//gomarkdoc:group Configuration
+//gomarkdoc:order 2
+const (
+	// MaxDepth is the maximum nesting depth.
+	MaxDepth = 10
+
+	// MaxSize is the maximum input size.
+	MaxSize = 1024
+)
+
+// DebugMode enables debug output. It is for internal use only.
+//
+//gomarkdoc:hide
+var DebugMode = false
+
+// Options configures a [Parser].
+//
+//gomarkdoc:group Configuration
+//gomarkdoc:order 1
+type Options struct {
+	// Strict rejects unknown input.
+	Strict bool
+
+	// Trace is for internal use only.
+	//gomarkdoc:hide
+	Trace bool
+}
+
+// Parser parses input. It uses a [Tokenizer] internally.
+//
+//gomarkdoc:group Parsing
+type Parser struct{}
+
+// NewParser creates a [Parser] with the provided [Options].
+func NewParser(opts Options) *Parser {
+	return &Parser{}
+}
+
+// Parse parses the input. The input of each token is kept in [Token.Text].
+//
+//gomarkdoc:order 1
+func (p *Parser) Parse(input string) error {
+	return nil
+}
+
+// Reset resets the parser. It is only used by [Parser.Parse].
+//
+//gomarkdoc:hide
+func (p *Parser) Reset() {}
+
+// Tokenizer splits input into tokens. It is an implementation detail of
+// [Parser].
+//
+//gomarkdoc:hide
+type Tokenizer struct{}
+
+// NewTokenizer creates a [Tokenizer]. It is documented on its own because its
+// type is hidden.
+func NewTokenizer() *Tokenizer {
+	return &Tokenizer{}
+}
+
+// Quote quotes the input. This text is left out of the documentation in favor
+// of the example.
+//
+//gomarkdoc:example-only
+//gomarkdoc:group Parsing
+func Quote(input string) string {
+	return `"` + input + `"`
+}
+
+// Token is a token read by [Parser.Parse]. This text is left out of the
+// documentation in favor of the example.
+//
+//gomarkdoc:example-only
+//gomarkdoc:group Parsing
+type Token struct {
+	// Text is the input the token was read from.
+	Text string
+}
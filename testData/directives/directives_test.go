@@ -0,0 +1,18 @@
+package directives_test
+
+import (
+	"fmt"
+
+	"github.com/princjef/gomarkdoc/testData/directives"
+)
+
+func ExampleQuote() {
+	fmt.Println(directives.Quote("text"))
+	// Output: "text"
+}
+
+func ExampleToken() {
+	tok := directives.Token{Text: "text"}
+	fmt.Println(tok.Text)
+	// Output: text
+}
@@ -0,0 +1,42 @@
+// Package examples exercises the verification of example output.
+package examples
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Greet produces a greeting for the provided name.
+func Greet(name string) string {
+	return fmt.Sprintf("Hello, %s!", name)
+}
+
+// Set holds a set of unique strings.
+type Set map[string]struct{}
+
+// NewSet creates a Set from the provided values.
+func NewSet(values ...string) Set {
+	s := make(Set)
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+
+	return s
+}
+
+// Values lists the values in the set in no particular order.
+func (s Set) Values() []string {
+	values := make([]string, 0, len(s))
+	for v := range s {
+		values = append(values, v)
+	}
+
+	return values
+}
+
+// Sorted lists the values in the set in sorted order.
+func (s Set) Sorted() []string {
+	values := s.Values()
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,9 @@
+package examples
+
+import "fmt"
+
+func ExampleNewSet() {
+	s := NewSet("a", "a", "b")
+	fmt.Println(len(s))
+	// Output: 2
+}
@@ -0,0 +1,34 @@
+package examples_test
+
+import (
+	"fmt"
+
+	"github.com/princjef/gomarkdoc/testData/examples"
+)
+
+func Example() {
+	fmt.Println(examples.Greet("gopher"))
+	// Output: Hello, gopher!
+}
+
+func ExampleGreet() {
+	fmt.Println(examples.Greet("world"))
+	fmt.Println(examples.Greet("gomarkdoc"))
+	// Output:
+	// Hello, world!
+	// Hello, gomarkdoc!
+}
+
+func ExampleSet_Values() {
+	for _, v := range examples.NewSet("a", "b", "c").Values() {
+		fmt.Println(v)
+	}
+	// Unordered output:
+	// c
+	// a
+	// b
+}
+
+func ExampleSet_Sorted() {
+	fmt.Println(examples.NewSet("b", "a").Sorted())
+}
@@ -0,0 +1,8 @@
+// Package inpackage has an example declared in the package itself whose
+// documented output no longer matches the output it produces.
+package inpackage
+
+// Double doubles the provided number.
+func Double(n int) int {
+	return n * 3
+}
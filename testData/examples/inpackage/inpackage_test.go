@@ -0,0 +1,13 @@
+//go:build verifyexamples
+
+// The documented output of ExampleDouble is stale on purpose, so this example
+// is left out of regular test runs.
+
+package inpackage
+
+import "fmt"
+
+func ExampleDouble() {
+	fmt.Println(Double(2))
+	// Output: 4
+}
@@ -0,0 +1,10 @@
+// Package stale has examples whose documented output no longer matches the
+// output they produce.
+package stale
+
+import "strings"
+
+// Shout converts the provided text to upper case.
+func Shout(text string) string {
+	return strings.ToUpper(text) + "!"
+}
@@ -0,0 +1,22 @@
+//go:build verifyexamples
+
+// The documented output of ExampleShout is stale on purpose, so these examples
+// are left out of regular test runs.
+
+package stale_test
+
+import (
+	"fmt"
+
+	"github.com/princjef/gomarkdoc/testData/examples/stale"
+)
+
+func ExampleShout() {
+	fmt.Println(stale.Shout("hello"))
+	// Output: HELLO
+}
+
+func ExampleShout_matching() {
+	fmt.Println(stale.Shout("hi"))
+	// Output: HI!
+}
@@ -0,0 +1,37 @@
+// Package fields exercises the rendering of the fields of struct types.
+//
+// Links to fields such as [Request.Header] point to the field itself.
+package fields
+
+import "net/http"
+
+// Request describes an outgoing request.
+type Request struct {
+	// Method holds the HTTP method of the request, such as GET.
+	Method string `json:"method"`
+
+	// Header holds the headers to send with the request.
+	//
+	// Header names are case insensitive.
+	Header http.Header `json:"header,omitempty"`
+
+	// Width and Height hold the size of the request's payload.
+	Width, Height int
+
+	*Options
+
+	Body []byte // Body holds the raw payload | if any.
+
+	// Retries holds the number of retries to attempt.
+	//
+	// Deprecated: Use [Options.Attempts] instead.
+	Retries int `json:"retries"`
+
+	private bool
+}
+
+// Options holds additional options for a [Request].
+type Options struct {
+	// Attempts holds the maximum number of attempts made for a request.
+	Attempts int
+}
@@ -0,0 +1,46 @@
+// Package filters has symbols that are left out of its documentation by
+// filters. See [Client] for the public API and [InternalState] for the state
+// that is filtered out.
+package filters
+
+// Version is the version of the package.
+const Version = "1.0.0"
+
+// DefaultTimeout is the default timeout in seconds. It is used by [NewClient].
+var DefaultTimeout = 30
+
+// Client is the public API of the package. It keeps an [InternalState].
+type Client struct {
+	// Name is the name of the client.
+	Name string
+
+	// State is the internal state of the client. See [InternalState.Reset].
+	State InternalState
+}
+
+// NewClient creates a [Client].
+func NewClient(name string) *Client {
+	return &Client{Name: name}
+}
+
+// Do does something. It is the opposite of [Client.Undo].
+func (c *Client) Do() {}
+
+// Undo undoes something. It is the opposite of [Client.Do].
+func (c *Client) Undo() {}
+
+// InternalState holds the internal state of a [Client].
+type InternalState struct {
+	count int
+}
+
+// NewInternalState creates an [InternalState] for use by a [Client].
+func NewInternalState() InternalState {
+	return InternalState{}
+}
+
+// Reset resets the state.
+func (s *InternalState) Reset() {}
+
+// InternalHelper helps [Client.Do].
+func InternalHelper() {}
@@ -1,5 +1,7 @@
 package generics
 
+import "cmp"
+
 // Generic is a generic struct.
 type Generic[T any] struct {
 	Field T
@@ -17,3 +19,33 @@ func (g Generic[T]) Method() {}
 func Func[S int | float64](s S) S {
 	return s
 }
+
+// Number is a constraint for numeric types.
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Ordered is a constraint for ordered types, including any [Number].
+type Ordered interface {
+	Number | ~string
+}
+
+// Pair holds two values of different types.
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Max returns the larger of the provided values.
+func Max[T Ordered](a, b T) T {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// Min returns the smaller of the provided values.
+func Min[T cmp.Ordered](a, b T) T {
+	return min(a, b)
+}
@@ -0,0 +1,48 @@
+// Package implements exercises the cross-reference between interfaces and the
+// types that implement them.
+package implements
+
+// Shape is implemented by several types.
+type Shape interface {
+	// Area provides the area of the shape.
+	Area() float64
+}
+
+// Named is implemented through a pointer.
+type Named interface {
+	// Name provides the name of the value.
+	Name() string
+}
+
+// Empty has no methods, so nothing is listed as implementing it.
+type Empty interface{}
+
+// Number is a constraint, so nothing is listed as implementing it.
+type Number interface {
+	~int | ~float64
+}
+
+// Square is a Shape.
+type Square struct {
+	// Side is the length of each side.
+	Side float64
+}
+
+// Area provides the area of the square.
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+// Name provides the name of the square.
+func (s *Square) Name() string {
+	return "square"
+}
+
+// Point implements nothing.
+type Point struct {
+	// X is the horizontal position.
+	X float64
+
+	// Y is the vertical position.
+	Y float64
+}
@@ -0,0 +1,13 @@
+// Package plugins implements interfaces from another package.
+package plugins
+
+// Circle is a shape from another package.
+type Circle struct {
+	// Radius is the radius of the circle.
+	Radius float64
+}
+
+// Area provides the area of the circle.
+func (c Circle) Area() float64 {
+	return 3 * c.Radius * c.Radius
+}
@@ -0,0 +1,20 @@
+// Package links has doc links to symbols that are left out of its
+// documentation by filters, along with a link to a symbol that doesn't exist
+// in a package that is documented.
+package links
+
+// DefaultSize is the size used by [New] by default.
+const DefaultSize = 10
+
+// Old does things the old way.
+//
+// Deprecated: Use [New] instead.
+func Old() {}
+
+// New does things. It replaces [Old] and uses [DefaultSize] unless told
+// otherwise.
+func New() {}
+
+// Broken links to [github.com/princjef/gomarkdoc/testData/links.Missing],
+// which doesn't exist.
+func Broken() {}
@@ -0,0 +1,11 @@
+//go:build enterprise
+
+package matrix
+
+// License holds the license for enterprise features.
+type License struct {
+	Key string
+}
+
+// Audit records the provided event in the audit log.
+func Audit(event string) {}
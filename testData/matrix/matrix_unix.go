@@ -0,0 +1,20 @@
+//go:build linux || darwin
+
+package matrix
+
+// Separator is the path separator on unix platforms.
+const Separator = "/"
+
+// Socket is a unix domain socket.
+type Socket struct {
+	Path string
+}
+
+// Fd provides the file descriptor of the connection.
+func (c *Conn) Fd() uintptr {
+	return c.fd
+}
+
+func open(name string) (*Conn, error) {
+	return &Conn{}, nil
+}
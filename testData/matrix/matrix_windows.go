@@ -0,0 +1,13 @@
+package matrix
+
+// Separator is the path separator on windows.
+const Separator = `\`
+
+// Handle provides the windows handle of the connection.
+func (c *Conn) Handle() uintptr {
+	return c.fd
+}
+
+func open(name string) (*Conn, error) {
+	return &Conn{}, nil
+}
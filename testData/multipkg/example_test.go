@@ -0,0 +1,23 @@
+package multipkg_test
+
+import (
+	"fmt"
+
+	"github.com/princjef/gomarkdoc/testData/multipkg"
+)
+
+func Example() {
+	var c multipkg.Counter
+	c.Inc()
+	fmt.Println(c.Count())
+	// Output: 1
+}
+
+func ExampleCounter_Inc() {
+	var c multipkg.Counter
+	fmt.Println(c.Inc())
+	fmt.Println(c.Inc())
+	// Output:
+	// 1
+	// 2
+}
@@ -0,0 +1,16 @@
+//go:build ignore
+
+// This program is run by go generate and is not part of the package.
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("generated")
+}
+
+// Example is not an example of the multipkg package.
+func Example() {
+	main()
+	// Output: generated
+}
@@ -0,0 +1,21 @@
+// Package multipkg shares its directory with its external test package and a
+// generator that is excluded from the build.
+package multipkg
+
+//go:generate go run gen.go
+
+// Counter counts things.
+type Counter struct {
+	n int
+}
+
+// Inc increments the counter and returns the new count.
+func (c *Counter) Inc() int {
+	c.n++
+	return c.n
+}
+
+// Count provides the current count.
+func (c *Counter) Count() int {
+	return c.n
+}
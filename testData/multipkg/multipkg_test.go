@@ -0,0 +1,6 @@
+package multipkg
+
+func ExampleCounter_Count() {
+	c := Counter{n: 2}
+	println(c.Count())
+}
@@ -0,0 +1,27 @@
+// Package ordering documents its symbols in a custom order.
+package ordering
+
+// Client sends requests to the service.
+//
+//gomarkdoc:group Clients
+type Client struct{}
+
+// NewClient creates a [Client].
+func NewClient(cfg Config) *Client {
+	return &Client{}
+}
+
+// Send sends a request.
+func (c *Client) Send() error {
+	return nil
+}
+
+// Close closes the client.
+//
+//gomarkdoc:order -1
+func (c *Client) Close() error {
+	return nil
+}
+
+// Version is the version of the service API.
+const Version = "v1"
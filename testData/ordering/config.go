@@ -0,0 +1,20 @@
+package ordering
+
+// Config configures a [Client].
+//
+//gomarkdoc:group Configuration
+type Config struct{}
+
+// DefaultTimeout is the timeout in seconds used when none is configured.
+//
+//gomarkdoc:group Configuration
+//gomarkdoc:order 1
+var DefaultTimeout = 30
+
+// LoadEnv populates the configuration from the environment.
+//
+//gomarkdoc:group Configuration
+//gomarkdoc:order -1
+func LoadEnv(cfg *Config) error {
+	return nil
+}
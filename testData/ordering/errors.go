@@ -0,0 +1,13 @@
+package ordering
+
+import "errors"
+
+// ErrClosed is returned when the [Client] is closed.
+//
+//gomarkdoc:group Clients
+var ErrClosed = errors.New("closed")
+
+// Retry retries the provided func until it succeeds.
+func Retry(fn func() error) error {
+	return fn()
+}
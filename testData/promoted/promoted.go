@@ -0,0 +1,60 @@
+// Package promoted exercises methods and fields promoted from embedded types.
+package promoted
+
+import "sync"
+
+// Base is embedded by other types.
+type Base struct {
+	// ID identifies the value.
+	ID int
+
+	// Name is shadowed by Top.
+	Name string
+}
+
+// Hello says hello.
+func (b Base) Hello() string {
+	return "hello"
+}
+
+// Describe describes the value.
+func (b *Base) Describe() string {
+	return b.Name
+}
+
+// Middle embeds Base and is embedded by Top.
+type Middle struct {
+	Base
+
+	// Level is the level of the value.
+	Level int
+}
+
+// Goodbye says goodbye.
+func (m Middle) Goodbye() string {
+	return "goodbye"
+}
+
+// Other is also embedded by Top.
+type Other struct {
+	// Level conflicts with Middle.Level.
+	Level int
+
+	// Extra is unique to Other.
+	Extra bool
+}
+
+// Top embeds several other types.
+type Top struct {
+	Middle
+	Other
+	sync.Mutex
+
+	// Name shadows Base.Name.
+	Name string
+}
+
+// Describe shadows Base.Describe.
+func (t *Top) Describe() string {
+	return t.Name
+}
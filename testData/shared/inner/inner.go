@@ -0,0 +1,16 @@
+// Package inner is documented in the same file as the shared package.
+package inner
+
+// Config configures the inner package.
+type Config struct {
+	// Name is the name of the inner package.
+	Name string
+}
+
+// New creates a [Config] with the default name.
+func New() Config {
+	return Config{Name: Version}
+}
+
+// Version is the version of the inner package.
+const Version = "v1"
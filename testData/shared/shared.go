@@ -0,0 +1,25 @@
+// Package shared is documented in the same file as the inner package, which
+// has symbols with the same names.
+package shared
+
+import "github.com/princjef/gomarkdoc/testData/shared/inner"
+
+// Config configures a [Client]. The inner package is configured separately
+// with an [inner.Config].
+type Config struct {
+	// Name is the name of the client.
+	Name string
+}
+
+// Client does work for the shared package.
+type Client struct{}
+
+// New creates a [Client] with the provided [Config].
+func New(cfg Config) *Client {
+	return &Client{}
+}
+
+// Do does the work using an inner config created by [inner.New].
+func (c *Client) Do(cfg inner.Config) error {
+	return nil
+}
@@ -0,0 +1,45 @@
+// Package typecheck exercises linking of identifiers in declarations when
+// packages are type checked.
+package typecheck
+
+import (
+	"io"
+	"time"
+)
+
+// Kind identifies the kind of a Reader.
+type Kind int
+
+const (
+	// Fast is a fast Reader.
+	Fast Kind = iota
+
+	// Slow is a slow Reader.
+	Slow
+)
+
+// DefaultTimeout is the timeout used when none is provided.
+var DefaultTimeout time.Duration = 5 * time.Second
+
+// Reader wraps an io.Reader with some additional settings.
+type Reader struct {
+	Source  io.Reader
+	Kind    Kind
+	Timeout time.Duration
+	options map[string]bool
+}
+
+// NewReader creates a Reader of the provided kind around the source.
+func NewReader(src io.Reader, kind Kind) *Reader {
+	return &Reader{Source: src, Kind: kind, Timeout: DefaultTimeout}
+}
+
+// Read reads from the underlying source.
+func (r *Reader) Read(p []byte) (n int, err error) {
+	return r.Source.Read(p)
+}
+
+// Copy copies from the reader into the provided writer.
+func Copy[T io.Writer](dst T, r *Reader) (int64, error) {
+	return io.Copy(dst, r)
+}
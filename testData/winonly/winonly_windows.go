@@ -0,0 +1,4 @@
+package winonly
+
+// Only is only on windows.
+func Only() {}